@@ -178,6 +178,19 @@ func writeGoClient(pkg *schema.Package, outdir string, templateDir string) {
 		panic(err)
 	}
 
+	// Hand-written code for the package, such as the output forms of functions.
+	overlays, err := ioutil.ReadDir(templateDir)
+	if err != nil && !os.IsNotExist(err) {
+		panic(err)
+	}
+	for _, overlay := range overlays {
+		contents, err := ioutil.ReadFile(filepath.Join(templateDir, overlay.Name()))
+		if err != nil {
+			panic(err)
+		}
+		files[filepath.Join(pkg.Name, overlay.Name())] = contents
+	}
+
 	mustWriteFiles(outdir, files)
 }
 
@@ -5,7 +5,11 @@ go 1.13
 require (
 	github.com/golang/protobuf v1.5.2
 	github.com/pkg/errors v0.9.1
+	github.com/pkg/sftp v1.13.4
 	github.com/pulumi/pulumi/pkg/v3 v3.10.0
 	github.com/pulumi/pulumi/sdk/v3 v3.10.0
+	golang.org/x/crypto v0.0.0-20210506145944-38f3c27a63bf
+	golang.org/x/sys v0.0.0-20210503173754-0981d6026fa6
 	google.golang.org/grpc v1.40.0
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 )
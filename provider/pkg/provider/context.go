@@ -0,0 +1,136 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// The variables that tell a command which operation, resource and stack it runs for.
+const (
+	envOp        = "PULUMI_COMMAND_OP"
+	envURN       = "PULUMI_COMMAND_URN"
+	envID        = "PULUMI_COMMAND_ID"
+	envStack     = "PULUMI_COMMAND_STACK"
+	envProject   = "PULUMI_COMMAND_PROJECT"
+	envOldStdout = "PULUMI_COMMAND_OLD_STDOUT"
+	envOldInputs = "PULUMI_COMMAND_OLD_INPUTS"
+)
+
+// stdinFromPreviousState feeds the previous state of the resource to the stdin of a command.
+const stdinFromPreviousState = "previousState"
+
+// stdinFromSources are the valid values of stdinFrom.
+var stdinFromSources = []string{stdinFromPreviousState}
+
+// oldState returns the state of the resource before the operation of req, or nil if it has none.
+func oldState(req hasUrn) *structpb.Struct {
+	switch r := req.(type) {
+	case *pulumirpc.ReadRequest:
+		return r.GetProperties()
+	case *pulumirpc.DiffRequest:
+		return r.GetOlds()
+	case *pulumirpc.UpdateRequest:
+		return r.GetOlds()
+	case *pulumirpc.DeleteRequest:
+		return r.GetProperties()
+	}
+	return nil
+}
+
+// contextEnv returns the variables describing the operation of req to the command that runs it.
+func contextEnv(req hasUrn, op string) ([]string, error) {
+	env := []string{envOp + "=" + op}
+	if urn := resource.URN(req.GetUrn()); urn != "" {
+		env = append(env,
+			envURN+"="+string(urn),
+			envStack+"="+string(urn.Stack()),
+			envProject+"="+string(urn.Project()),
+		)
+	}
+	if r, ok := req.(hasID); ok && r.GetId() != "" {
+		env = append(env, envID+"="+r.GetId())
+	}
+	if olds := oldState(req); olds != nil {
+		state, err := plainProperties(olds)
+		if err != nil {
+			return nil, err
+		}
+		if stdout := state["stdout"]; stdout.IsString() {
+			env = append(env, envOldStdout+"="+stdout.StringValue())
+		}
+		inputs, err := publicInputs(olds)
+		if err != nil {
+			return nil, err
+		}
+		if inputs != nil {
+			b, err := json.Marshal(inputs)
+			if err != nil {
+				return nil, err
+			}
+			env = append(env, envOldInputs+"="+string(b))
+		}
+	}
+	return env, nil
+}
+
+// publicInputs returns the inputs saved in the state of a resource without its connection and secret
+// values, which must not be passed to commands. It returns nil when the state has no inputs.
+func publicInputs(olds *structpb.Struct) (map[string]interface{}, error) {
+	state, err := plugin.UnmarshalProperties(olds, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipNulls: true})
+	if err != nil {
+		return nil, err
+	}
+	inputs := state["inputs"]
+	if inputs.IsSecret() || !inputs.IsObject() {
+		return nil, nil
+	}
+	public := withoutSecrets(inputs).ObjectValue()
+	delete(public, "connection")
+	return public.Mappable(), nil
+}
+
+// previousStateJSON returns a JSON document of the stdout, stderr, parsed output and public inputs saved in
+// the previous state of the resource.
+func previousStateJSON(req hasUrn) ([]byte, error) {
+	olds := oldState(req)
+	if olds == nil {
+		return nil, errors.New("there is no previous state to read from stdin")
+	}
+	state, err := plainProperties(olds)
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]interface{}{"stdout": "", "stderr": ""}
+	for _, k := range []resource.PropertyKey{"stdout", "stderr", "parsed"} {
+		if v, ok := state[k]; ok && !v.IsNull() {
+			doc[string(k)] = v.Mappable()
+		}
+	}
+	inputs, err := publicInputs(olds)
+	if err != nil {
+		return nil, err
+	}
+	if inputs != nil {
+		doc["inputs"] = inputs
+	}
+	return json.Marshal(doc)
+}
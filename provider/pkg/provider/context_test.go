@@ -0,0 +1,81 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+func Test_contextEnv(t *testing.T) {
+	urn := "urn:pulumi:dev::website::command:v1:Command::demo"
+	req := &pulumirpc.DeleteRequest{}
+	err := jsonpb.UnmarshalString(`{"id":"abc","urn":"`+urn+`","properties":{"inputs":{"create":{"command":["true"]}},"stdout":"old\n","stderr":""}}`, req)
+	if err != nil {
+		t.Fatalf("Could not unmarshal json string: %v", err)
+	}
+	tests := []struct {
+		name string
+		req  hasUrn
+		op   string
+		want []string
+	}{
+		{
+			name: "Create",
+			req:  &pulumirpc.CreateRequest{Urn: urn},
+			op:   "create",
+			want: []string{
+				"PULUMI_COMMAND_OP=create",
+				"PULUMI_COMMAND_URN=" + urn,
+				"PULUMI_COMMAND_STACK=dev",
+				"PULUMI_COMMAND_PROJECT=website",
+			},
+		},
+		{
+			name: "Delete",
+			req:  req,
+			op:   "delete",
+			want: []string{
+				"PULUMI_COMMAND_OP=delete",
+				"PULUMI_COMMAND_URN=" + urn,
+				"PULUMI_COMMAND_STACK=dev",
+				"PULUMI_COMMAND_PROJECT=website",
+				"PULUMI_COMMAND_ID=abc",
+				"PULUMI_COMMAND_OLD_STDOUT=old\n",
+				`PULUMI_COMMAND_OLD_INPUTS={"create":{"command":["true"]}}`,
+			},
+		},
+		{
+			name: "Run",
+			req:  invokeRequest{&pulumirpc.InvokeRequest{}},
+			op:   "run",
+			want: []string{"PULUMI_COMMAND_OP=run"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := contextEnv(tt.req, tt.op)
+			if err != nil {
+				t.Fatalf("contextEnv() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("contextEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,841 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"github.com/pkg/sftp"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"golang.org/x/crypto/ssh"
+)
+
+// The types of the resources that copy files to a remote host over SFTP. Their ID is the remote path.
+const (
+	copyFileType      = "command:v1:CopyFile"
+	copyDirectoryType = "command:v1:CopyDirectory"
+)
+
+// copyFile are the inputs of a CopyFile resource.
+type copyFile struct {
+	Connection *connection `pulumi:"connection,optional"`
+	// Source is the local file to upload. Content or ContentBase64 hold the content of the file instead.
+	Source        string `pulumi:"source,optional"`
+	Content       string `pulumi:"content,optional"`
+	ContentBase64 string `pulumi:"contentBase64,optional" structpb:"contentBase64"`
+	RemotePath    string `pulumi:"remotePath,forceNew" structpb:"remotePath"`
+	// Mode is the octal permission bits of the remote file. Defaults to the mode of Source or 0644.
+	Mode string `pulumi:"mode,optional"`
+}
+
+// copyDirectory are the inputs of a CopyDirectory resource.
+type copyDirectory struct {
+	Connection *connection `pulumi:"connection,optional"`
+	Source     string      `pulumi:"source"`
+	RemotePath string      `pulumi:"remotePath,forceNew" structpb:"remotePath"`
+}
+
+// isCopy reports whether a request is for a CopyFile or CopyDirectory resource.
+func isCopy(req hasUrn) bool {
+	t := resource.URN(req.GetUrn()).Type()
+	return t == copyFileType || t == copyDirectoryType
+}
+
+func isCopyDirectory(req hasUrn) bool {
+	return resource.URN(req.GetUrn()).Type() == copyDirectoryType
+}
+
+// open returns the content of the file and its permission bits.
+func (f copyFile) open() (io.ReadCloser, os.FileMode, error) {
+	if f.Source == "" {
+		data, err := localFile{Content: f.Content, ContentBase64: f.ContentBase64}.data()
+		if err != nil {
+			return nil, 0, err
+		}
+		perm, err := parseFileMode(f.Mode)
+		if err != nil {
+			return nil, 0, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(data)), perm, nil
+	}
+	file, err := os.Open(f.Source)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err == nil && !info.Mode().IsRegular() {
+		err = errors.Errorf("%s is not a regular file", f.Source)
+	}
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	perm := info.Mode().Perm()
+	if f.Mode != "" {
+		if perm, err = parseFileMode(f.Mode); err != nil {
+			file.Close()
+			return nil, 0, err
+		}
+	}
+	return file, perm, nil
+}
+
+// hash returns the hash and size of the content of the file and its permission bits.
+func (f copyFile) hash() (string, int64, os.FileMode, error) {
+	r, perm, err := f.open()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	defer r.Close()
+	hash, size, err := hashReader(r)
+	return hash, size, perm, err
+}
+
+// hashReader returns the hex-encoded SHA-256 hash of the content of r and its size.
+func hashReader(r io.Reader) (string, int64, error) {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	return hex.EncodeToString(h.Sum(nil)), n, err
+}
+
+// copyEntry is a file or directory of a copied tree. Directories have no hash.
+type copyEntry struct {
+	mode   os.FileMode
+	sha256 string
+}
+
+func (e copyEntry) isDir() bool {
+	return e.sha256 == ""
+}
+
+// manifest maps the slash-separated paths of the entries of a tree, relative to its root, to the
+// entries. The root itself is ".".
+type manifest map[string]copyEntry
+
+// sortedPaths returns the paths of the entries in byte order.
+func (m manifest) sortedPaths() []string {
+	paths := make([]string, 0, len(m))
+	for p := range m {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// paths returns the paths of the entries ordered by depth, so that directories come before their contents.
+// Byte order alone does not do that: "-a" sorts before ".".
+func (m manifest) paths() []string {
+	paths := m.sortedPaths()
+	sort.SliceStable(paths, func(i, j int) bool {
+		return pathDepth(paths[i]) < pathDepth(paths[j])
+	})
+	return paths
+}
+
+// pathDepth returns the number of elements of a path of a manifest. The root has none.
+func pathDepth(p string) int {
+	if p == "." {
+		return 0
+	}
+	return strings.Count(p, "/") + 1
+}
+
+// hash returns the hash of a tree, which covers the paths, modes and content of its entries.
+func (m manifest) hash() string {
+	h := sha256.New()
+	for _, p := range m.sortedPaths() {
+		fmt.Fprintf(h, "%s %s %q\n", formatFileMode(m[p].mode), m[p].sha256, p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// property returns the entries of the tree as they are saved in the state.
+func (m manifest) property() resource.PropertyValue {
+	files := resource.PropertyMap{}
+	for p, e := range m {
+		entry := resource.PropertyMap{"mode": resource.NewStringProperty(formatFileMode(e.mode))}
+		if !e.isDir() {
+			entry["sha256"] = resource.NewStringProperty(e.sha256)
+		}
+		files[resource.PropertyKey(p)] = resource.NewObjectProperty(entry)
+	}
+	return resource.NewObjectProperty(files)
+}
+
+// stateManifest returns the tree saved in the state of a CopyDirectory resource.
+func stateManifest(state resource.PropertyMap) manifest {
+	m := manifest{}
+	if files := state["files"]; files.IsObject() {
+		for p, v := range files.ObjectValue() {
+			if !v.IsObject() {
+				continue
+			}
+			entry := v.ObjectValue()
+			mode, err := parseFileMode(entry["mode"].StringValue())
+			if !entry["mode"].IsString() || err != nil {
+				continue
+			}
+			e := copyEntry{mode: mode}
+			if sum := entry["sha256"]; sum.IsString() {
+				e.sha256 = sum.StringValue()
+			}
+			m[string(p)] = e
+		}
+	}
+	return m
+}
+
+// localManifest reads the local tree at root, hashing the content of its files.
+func localManifest(root string) (manifest, error) {
+	m := manifest{}
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			m[filepath.ToSlash(rel)] = copyEntry{mode: info.Mode().Perm()}
+		case info.Mode().IsRegular():
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			hash, _, err := hashReader(f)
+			if err != nil {
+				return err
+			}
+			m[filepath.ToSlash(rel)] = copyEntry{mode: info.Mode().Perm(), sha256: hash}
+		default:
+			return errors.Errorf("%s is not a regular file or directory", p)
+		}
+		return nil
+	})
+	if err == nil && !m["."].isDir() {
+		err = errors.Errorf("%s is not a directory", root)
+	}
+	return m, err
+}
+
+// sftpClient is an SFTP session on a remote host. Closing it also closes its SSH connection.
+type sftpClient struct {
+	*sftp.Client
+	conn *ssh.Client
+}
+
+// openSFTP connects to the host of conn and starts an SFTP session. warn shows the user host keys that are
+// accepted without being pinned.
+func openSFTP(ctx context.Context, conn *connection, warn func(string)) (*sftpClient, error) {
+	if conn == nil {
+		return nil, errors.New("the connection is unknown")
+	}
+	client, err := conn.connect(ctx, warn)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := sftp.NewClient(client)
+	if err != nil {
+		client.Close()
+		return nil, errors.Wrapf(err, "could not start SFTP on %s", conn.address())
+	}
+	return &sftpClient{Client: sc, conn: client}, nil
+}
+
+func (c *sftpClient) Close() error {
+	c.Client.Close()
+	return c.conn.Close()
+}
+
+// upload replaces the remote file with the content of r atomically: the content is written to a temporary
+// file in the same directory, which is then renamed over the file.
+func (c *sftpClient) upload(r io.Reader, remotePath string, perm os.FileMode) error {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	tmp := path.Join(path.Dir(remotePath), fmt.Sprintf(".%s.tmp-%x", path.Base(remotePath), suffix))
+	f, err := c.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		c.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		c.Remove(tmp)
+		return err
+	}
+	// The mode is set explicitly so that it does not depend on the umask of the SFTP server.
+	if err := c.Chmod(tmp, perm); err != nil {
+		c.Remove(tmp)
+		return err
+	}
+	if err := c.PosixRename(tmp, remotePath); err != nil {
+		c.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// removeAll removes the remote path and, if it is a directory, everything in it. A path that does not
+// exist is already removed.
+func (c *sftpClient) removeAll(remotePath string) error {
+	info, err := c.Lstat(remotePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return c.Remove(remotePath)
+	}
+	entries, err := c.ReadDir(remotePath)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := c.removeAll(path.Join(remotePath, e.Name())); err != nil {
+			return err
+		}
+	}
+	return c.RemoveDirectory(remotePath)
+}
+
+// remoteManifest reads the remote tree at root, hashing the content of its files. Entries that are neither
+// regular files nor directories are left out.
+func (c *sftpClient) remoteManifest(root string) (manifest, error) {
+	m := manifest{}
+	walker := c.Walk(root)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(root, walker.Path())
+		if err != nil {
+			return nil, err
+		}
+		info := walker.Stat()
+		switch {
+		case info.IsDir():
+			m[filepath.ToSlash(rel)] = copyEntry{mode: info.Mode().Perm()}
+		case info.Mode().IsRegular():
+			f, err := c.Open(walker.Path())
+			if err != nil {
+				return nil, err
+			}
+			hash, _, err := hashReader(f)
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+			m[filepath.ToSlash(rel)] = copyEntry{mode: info.Mode().Perm(), sha256: hash}
+		}
+	}
+	if !m["."].isDir() {
+		return nil, errors.Errorf("%s is not a directory", root)
+	}
+	return m, nil
+}
+
+// syncDirectory makes the remote tree at root match the local tree want at source. have is the tree as
+// it was last copied: only the entries that differ from it are uploaded, and the entries that are no
+// longer in want are removed. Entries that are not in have, such as the files of an existing directory
+// the tree is copied into, are left alone.
+func (c *sftpClient) syncDirectory(source, root string, want, have manifest) error {
+	paths := want.paths()
+	for _, p := range paths {
+		e := want[p]
+		old, managed := have[p]
+		remotePath := path.Join(root, p)
+		if e.isDir() {
+			if !managed || !old.isDir() {
+				if managed {
+					if err := c.removeAll(remotePath); err != nil {
+						return err
+					}
+				}
+				if err := c.MkdirAll(remotePath); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if managed && e == old {
+			continue
+		}
+		if managed && old.isDir() {
+			if err := c.removeAll(remotePath); err != nil {
+				return err
+			}
+		}
+		logging.V(9).Infof("uploading %s to %s", p, remotePath)
+		f, err := os.Open(filepath.Join(source, filepath.FromSlash(p)))
+		if err != nil {
+			return err
+		}
+		err = c.upload(f, remotePath, e.mode)
+		f.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to upload %s", p)
+		}
+	}
+	// Children are removed before their parents, and directories are given their mode once their contents
+	// are written, as the mode may not allow writing.
+	old := have.paths()
+	for i := len(old) - 1; i >= 0; i-- {
+		if _, ok := want[old[i]]; !ok {
+			if err := c.removeAll(path.Join(root, old[i])); err != nil {
+				return err
+			}
+		}
+	}
+	for i := len(paths) - 1; i >= 0; i-- {
+		if e := want[paths[i]]; e.isDir() && e != have[paths[i]] {
+			if err := c.Chmod(path.Join(root, paths[i]), e.mode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyOutputNames are the outputs of a copy that are computed from its content.
+var copyOutputNames = map[string][]string{
+	copyFileType:      {"mode", "sha256", "size"},
+	copyDirectoryType: {"sha256", "files"},
+}
+
+// checkCopy validates the inputs of a CopyFile or CopyDirectory resource.
+func (p *commandProvider) checkCopy(req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	plain, err := plainProperties(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	schema := reflect.TypeOf(copyFile{})
+	if isCopyDirectory(req) {
+		schema = reflect.TypeOf(copyDirectory{})
+	}
+	c := checker{remote: true}
+	if err := c.checkProperty("", resource.NewObjectProperty(plain), schema); err != nil {
+		return nil, err
+	}
+	c.checkConnection("connection", plain, true)
+	for _, k := range []resource.PropertyKey{"remotePath", "source"} {
+		if v := plain[k]; v.IsString() && v.StringValue() == "" {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: string(k), Reason: fmt.Sprintf("%s must not be empty", k)})
+		}
+	}
+	if !isCopyDirectory(req) {
+		count := 0
+		for _, k := range []resource.PropertyKey{"source", "content", "contentBase64"} {
+			if _, ok := plain[k]; ok {
+				count++
+			}
+		}
+		if count != 1 {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{
+				Property: "source",
+				Reason:   "expected exactly one of source, content and contentBase64",
+			})
+		}
+		if b := plain["contentBase64"]; b.IsString() {
+			if _, err := base64.StdEncoding.DecodeString(b.StringValue()); err != nil {
+				c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: "contentBase64", Reason: err.Error()})
+			}
+		}
+		if mode := plain["mode"]; mode.IsString() {
+			if _, err := parseFileMode(mode.StringValue()); err != nil {
+				c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: "mode", Reason: err.Error()})
+			}
+		}
+	}
+	return &pulumirpc.CheckResponse{Inputs: req.GetNews(), Failures: c.failures}, nil
+}
+
+// diffCopy compares the content of the local file or tree by its hash with the content that was last
+// copied, so that unchanged content is not uploaded again. A new remote path or target host replaces
+// the copy; new credentials are not a change.
+func (p *commandProvider) diffCopy(req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	news, err := plainProperties(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	olds, err := plainProperties(req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	detailed := map[string]*pulumirpc.PropertyDiff{}
+	if remotePath := news["remotePath"]; remotePath.ContainsUnknowns() || !remotePath.DeepEquals(olds["remotePath"]) {
+		detailed["remotePath"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE_REPLACE, InputDiff: true}
+	}
+	if news["connection"].ContainsUnknowns() {
+		detailed["connection"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE_REPLACE, InputDiff: true}
+	} else {
+		newConn, err := decodeConnection(news)
+		if err != nil {
+			return nil, err
+		}
+		oldConn, err := decodeConnection(olds)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case newConn.target() != oldConn.target():
+			detailed["connection"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE_REPLACE, InputDiff: true}
+		case !reflect.DeepEqual(newConn, oldConn):
+			// New credentials are saved in the state, which the next read and delete connect with.
+			detailed["connection"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE, InputDiff: true}
+		}
+	}
+	contentKeys := []string{"source"}
+	if !isCopyDirectory(req) {
+		contentKeys = []string{"source", "content", "contentBase64", "mode"}
+	}
+	changed := ""
+	for _, k := range contentKeys {
+		if news[resource.PropertyKey(k)].ContainsUnknowns() {
+			changed = k
+		}
+	}
+	if changed == "" {
+		changed, err = copyContentChanged(req, news, olds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if changed != "" {
+		detailed[changed] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE, InputDiff: true}
+	}
+	if len(detailed) == 0 {
+		return &pulumirpc.DiffResponse{Changes: pulumirpc.DiffResponse_DIFF_NONE, Replaces: []string{}, Stables: []string{}}, nil
+	}
+	replaces := []string{}
+	for _, k := range []string{"connection", "remotePath"} {
+		if d, ok := detailed[k]; ok && d.Kind == pulumirpc.PropertyDiff_UPDATE_REPLACE {
+			replaces = append(replaces, k)
+		}
+	}
+	// A replacement at the same path on the same host must not remove the new copy when the old one is deleted.
+	_, pathChanged := detailed["remotePath"]
+	return &pulumirpc.DiffResponse{
+		Changes:             pulumirpc.DiffResponse_DIFF_SOME,
+		Replaces:            replaces,
+		Stables:             []string{},
+		DeleteBeforeReplace: len(replaces) > 0 && !pathChanged,
+		Diffs:               changedKeys(detailed),
+		DetailedDiff:        detailed,
+		HasDetailedDiff:     true,
+	}, nil
+}
+
+// copyContentChanged returns the input whose content differs from the content that was last copied, or
+// "" if none does. Local content that cannot be read, such as a file that is written later in the
+// deployment, is a change.
+func copyContentChanged(req hasUrn, news, olds resource.PropertyMap) (string, error) {
+	oldHash := olds["sha256"].StringValue()
+	// The content does not depend on the connection, which may not be known yet.
+	news = news.Copy()
+	delete(news, "connection")
+	if isCopyDirectory(req) {
+		var d copyDirectory
+		if err := decodeProperty("", resource.NewObjectProperty(news), reflect.ValueOf(&d)); err != nil {
+			return "", err
+		}
+		m, err := localManifest(d.Source)
+		if err != nil {
+			logging.V(5).Infof("could not read %s: %v", d.Source, err)
+			return "source", nil
+		}
+		if m.hash() != oldHash {
+			return "source", nil
+		}
+		return "", nil
+	}
+	var f copyFile
+	if err := decodeProperty("", resource.NewObjectProperty(news), reflect.ValueOf(&f)); err != nil {
+		return "", err
+	}
+	contentKey := "content"
+	for _, k := range []string{"source", "contentBase64"} {
+		if _, ok := news[resource.PropertyKey(k)]; ok {
+			contentKey = k
+		}
+	}
+	hash, _, perm, err := f.hash()
+	if err != nil {
+		logging.V(5).Infof("could not read the content of %s: %v", f.RemotePath, err)
+		return contentKey, nil
+	}
+	if hash != oldHash {
+		return contentKey, nil
+	}
+	if oldMode, err := parseFileMode(olds["mode"].StringValue()); err != nil || oldMode != perm {
+		return "mode", nil
+	}
+	return "", nil
+}
+
+// copy uploads the file or tree described by props unless this is a preview, and returns its ID and
+// state. olds is the state of the last copy, whose unchanged content is not uploaded again.
+func (p *commandProvider) copy(ctx context.Context, req hasUrn, props, olds *structpb.Struct, preview bool) (string, *structpb.Struct, error) {
+	label := fmt.Sprintf("%s.copy(%s)", p.label(), req.GetUrn())
+	inputs, err := plugin.UnmarshalProperties(props, plugin.MarshalOptions{
+		KeepUnknowns: true, KeepSecrets: true, SkipNulls: true,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	oldState, err := plainProperties(olds)
+	if err != nil {
+		return "", nil, err
+	}
+	id := inputs["remotePath"].StringValue()
+	outputs := inputs.Copy()
+	known := !inputs.ContainsUnknowns()
+	if known {
+		if isCopyDirectory(req) {
+			var d copyDirectory
+			if err := decodeProperty("", resource.NewObjectProperty(inputs), reflect.ValueOf(&d)); err != nil {
+				return "", nil, err
+			}
+			err = p.copyDirectory(ctx, req, d, stateManifest(oldState), outputs, preview)
+		} else {
+			var f copyFile
+			if err := decodeProperty("", resource.NewObjectProperty(inputs), reflect.ValueOf(&f)); err != nil {
+				return "", nil, err
+			}
+			err = p.copyFile(ctx, req, f, oldState, outputs, preview)
+		}
+		switch {
+		case err != nil && preview:
+			// The local content may be written by another resource during the deployment.
+			logging.V(5).Infof("%s: %v", label, err)
+			known = false
+		case err != nil:
+			return "", nil, err
+		}
+	} else if !preview {
+		return "", nil, errors.New("the copy depends on values that are not known yet")
+	}
+	if !known {
+		for _, name := range copyOutputNames[resource.URN(req.GetUrn()).Type().String()] {
+			if _, ok := inputs[resource.PropertyKey(name)]; !ok {
+				outputs[resource.PropertyKey(name)] = resource.MakeComputed(resource.NewStringProperty(""))
+			}
+		}
+	}
+	out, err := plugin.MarshalProperties(outputs, plugin.MarshalOptions{
+		Label: label, KeepUnknowns: true, KeepSecrets: true, SkipNulls: true,
+	})
+	return id, out, err
+}
+
+// copyFile uploads a file, or only changes its mode if its content is unchanged since the last copy in
+// olds, and records its hash, size and mode in outputs.
+func (p *commandProvider) copyFile(ctx context.Context, req hasUrn, f copyFile, olds resource.PropertyMap, outputs resource.PropertyMap, preview bool) error {
+	hash, size, perm, err := f.hash()
+	if err != nil {
+		return err
+	}
+	outputs["mode"] = resource.NewStringProperty(formatFileMode(perm))
+	outputs["sha256"] = resource.NewStringProperty(hash)
+	outputs["size"] = resource.NewNumberProperty(float64(size))
+	if preview {
+		return nil
+	}
+	ctx, cancel := p.withCancellation(ctx)
+	defer cancel()
+	sc, err := openSFTP(ctx, f.Connection, p.warner(ctx, req))
+	if err != nil {
+		return err
+	}
+	defer sc.Close()
+	if oldHash := olds["sha256"]; oldHash.IsString() && oldHash.StringValue() == hash {
+		logging.V(9).Infof("the content of %s is unchanged, setting its mode", f.RemotePath)
+		return errors.Wrapf(sc.Chmod(f.RemotePath, perm), "failed to set the mode of %s", f.RemotePath)
+	}
+	if err := sc.MkdirAll(path.Dir(f.RemotePath)); err != nil {
+		return errors.Wrapf(err, "failed to create the directory of %s", f.RemotePath)
+	}
+	r, perm, err := f.open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	logging.V(9).Infof("uploading %s", f.RemotePath)
+	return errors.Wrapf(sc.upload(r, f.RemotePath, perm), "failed to upload %s", f.RemotePath)
+}
+
+// copyDirectory uploads the files of a tree that changed since the last copy have, and records the tree
+// in outputs.
+func (p *commandProvider) copyDirectory(ctx context.Context, req hasUrn, d copyDirectory, have manifest, outputs resource.PropertyMap, preview bool) error {
+	want, err := localManifest(d.Source)
+	if err != nil {
+		return err
+	}
+	outputs["sha256"] = resource.NewStringProperty(want.hash())
+	outputs["files"] = want.property()
+	if preview {
+		return nil
+	}
+	ctx, cancel := p.withCancellation(ctx)
+	defer cancel()
+	sc, err := openSFTP(ctx, d.Connection, p.warner(ctx, req))
+	if err != nil {
+		return err
+	}
+	defer sc.Close()
+	return errors.Wrapf(sc.syncDirectory(d.Source, d.RemotePath, want, have), "failed to copy %s to %s", d.Source, d.RemotePath)
+}
+
+func (p *commandProvider) createCopy(ctx context.Context, req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
+	id, out, err := p.copy(ctx, req, req.GetProperties(), nil, req.GetPreview())
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CreateResponse{Id: id, Properties: out}, nil
+}
+
+func (p *commandProvider) updateCopy(ctx context.Context, req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
+	_, out, err := p.copy(ctx, req, req.GetNews(), req.GetOlds(), req.GetPreview())
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.UpdateResponse{Properties: out}, nil
+}
+
+// readCopy hashes the remote file or tree. Content or modes that changed outside of Pulumi are reported in
+// the state, so that the next update uploads the copy again. A copy that no longer exists is removed from
+// the state.
+func (p *commandProvider) readCopy(ctx context.Context, req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
+	state, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{KeepSecrets: true, SkipNulls: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(state) == 0 {
+		return nil, errors.Errorf("%s resources cannot be imported, as their connection is unknown", resource.URN(req.GetUrn()).Type())
+	}
+	conn, err := decodeConnection(state)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := p.withCancellation(ctx)
+	defer cancel()
+	sc, err := openSFTP(ctx, conn, p.warner(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	defer sc.Close()
+	remotePath := req.GetId()
+	info, err := sc.Stat(remotePath)
+	if os.IsNotExist(err) {
+		logging.V(1).Infof("Read check: %s no longer exists", remotePath)
+		return &pulumirpc.ReadResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if isCopyDirectory(req) {
+		m, err := sc.remoteManifest(remotePath)
+		if err != nil {
+			return nil, err
+		}
+		state["sha256"] = resource.NewStringProperty(m.hash())
+		state["files"] = m.property()
+	} else {
+		f, err := sc.Open(remotePath)
+		if err != nil {
+			return nil, err
+		}
+		hash, size, err := hashReader(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if old := state["sha256"]; !old.IsString() || old.StringValue() != hash {
+			logging.V(1).Infof("Read check: the content of %s has drifted", remotePath)
+		}
+		state["sha256"] = resource.NewStringProperty(hash)
+		state["size"] = resource.NewNumberProperty(float64(size))
+		state["mode"] = resource.NewStringProperty(formatFileMode(info.Mode()))
+	}
+	properties, err := plugin.MarshalProperties(state, plugin.MarshalOptions{
+		Label: fmt.Sprintf("%s.Read(%s)", p.label(), req.GetUrn()), KeepSecrets: true, SkipNulls: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	inputs := req.GetInputs()
+	if len(inputs.GetFields()) == 0 {
+		// Older engines do not send the inputs, which the state of a copy includes.
+		inputs = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		for k, v := range req.GetProperties().GetFields() {
+			if k != "sha256" && k != "size" && k != "files" {
+				inputs.Fields[k] = v
+			}
+		}
+	}
+	return &pulumirpc.ReadResponse{Id: remotePath, Properties: properties, Inputs: inputs}, nil
+}
+
+// deleteCopy removes the remote file or tree. A copy that no longer exists is already deleted.
+func (p *commandProvider) deleteCopy(ctx context.Context, req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
+	state, err := plainProperties(req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	conn, err := decodeConnection(state)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := p.withCancellation(ctx)
+	defer cancel()
+	sc, err := openSFTP(ctx, conn, p.warner(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	defer sc.Close()
+	if err := sc.removeAll(req.GetId()); err != nil {
+		return nil, errors.Wrapf(err, "failed to remove %s", req.GetId())
+	}
+	return &pbempty.Empty{}, nil
+}
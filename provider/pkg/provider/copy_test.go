@@ -0,0 +1,399 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+const (
+	copyFileURN      = "urn:pulumi:command-test::command-test::command:v1:CopyFile::demo"
+	copyDirectoryURN = "urn:pulumi:command-test::command-test::command:v1:CopyDirectory::demo"
+)
+
+func mustUnmarshal(t *testing.T, s *structpb.Struct) resource.PropertyMap {
+	t.Helper()
+	props, err := plugin.UnmarshalProperties(s, plugin.MarshalOptions{KeepSecrets: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return props
+}
+
+func Test_commandProvider_CheckCopy(t *testing.T) {
+	conn := resource.NewObjectProperty(resource.PropertyMap{"host": resource.NewStringProperty("example.com")})
+	tests := []struct {
+		name         string
+		urn          string
+		news         resource.PropertyMap
+		wantFailures []string
+	}{
+		{
+			name: "File from source",
+			urn:  copyFileURN,
+			news: resource.PropertyMap{"connection": conn, "source": resource.NewStringProperty("app"), "remotePath": resource.NewStringProperty("/usr/local/bin/app")},
+		},
+		{
+			name: "Invalid file",
+			urn:  copyFileURN,
+			news: resource.PropertyMap{
+				"source":        resource.NewStringProperty("app"),
+				"contentBase64": resource.NewStringProperty("!"),
+				"remotePath":    resource.NewStringProperty(""),
+				"mode":          resource.NewStringProperty("rwx"),
+			},
+			wantFailures: []string{"", "remotePath", "source", "contentBase64", "mode"},
+		},
+		{
+			name:         "File without content",
+			urn:          copyFileURN,
+			news:         resource.PropertyMap{"connection": conn, "remotePath": resource.NewStringProperty("/etc/app.conf")},
+			wantFailures: []string{"source"},
+		},
+		{
+			name:         "Directory without source",
+			urn:          copyDirectoryURN,
+			news:         resource.PropertyMap{"connection": conn, "remotePath": resource.NewStringProperty("/srv/app")},
+			wantFailures: []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			resp, err := p.Check(context.Background(), &pulumirpc.CheckRequest{Urn: tt.urn, News: mustMarshal(t, tt.news)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, f := range resp.GetFailures() {
+				got = append(got, f.GetProperty())
+			}
+			if tt.wantFailures == nil {
+				tt.wantFailures = []string{}
+			}
+			if !reflect.DeepEqual(got, tt.wantFailures) {
+				t.Errorf("Check() failures = %v, want %v", resp.GetFailures(), tt.wantFailures)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_CopyFile(t *testing.T) {
+	ctx := context.Background()
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	server := newTestSSHServer(t, nil)
+	remotePath := filepath.Join(t.TempDir(), "etc", "app.conf")
+	inputs := resource.PropertyMap{
+		"connection": resource.NewObjectProperty(server.connection()),
+		"content":    resource.MakeSecret(resource.NewStringProperty("port = 80\n")),
+		"remotePath": resource.NewStringProperty(remotePath),
+		"mode":       resource.NewStringProperty("0600"),
+	}
+
+	created, err := p.Create(ctx, &pulumirpc.CreateRequest{Urn: copyFileURN, Properties: mustMarshal(t, inputs)})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.GetId() != remotePath {
+		t.Errorf("Create() id = %q, want %q", created.GetId(), remotePath)
+	}
+	assertFile(t, remotePath, "port = 80\n", 0600)
+	state := mustUnmarshal(t, created.GetProperties())
+	if state["sha256"].StringValue() != contentHash([]byte("port = 80\n")) || state["size"].NumberValue() != 10 {
+		t.Errorf("Create() state = %v", state)
+	}
+
+	diff := func(olds *structpb.Struct, news resource.PropertyMap) *pulumirpc.DiffResponse {
+		t.Helper()
+		resp, err := p.Diff(ctx, &pulumirpc.DiffRequest{Urn: copyFileURN, Id: remotePath, Olds: olds, News: mustMarshal(t, news)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	// The same content from a local file is not a change.
+	source := filepath.Join(t.TempDir(), "app.conf")
+	if err := ioutil.WriteFile(source, []byte("port = 80\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fromSource := inputs.Copy()
+	delete(fromSource, "content")
+	delete(fromSource, "mode")
+	fromSource["source"] = resource.NewStringProperty(source)
+	if resp := diff(created.GetProperties(), fromSource); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_NONE {
+		t.Errorf("Diff() of the same content = %v, want DIFF_NONE", resp)
+	}
+	// New credentials update the copy, so that they are saved in the state.
+	newPassword := inputs.Copy()
+	conn := server.connection()
+	conn["password"] = resource.NewStringProperty("rotated")
+	newPassword["connection"] = resource.NewObjectProperty(conn)
+	if resp := diff(created.GetProperties(), newPassword); !reflect.DeepEqual(resp.GetDiffs(), []string{"connection"}) || len(resp.GetReplaces()) != 0 {
+		t.Errorf("Diff() of new credentials = %v, want an update of connection", resp)
+	}
+	newMode := inputs.Copy()
+	newMode["mode"] = resource.NewStringProperty("0640")
+	if resp := diff(created.GetProperties(), newMode); !reflect.DeepEqual(resp.GetDiffs(), []string{"mode"}) || len(resp.GetReplaces()) != 0 {
+		t.Errorf("Diff() of a new mode = %v, want an update of mode", resp)
+	}
+
+	// Only the mode of unchanged content is set.
+	before, err := os.Stat(remotePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := p.Update(ctx, &pulumirpc.UpdateRequest{Urn: copyFileURN, Id: remotePath, Olds: created.GetProperties(), News: mustMarshal(t, newMode)})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertFile(t, remotePath, "port = 80\n", 0640)
+	if after, err := os.Stat(remotePath); err != nil || !os.SameFile(before, after) {
+		t.Errorf("Update() uploaded unchanged content again")
+	}
+
+	// A refresh reports content changed on the host, which the next update uploads again.
+	if err := ioutil.WriteFile(remotePath, []byte("port = 8080\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	read, err := p.Read(ctx, &pulumirpc.ReadRequest{Urn: copyFileURN, Id: remotePath, Properties: updated.GetProperties(), Inputs: mustMarshal(t, newMode)})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if resp := diff(read.GetProperties(), newMode); !reflect.DeepEqual(resp.GetDiffs(), []string{"content"}) {
+		t.Errorf("Diff() after drift = %v, want an update of content", resp)
+	}
+	if _, err := p.Update(ctx, &pulumirpc.UpdateRequest{Urn: copyFileURN, Id: remotePath, Olds: read.GetProperties(), News: mustMarshal(t, newMode)}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertFile(t, remotePath, "port = 80\n", 0640)
+
+	for i := 0; i < 2; i++ {
+		if _, err := p.Delete(ctx, &pulumirpc.DeleteRequest{Urn: copyFileURN, Id: remotePath, Properties: updated.GetProperties()}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}
+	if _, err := os.Stat(remotePath); !os.IsNotExist(err) {
+		t.Errorf("Delete() left %s", remotePath)
+	}
+	read, err = p.Read(ctx, &pulumirpc.ReadRequest{Urn: copyFileURN, Id: remotePath, Properties: updated.GetProperties()})
+	if err != nil || read.GetId() != "" {
+		t.Errorf("Read() of a deleted file = %v, %v, want an empty ID", read, err)
+	}
+}
+
+func Test_commandProvider_CopyDirectory(t *testing.T) {
+	ctx := context.Background()
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	server := newTestSSHServer(t, nil)
+	source := t.TempDir()
+	writeTree(t, source, map[string]string{"bin/run": "#!/bin/sh\n", "config.yaml": "a: 1\n", "old.txt": "old\n"})
+	if err := os.Chmod(filepath.Join(source, "bin", "run"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	remotePath := filepath.Join(t.TempDir(), "srv", "app")
+	inputs := resource.PropertyMap{
+		"connection": resource.NewObjectProperty(server.connection()),
+		"source":     resource.NewStringProperty(source),
+		"remotePath": resource.NewStringProperty(remotePath),
+	}
+
+	created, err := p.Create(ctx, &pulumirpc.CreateRequest{Urn: copyDirectoryURN, Properties: mustMarshal(t, inputs)})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	assertFile(t, filepath.Join(remotePath, "bin", "run"), "#!/bin/sh\n", 0755)
+	assertFile(t, filepath.Join(remotePath, "config.yaml"), "a: 1\n", 0644)
+	state := mustUnmarshal(t, created.GetProperties())
+	if files := state["files"].ObjectValue(); len(files) != 5 {
+		t.Errorf("Create() files = %v, want 5 entries", files)
+	}
+
+	diff := func(olds *structpb.Struct) *pulumirpc.DiffResponse {
+		t.Helper()
+		resp, err := p.Diff(ctx, &pulumirpc.DiffRequest{Urn: copyDirectoryURN, Id: remotePath, Olds: olds, News: mustMarshal(t, inputs)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	if resp := diff(created.GetProperties()); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_NONE {
+		t.Errorf("Diff() of an unchanged tree = %v, want DIFF_NONE", resp)
+	}
+
+	// Only changed files are uploaded, and files removed locally are removed from the host.
+	if err := os.Remove(filepath.Join(source, "old.txt")); err != nil {
+		t.Fatal(err)
+	}
+	writeTree(t, source, map[string]string{"config.yaml": "a: 2\n", "new/file.txt": "new\n"})
+	if resp := diff(created.GetProperties()); !reflect.DeepEqual(resp.GetDiffs(), []string{"source"}) {
+		t.Errorf("Diff() of a changed tree = %v, want an update of source", resp)
+	}
+	before, err := os.Stat(filepath.Join(remotePath, "bin", "run"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := p.Update(ctx, &pulumirpc.UpdateRequest{Urn: copyDirectoryURN, Id: remotePath, Olds: created.GetProperties(), News: mustMarshal(t, inputs)})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertFile(t, filepath.Join(remotePath, "config.yaml"), "a: 2\n", 0644)
+	assertFile(t, filepath.Join(remotePath, "new", "file.txt"), "new\n", 0644)
+	if _, err := os.Stat(filepath.Join(remotePath, "old.txt")); !os.IsNotExist(err) {
+		t.Errorf("Update() left old.txt")
+	}
+	if after, err := os.Stat(filepath.Join(remotePath, "bin", "run")); err != nil || !os.SameFile(before, after) {
+		t.Errorf("Update() uploaded an unchanged file again")
+	}
+
+	// A refresh of an unchanged tree reports the same hash; a tree changed on the host is a change.
+	read, err := p.Read(ctx, &pulumirpc.ReadRequest{Urn: copyDirectoryURN, Id: remotePath, Properties: updated.GetProperties(), Inputs: mustMarshal(t, inputs)})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if resp := diff(read.GetProperties()); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_NONE {
+		t.Errorf("Diff() after refreshing an unchanged tree = %v, want DIFF_NONE", resp)
+	}
+	writeTree(t, remotePath, map[string]string{"extra.txt": "extra\n"})
+	read, err = p.Read(ctx, &pulumirpc.ReadRequest{Urn: copyDirectoryURN, Id: remotePath, Properties: updated.GetProperties(), Inputs: mustMarshal(t, inputs)})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if resp := diff(read.GetProperties()); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_SOME {
+		t.Errorf("Diff() after drift = %v, want DIFF_SOME", resp)
+	}
+	if _, err := p.Update(ctx, &pulumirpc.UpdateRequest{Urn: copyDirectoryURN, Id: remotePath, Olds: read.GetProperties(), News: mustMarshal(t, inputs)}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(remotePath, "extra.txt")); !os.IsNotExist(err) {
+		t.Errorf("Update() left extra.txt")
+	}
+
+	if _, err := p.Delete(ctx, &pulumirpc.DeleteRequest{Urn: copyDirectoryURN, Id: remotePath, Properties: updated.GetProperties()}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(remotePath); !os.IsNotExist(err) {
+		t.Errorf("Delete() left %s", remotePath)
+	}
+}
+
+func Test_commandProvider_CopyDirectoryExisting(t *testing.T) {
+	ctx := context.Background()
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	server := newTestSSHServer(t, nil)
+	source := t.TempDir()
+	// "-flag" sorts before the root, ".".
+	writeTree(t, source, map[string]string{"-flag": "flag\n", "conf/app.yaml": "a: 1\n"})
+	remotePath := t.TempDir()
+	writeTree(t, remotePath, map[string]string{"keep.txt": "keep\n", "conf/local.yaml": "b: 1\n"})
+	inputs := resource.PropertyMap{
+		"connection": resource.NewObjectProperty(server.connection()),
+		"source":     resource.NewStringProperty(source),
+		"remotePath": resource.NewStringProperty(remotePath),
+	}
+
+	if _, err := p.Create(ctx, &pulumirpc.CreateRequest{Urn: copyDirectoryURN, Properties: mustMarshal(t, inputs)}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	assertFile(t, filepath.Join(remotePath, "-flag"), "flag\n", 0644)
+	assertFile(t, filepath.Join(remotePath, "conf", "app.yaml"), "a: 1\n", 0644)
+	assertFile(t, filepath.Join(remotePath, "keep.txt"), "keep\n", 0644)
+	assertFile(t, filepath.Join(remotePath, "conf", "local.yaml"), "b: 1\n", 0644)
+}
+
+func Test_commandProvider_DiffCopyTarget(t *testing.T) {
+	olds := resource.PropertyMap{
+		"connection": resource.NewObjectProperty(resource.PropertyMap{"host": resource.NewStringProperty("a.example.com")}),
+		"content":    resource.NewStringProperty("hello"),
+		"remotePath": resource.NewStringProperty("/etc/motd"),
+		"mode":       resource.NewStringProperty("0644"),
+		"sha256":     resource.NewStringProperty(contentHash([]byte("hello"))),
+	}
+	tests := []struct {
+		name      string
+		change    resource.PropertyMap
+		wantDiffs []string
+		// wantUpdate is set when the changes update the copy instead of replacing it.
+		wantUpdate bool
+		wantDBR    bool
+	}{
+		{name: "New host", change: resource.PropertyMap{"connection": resource.NewObjectProperty(resource.PropertyMap{"host": resource.NewStringProperty("b.example.com")})}, wantDiffs: []string{"connection"}, wantDBR: true},
+		{name: "New password", change: resource.PropertyMap{"connection": resource.NewObjectProperty(resource.PropertyMap{"host": resource.NewStringProperty("a.example.com"), "password": resource.NewStringProperty("new")})}, wantDiffs: []string{"connection"}, wantUpdate: true},
+		{name: "New path", change: resource.PropertyMap{"remotePath": resource.NewStringProperty("/etc/issue")}, wantDiffs: []string{"remotePath"}},
+		{name: "Unknown host", change: resource.PropertyMap{"connection": resource.NewObjectProperty(resource.PropertyMap{"host": resource.MakeComputed(resource.NewStringProperty(""))})}, wantDiffs: []string{"connection"}, wantDBR: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			news := olds.Copy()
+			delete(news, "sha256")
+			for k, v := range tt.change {
+				news[k] = v
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			resp, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{Urn: copyFileURN, Olds: mustMarshal(t, olds), News: mustMarshal(t, news)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantReplaces := tt.wantDiffs
+			if tt.wantUpdate {
+				wantReplaces = []string{}
+			}
+			if !reflect.DeepEqual(resp.GetDiffs(), tt.wantDiffs) || !reflect.DeepEqual(resp.GetReplaces(), wantReplaces) {
+				t.Errorf("Diff() = %v, want changes to %v replacing %v", resp, tt.wantDiffs, wantReplaces)
+			}
+			if resp.GetDeleteBeforeReplace() != tt.wantDBR {
+				t.Errorf("Diff() deleteBeforeReplace = %v, want %v", resp.GetDeleteBeforeReplace(), tt.wantDBR)
+			}
+		})
+	}
+}
+
+// assertFile checks the content and permission bits of a file.
+func assertFile(t *testing.T, name, content string, perm os.FileMode) {
+	t.Helper()
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Errorf("%s = %q, want %q", name, data, content)
+	}
+	if info, err := os.Stat(name); err != nil || info.Mode().Perm() != perm {
+		t.Errorf("%s mode = %v, want %v", name, info.Mode().Perm(), perm)
+	}
+}
+
+// writeTree writes files, given by their slash-separated paths relative to root.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
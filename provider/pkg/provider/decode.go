@@ -43,6 +43,14 @@ func failureError(f *pulumirpc.CheckFailure) error {
 	return errors.Errorf("%v: %v", f.Property, f.Reason)
 }
 
+// propertyPath appends name to a dotted property path.
+func propertyPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return fmt.Sprintf("%v.%v", path, name)
+}
+
 type fieldDesc struct {
 	name     string
 	optional bool
@@ -83,9 +91,14 @@ func getFieldDesc(field reflect.StructField) (*fieldDesc, error) {
 
 type checker struct {
 	failures []*pulumirpc.CheckFailure
+	// remote is set when the commands run on a remote host rather than on the machine running the provider.
+	remote bool
 }
 
 func (c *checker) checkProperty(path string, v resource.PropertyValue, schema reflect.Type) error {
+	if v.IsSecret() {
+		v = v.SecretValue().Element
+	}
 	if v.IsComputed() {
 		return nil
 	}
@@ -111,6 +124,7 @@ func (c *checker) checkProperty(path string, v resource.PropertyValue, schema re
 	case reflect.Slice:
 		if !v.IsArray() {
 			c.failures = append(c.failures, typeMismatch(path, "[]", v))
+			break
 		}
 		for i, e := range v.ArrayValue() {
 			if err := c.checkProperty(fmt.Sprintf("%v[%v]", path, i), e, schema.Elem()); err != nil {
@@ -126,7 +140,7 @@ func (c *checker) checkProperty(path string, v resource.PropertyValue, schema re
 			c.failures = append(c.failures, typeMismatch(path, "object", v))
 		} else {
 			for k, e := range v.ObjectValue() {
-				if err := c.checkProperty(fmt.Sprintf("%v.%v", path, k), e, schema.Elem()); err != nil {
+				if err := c.checkProperty(propertyPath(path, string(k)), e, schema.Elem()); err != nil {
 					return err
 				}
 			}
@@ -154,7 +168,7 @@ func (c *checker) checkProperty(path string, v resource.PropertyValue, schema re
 					}
 					continue
 				}
-				if err := c.checkProperty(fmt.Sprintf("%v.%v", path, desc.name), e, f.Type); err != nil {
+				if err := c.checkProperty(propertyPath(path, desc.name), e, f.Type); err != nil {
 					return err
 				}
 			}
@@ -173,6 +187,9 @@ func (c *checker) checkProperty(path string, v resource.PropertyValue, schema re
 }
 
 func decodeProperty(path string, v resource.PropertyValue, dest reflect.Value) error {
+	if v.IsSecret() {
+		v = v.SecretValue().Element
+	}
 	switch dest.Kind() {
 	case reflect.Bool:
 		if !v.IsBool() {
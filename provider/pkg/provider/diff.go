@@ -0,0 +1,129 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+var (
+	// simpleKey matches property keys that can be written in a property path without quoting.
+	simpleKey = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// topLevelKey matches the first element of a property path.
+	topLevelKey = regexp.MustCompile(`^[^.\[]+`)
+)
+
+// propertyKeyPath appends key to a property path, quoting keys such as environment variable names
+// that are not valid identifiers.
+func propertyKeyPath(path string, key resource.PropertyKey) string {
+	if simpleKey.MatchString(string(key)) {
+		return propertyPath(path, string(key))
+	}
+	return fmt.Sprintf("%s[%q]", path, string(key))
+}
+
+// detailedDiff returns the differences between the old and new inputs of a resource keyed by property path.
+func detailedDiff(olds, news resource.PropertyMap) map[string]*pulumirpc.PropertyDiff {
+	diffs := map[string]*pulumirpc.PropertyDiff{}
+	if d := olds.Diff(news); d != nil {
+		addObjectDiff(diffs, "", d)
+	}
+	return diffs
+}
+
+func addObjectDiff(diffs map[string]*pulumirpc.PropertyDiff, path string, d *resource.ObjectDiff) {
+	for k := range d.Adds {
+		diffs[propertyKeyPath(path, k)] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_ADD, InputDiff: true}
+	}
+	for k := range d.Deletes {
+		diffs[propertyKeyPath(path, k)] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_DELETE, InputDiff: true}
+	}
+	for k, v := range d.Updates {
+		addValueDiff(diffs, propertyKeyPath(path, k), v)
+	}
+}
+
+func addValueDiff(diffs map[string]*pulumirpc.PropertyDiff, path string, v resource.ValueDiff) {
+	switch {
+	case v.Object != nil:
+		addObjectDiff(diffs, path, v.Object)
+	case v.Array != nil:
+		for i := range v.Array.Adds {
+			diffs[fmt.Sprintf("%s[%d]", path, i)] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_ADD, InputDiff: true}
+		}
+		for i := range v.Array.Deletes {
+			diffs[fmt.Sprintf("%s[%d]", path, i)] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_DELETE, InputDiff: true}
+		}
+		for i, e := range v.Array.Updates {
+			addValueDiff(diffs, fmt.Sprintf("%s[%d]", path, i), e)
+		}
+	default:
+		diffs[path] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE, InputDiff: true}
+	}
+}
+
+// changedKeys returns the top-level properties named by the paths of a detailed diff.
+func changedKeys(diffs map[string]*pulumirpc.PropertyDiff) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for path := range diffs {
+		key := topLevelKey.FindString(path)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// replaceKinds maps the kind of a property change to the kind that replaces the resource.
+var replaceKinds = map[pulumirpc.PropertyDiff_Kind]pulumirpc.PropertyDiff_Kind{
+	pulumirpc.PropertyDiff_ADD:    pulumirpc.PropertyDiff_ADD_REPLACE,
+	pulumirpc.PropertyDiff_DELETE: pulumirpc.PropertyDiff_DELETE_REPLACE,
+	pulumirpc.PropertyDiff_UPDATE: pulumirpc.PropertyDiff_UPDATE_REPLACE,
+}
+
+// markReplacements marks the changes to the keys that replace the resource and returns those keys.
+func markReplacements(diffs map[string]*pulumirpc.PropertyDiff, keys map[string]bool) []string {
+	replaced := map[string]*pulumirpc.PropertyDiff{}
+	for path, d := range diffs {
+		if keys[topLevelKey.FindString(path)] {
+			if kind, ok := replaceKinds[d.Kind]; ok {
+				d.Kind = kind
+			}
+			replaced[path] = d
+		}
+	}
+	return changedKeys(replaced)
+}
+
+// withDefaultInputs records the default settings of every command in props, so that inputs written
+// before a setting existed compare equal to inputs that record its default.
+func withDefaultInputs(props resource.PropertyMap) resource.PropertyMap {
+	for _, op := range commandOps {
+		if what := props[resource.PropertyKey(op)]; what.IsObject() {
+			if _, ok := what.ObjectValue()["inheritEnv"]; !ok {
+				what.ObjectValue()["inheritEnv"] = resource.NewStringProperty(inheritEnvAll)
+			}
+		}
+	}
+	return props
+}
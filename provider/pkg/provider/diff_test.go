@@ -0,0 +1,61 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+func Test_detailedDiff(t *testing.T) {
+	olds := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"compare": "a",
+		"create":  map[string]interface{}{"command": []interface{}{"echo", "hello"}, "stdin": "in"},
+		"update": map[string]interface{}{
+			"command":     []interface{}{"echo"},
+			"environment": map[string]interface{}{"VAR": "1", "OLD": "x"},
+		},
+	})
+	news := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"compare": "b",
+		"create":  map[string]interface{}{"command": []interface{}{"echo", "world", "!"}, "stdin": "in"},
+		"update": map[string]interface{}{
+			"command":     []interface{}{"echo"},
+			"environment": map[string]interface{}{"VAR": "2", "my.var": "y"},
+		},
+	})
+	got := map[string]pulumirpc.PropertyDiff_Kind{}
+	diffs := detailedDiff(olds, news)
+	for k, v := range diffs {
+		got[k] = v.Kind
+	}
+	want := map[string]pulumirpc.PropertyDiff_Kind{
+		"compare":                      pulumirpc.PropertyDiff_UPDATE,
+		"create.command[1]":            pulumirpc.PropertyDiff_UPDATE,
+		"create.command[2]":            pulumirpc.PropertyDiff_ADD,
+		"update.environment.VAR":       pulumirpc.PropertyDiff_UPDATE,
+		"update.environment.OLD":       pulumirpc.PropertyDiff_DELETE,
+		`update.environment["my.var"]`: pulumirpc.PropertyDiff_ADD,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("detailedDiff() = %v, want %v", got, want)
+	}
+	if keys := changedKeys(diffs); !reflect.DeepEqual(keys, []string{"compare", "create", "update"}) {
+		t.Errorf("changedKeys() = %v", keys)
+	}
+}
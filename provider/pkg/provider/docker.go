@@ -0,0 +1,551 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// image runs a command in a container created from an image, through the Docker Engine API.
+type image struct {
+	// Name is the image reference, such as "alpine:3.14" or "alpine@sha256:...".
+	Name   string  `pulumi:"name"`
+	Mounts []mount `pulumi:"mounts,optional"`
+	// NetworkMode is the network of the container, such as "none", "host" or the name of a network.
+	NetworkMode string `pulumi:"networkMode,optional" structpb:"networkMode"`
+	// User is the user the command runs as, such as "nobody" or "1000:1000". Defaults to the user of the image.
+	User string `pulumi:"user,optional"`
+}
+
+// mount binds a path of the machine running the provider into the container.
+type mount struct {
+	Source   string `pulumi:"source"`
+	Target   string `pulumi:"target"`
+	ReadOnly bool   `pulumi:"readOnly,optional" structpb:"readOnly"`
+}
+
+// dockerAPIVersion is the version of the Docker Engine API the provider speaks, which Docker supports since 19.03.
+const dockerAPIVersion = "v1.40"
+
+// defaultDockerSocket is the socket of the Docker daemon when DOCKER_HOST is not set.
+const defaultDockerSocket = "/var/run/docker.sock"
+
+// dockerClient is a client of the Docker Engine API over a unix socket.
+type dockerClient struct {
+	socket string
+	http   *http.Client
+}
+
+// newDockerClient returns a client of the Docker daemon at DOCKER_HOST, which must be a unix socket.
+func newDockerClient() (*dockerClient, error) {
+	socket := defaultDockerSocket
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		if !strings.HasPrefix(host, "unix://") {
+			return nil, errors.Errorf("DOCKER_HOST %q is not a unix socket", host)
+		}
+		socket = strings.TrimPrefix(host, "unix://")
+	}
+	c := &dockerClient{socket: socket}
+	c.http = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return c.dial(ctx)
+		},
+	}}
+	return c, nil
+}
+
+func (c *dockerClient) dial(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", c.socket)
+	return conn, errors.Wrapf(err, "could not connect to the Docker daemon at %s", c.socket)
+}
+
+// dockerAPIError is an error response of the Docker API.
+type dockerAPIError struct {
+	status  int
+	message string
+}
+
+func (e *dockerAPIError) Error() string {
+	return "Docker API: " + e.message
+}
+
+// isNotFound reports whether the Docker API did not find an image or container.
+func isNotFound(err error) bool {
+	apiError, ok := errors.Cause(err).(*dockerAPIError)
+	return ok && apiError.status == http.StatusNotFound
+}
+
+// readDockerError reads the error of a failed response.
+func readDockerError(resp *http.Response) error {
+	data, _ := ioutil.ReadAll(resp.Body)
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Message == "" {
+		body.Message = strings.TrimSpace(string(data))
+	}
+	return &dockerAPIError{status: resp.StatusCode, message: body.Message}
+}
+
+func (c *dockerClient) request(ctx context.Context, method, endpoint string, query url.Values, body interface{}) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+	u := "http://docker/" + dockerAPIVersion + endpoint
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req.WithContext(ctx), nil
+}
+
+// send sends a request to the Docker API and returns its successful response.
+func (c *dockerClient) send(ctx context.Context, method, endpoint string, query url.Values, body interface{}) (*http.Response, error) {
+	req, err := c.request(ctx, method, endpoint, query, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, readDockerError(resp)
+	}
+	return resp, nil
+}
+
+// do sends a request to the Docker API and decodes its JSON response into out, unless out is nil.
+func (c *dockerClient) do(ctx context.Context, method, endpoint string, query url.Values, body, out interface{}) error {
+	resp, err := c.send(ctx, method, endpoint, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// imageRepository returns the repository of an image reference, without its tag or digest.
+func imageRepository(name string) string {
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name
+}
+
+// resolveImage returns the digest an image reference resolves to and a reference that pins it. The digest
+// is looked up in the registry of the image, so that a tag that was moved to a new image is noticed. An
+// image that is not in a registry, such as one built locally, resolves to its ID.
+func (c *dockerClient) resolveImage(ctx context.Context, name string) (digest, ref string, err error) {
+	var distribution struct {
+		Descriptor struct {
+			Digest string
+		}
+	}
+	err = c.do(ctx, http.MethodGet, "/distribution/"+name+"/json", nil, nil, &distribution)
+	if err == nil && distribution.Descriptor.Digest != "" {
+		digest = distribution.Descriptor.Digest
+		return digest, imageRepository(name) + "@" + digest, nil
+	}
+	logging.V(5).Infof("could not look up %s in its registry: %v", name, err)
+	var local struct {
+		ID          string `json:"Id"`
+		RepoDigests []string
+	}
+	if err := c.do(ctx, http.MethodGet, "/images/"+name+"/json", nil, nil, &local); err != nil {
+		return "", "", errors.Wrapf(err, "could not resolve image %s", name)
+	}
+	// An image that was pulled before the registry became unreachable keeps the digest it was pulled by.
+	repository := imageRepository(name)
+	for _, repoDigest := range local.RepoDigests {
+		if imageRepository(repoDigest) == repository {
+			return repoDigest[strings.Index(repoDigest, "@")+1:], local.ID, nil
+		}
+	}
+	return local.ID, local.ID, nil
+}
+
+// pullImage pulls an image unless it is present already.
+func (c *dockerClient) pullImage(ctx context.Context, ref string) error {
+	err := c.do(ctx, http.MethodGet, "/images/"+ref+"/json", nil, nil, nil)
+	if !isNotFound(err) {
+		return err
+	}
+	logging.V(5).Infof("pulling image %s", ref)
+	resp, err := c.send(ctx, http.MethodPost, "/images/create", url.Values{"fromImage": {ref}}, nil)
+	if err != nil {
+		return errors.Wrapf(err, "could not pull image %s", ref)
+	}
+	defer resp.Body.Close()
+	// Errors that occur once the pull started are reported in its progress messages.
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var progress struct {
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&progress); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrapf(err, "could not pull image %s", ref)
+		}
+		if progress.Error != "" {
+			return errors.Errorf("could not pull image %s: %s", ref, progress.Error)
+		}
+	}
+}
+
+// attach attaches to the streams of a container, returning the connection they are multiplexed on.
+func (c *dockerClient) attach(ctx context.Context, id string, stdin bool) (net.Conn, io.Reader, error) {
+	query := url.Values{"stream": {"1"}, "stdout": {"1"}, "stderr": {"1"}}
+	if stdin {
+		query.Set("stdin", "1")
+	}
+	req, err := c.request(ctx, http.MethodPost, "/containers/"+id+"/attach", query, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols && resp.StatusCode != http.StatusOK {
+		defer conn.Close()
+		return nil, nil, readDockerError(resp)
+	}
+	return conn, r, nil
+}
+
+// demux copies the multiplexed output of a container to stdout and stderr. Each frame has an 8 byte
+// header holding its stream and size.
+func demux(r io.Reader, stdout, stderr io.Writer) error {
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		w := stdout
+		if header[0] == 2 {
+			w = stderr
+		}
+		if _, err := io.CopyN(w, r, int64(binary.BigEndian.Uint32(header[4:]))); err != nil {
+			return err
+		}
+	}
+}
+
+// waitContainer waits for the next exit of a container, which is reported on the returned channel. The
+// wait starts before waitContainer returns, so that it also observes a container that exits right away.
+func (c *dockerClient) waitContainer(id string) (<-chan error, error) {
+	resp, err := c.send(context.Background(), http.MethodPost, "/containers/"+id+"/wait", url.Values{"condition": {"next-exit"}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	exited := make(chan error, 1)
+	go func() {
+		defer resp.Body.Close()
+		var status struct {
+			StatusCode int
+			Error      *struct {
+				Message string
+			}
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+			exited <- err
+			return
+		}
+		if status.Error != nil && status.Error.Message != "" {
+			exited <- errors.New(status.Error.Message)
+			return
+		}
+		if status.StatusCode != 0 {
+			exited <- &containerExitError{code: status.StatusCode}
+			return
+		}
+		exited <- nil
+	}()
+	return exited, nil
+}
+
+// kill sends a signal to the process of a container.
+func (c *dockerClient) kill(id, signal string) {
+	if err := c.do(context.Background(), http.MethodPost, "/containers/"+id+"/kill", url.Values{"signal": {signal}}, nil, nil); err != nil {
+		logging.V(5).Infof("failed to send %s to container %s: %v", signal, id, err)
+	}
+}
+
+// containerExitError reports the exit status of a command run in a container.
+type containerExitError struct {
+	code int
+}
+
+func (e *containerExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+func (e *containerExitError) ExitCode() int {
+	return e.code
+}
+
+// binds returns the mounts of the image in the form of the Docker API. Relative sources are resolved
+// against the Pulumi project root.
+func (img image) binds() ([]string, error) {
+	var binds []string
+	for _, m := range img.Mounts {
+		source, err := filepath.Abs(m.Source)
+		if err != nil {
+			return nil, err
+		}
+		bind := source + ":" + m.Target
+		if m.ReadOnly {
+			bind += ":ro"
+		}
+		binds = append(binds, bind)
+	}
+	return binds, nil
+}
+
+// runContainer runs args in dir of a new container of img with the variables env, and returns the digest
+// of the image it ran. If ctx is done first, the command is sent the stop signal and, if it is still
+// running after the grace period, killed. The container is removed once the command exits.
+func runContainer(ctx context.Context, img image, args []string, dir string, env []string, stdin io.Reader, stdout, stderr io.Writer, stop stopPolicy) (string, error) {
+	docker, err := newDockerClient()
+	if err != nil {
+		return "", err
+	}
+	digest, ref, err := docker.resolveImage(ctx, img.Name)
+	if err != nil {
+		return "", err
+	}
+	if err := docker.pullImage(ctx, ref); err != nil {
+		return digest, err
+	}
+	binds, err := img.binds()
+	if err != nil {
+		return digest, err
+	}
+	config := map[string]interface{}{
+		"Image":        ref,
+		"Cmd":          args,
+		"Env":          env,
+		"WorkingDir":   dir,
+		"User":         img.User,
+		"AttachStdin":  stdin != nil,
+		"AttachStdout": true,
+		"AttachStderr": true,
+		"OpenStdin":    stdin != nil,
+		"StdinOnce":    stdin != nil,
+		"HostConfig": map[string]interface{}{
+			"Binds":       binds,
+			"NetworkMode": img.NetworkMode,
+		},
+	}
+	var created struct {
+		ID string `json:"Id"`
+	}
+	if err := docker.do(ctx, http.MethodPost, "/containers/create", nil, config, &created); err != nil {
+		return digest, errors.Wrap(err, "could not create the container")
+	}
+	id := created.ID
+	defer func() {
+		err := docker.do(context.Background(), http.MethodDelete, "/containers/"+id, url.Values{"force": {"1"}}, nil, nil)
+		if err != nil {
+			logging.V(5).Infof("failed to remove container %s: %v", id, err)
+		}
+	}()
+	conn, streams, err := docker.attach(ctx, id, stdin != nil)
+	if err != nil {
+		return digest, errors.Wrap(err, "could not attach to the container")
+	}
+	defer conn.Close()
+	exited, err := docker.waitContainer(id)
+	if err != nil {
+		return digest, err
+	}
+	if err := docker.do(ctx, http.MethodPost, "/containers/"+id+"/start", nil, nil, nil); err != nil {
+		return digest, errors.Wrap(err, "could not start the container")
+	}
+	if stdin != nil {
+		go func() {
+			_, _ = io.Copy(conn, stdin)
+			if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+				_ = cw.CloseWrite()
+			}
+		}()
+	}
+	copied := make(chan error, 1)
+	go func() {
+		copied <- demux(streams, stdout, stderr)
+	}()
+
+	select {
+	case err := <-exited:
+		if copyErr := <-copied; err == nil && copyErr != nil {
+			err = copyErr
+		}
+		return digest, err
+	case <-ctx.Done():
+	}
+	docker.kill(id, signalName(stop.signal))
+	select {
+	case <-exited:
+	case <-time.After(stop.grace):
+		docker.kill(id, "SIGKILL")
+		<-exited
+	}
+	return digest, ctx.Err()
+}
+
+// imageDigestsKey is the state field that maps each image that a create or update command ran in to the digest
+// it resolved to. The imageDigest output only holds the digest of the last command, whose image may differ.
+const imageDigestsKey = "imageDigests"
+
+// imageDigests returns the state field that records that a command ran in the image name with digest.
+func imageDigests(name, digest string) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{
+		Fields: map[string]*structpb.Value{name: {Kind: &structpb.Value_StringValue{StringValue: digest}}},
+	}}}
+}
+
+// keepImageDigests copies the digests recorded in the previous state olds to out, for the images that the
+// command of out did not run in.
+func keepImageDigests(out, olds *structpb.Struct) {
+	old := olds.GetFields()[imageDigestsKey].GetStructValue()
+	if len(old.GetFields()) == 0 {
+		return
+	}
+	merged := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for name, digest := range old.GetFields() {
+		merged.Fields[name] = digest
+	}
+	for name, digest := range out.Fields[imageDigestsKey].GetStructValue().GetFields() {
+		merged.Fields[name] = digest
+	}
+	out.Fields[imageDigestsKey] = &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: merged}}
+}
+
+// imageChanged reports whether the image of a command now resolves to another digest than the one a command
+// last ran in it with. An image that no command has run in yet, or that cannot be resolved, for example
+// because Docker is not running, is not a change.
+func imageChanged(ctx context.Context, c cmd, state resource.PropertyMap) bool {
+	if c.Image == nil || !state[imageDigestsKey].IsObject() {
+		return false
+	}
+	old := state[imageDigestsKey].ObjectValue()[resource.PropertyKey(c.Image.Name)]
+	if !old.IsString() {
+		return false
+	}
+	docker, err := newDockerClient()
+	if err != nil {
+		logging.V(1).Infof("Diff check: %v", err)
+		return false
+	}
+	digest, _, err := docker.resolveImage(ctx, c.Image.Name)
+	if err != nil {
+		logging.V(1).Infof("Diff check: %v", err)
+		return false
+	}
+	return digest != old.StringValue()
+}
+
+// checkImage validates the image of a command, which is not supported by RemoteCommand nor with scriptFile.
+func (c *checker) checkImage(path string, spec resource.PropertyMap) {
+	v := spec["image"]
+	if !v.IsObject() {
+		return
+	}
+	if c.remote {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "image"),
+			Reason:   "image is not supported by RemoteCommand",
+		})
+	}
+	if scriptFile := spec["scriptFile"]; scriptFile.IsBool() && scriptFile.BoolValue() {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "scriptFile"),
+			Reason:   "scriptFile is not supported with image",
+		})
+	}
+	img := v.ObjectValue()
+	if name := img["name"]; name.IsString() && name.StringValue() == "" {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: propertyPath(path, "image.name"), Reason: "name must not be empty"})
+	}
+	if mounts := img["mounts"]; mounts.IsArray() {
+		for i, m := range mounts.ArrayValue() {
+			if !m.IsObject() {
+				continue
+			}
+			if target := m.ObjectValue()["target"]; target.IsString() && !strings.HasPrefix(target.StringValue(), "/") {
+				c.failures = append(c.failures, &pulumirpc.CheckFailure{
+					Property: propertyPath(path, fmt.Sprintf("image.mounts[%d].target", i)),
+					Reason:   "expected an absolute path in the container",
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,474 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// fakeDocker is an in-process Docker Engine API server. Its containers run their command on the local
+// machine, and its registry maps image references to digests.
+type fakeDocker struct {
+	mu sync.Mutex
+	// registry maps the images that can be pulled to their digests.
+	registry map[string]string
+	// pulled are the references of the images that were pulled.
+	pulled map[string]bool
+	// containers are the configurations of the containers that were created, by ID.
+	containers map[string]*fakeContainer
+	removed    []string
+}
+
+type fakeContainer struct {
+	config struct {
+		Image      string
+		Cmd        []string
+		Env        []string
+		WorkingDir string
+		User       string
+		OpenStdin  bool
+		HostConfig struct {
+			Binds       []string
+			NetworkMode string
+		}
+	}
+	conn   net.Conn
+	cmd    *exec.Cmd
+	exited chan int
+}
+
+// newFakeDocker starts a server and points DOCKER_HOST at it for the duration of the test.
+func newFakeDocker(t *testing.T, registry map[string]string) *fakeDocker {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "docker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "docker.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &fakeDocker{registry: registry, pulled: map[string]bool{}, containers: map[string]*fakeContainer{}}
+	server := &http.Server{Handler: http.StripPrefix("/"+dockerAPIVersion, d)}
+	go server.Serve(l)
+	t.Cleanup(func() { server.Close() })
+	old, ok := os.LookupEnv("DOCKER_HOST")
+	os.Setenv("DOCKER_HOST", "unix://"+socket)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv("DOCKER_HOST", old)
+		} else {
+			os.Unsetenv("DOCKER_HOST")
+		}
+	})
+	return d
+}
+
+func (d *fakeDocker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"message": "not found: %s"}`, r.URL.Path)
+	}
+	switch {
+	case parts[0] == "distribution" && r.Method == http.MethodGet:
+		name := strings.Join(parts[1:len(parts)-1], "/")
+		digest, ok := d.registry[name]
+		if !ok {
+			notFound()
+			return
+		}
+		fmt.Fprintf(w, `{"Descriptor": {"Digest": %q}}`, digest)
+	case parts[0] == "images" && parts[1] == "create" && r.Method == http.MethodPost:
+		ref := r.URL.Query().Get("fromImage")
+		d.pulled[ref] = true
+		fmt.Fprintf(w, `{"status": "Pulling from %s"}`+"\n"+`{"status": "Downloaded"}`+"\n", ref)
+	case parts[0] == "images" && r.Method == http.MethodGet:
+		ref := strings.Join(parts[1:len(parts)-1], "/")
+		if !d.pulled[ref] {
+			notFound()
+			return
+		}
+		fmt.Fprintf(w, `{"Id": "sha256:local", "RepoDigests": [%q]}`, ref)
+	case parts[0] == "containers" && parts[1] == "create":
+		c := &fakeContainer{exited: make(chan int, 1)}
+		if err := json.NewDecoder(r.Body).Decode(&c.config); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !d.pulled[c.config.Image] {
+			notFound()
+			return
+		}
+		id := fmt.Sprintf("c%d", len(d.containers)+1)
+		d.containers[id] = c
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"Id": %q}`, id)
+	default:
+		c, ok := d.containers[parts[1]]
+		if !ok || parts[0] != "containers" {
+			notFound()
+			return
+		}
+		d.container(w, r, parts[1], c, parts[len(parts)-1])
+	}
+}
+
+// container handles the requests on a container. The lock of the server is held.
+func (d *fakeDocker) container(w http.ResponseWriter, r *http.Request, id string, c *fakeContainer, action string) {
+	switch action {
+	case "attach":
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		buf.WriteString("HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.raw-stream\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+		buf.Flush()
+		c.conn = conn
+	case "wait":
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		d.mu.Unlock()
+		code := <-c.exited
+		d.mu.Lock()
+		fmt.Fprintf(w, `{"StatusCode": %d}`, code)
+	case "start":
+		c.cmd = exec.Command(c.config.Cmd[0], c.config.Cmd[1:]...)
+		c.cmd.Env = c.config.Env
+		c.cmd.Dir = c.config.WorkingDir
+		if c.config.OpenStdin {
+			c.cmd.Stdin = c.conn
+		}
+		c.cmd.Stdout = &frameWriter{w: c.conn, stream: 1}
+		c.cmd.Stderr = &frameWriter{w: c.conn, stream: 2}
+		if err := c.cmd.Start(); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, `{"message": %q}`, err.Error())
+			return
+		}
+		go func() {
+			code := 0
+			if err := c.cmd.Wait(); err != nil {
+				code = 137
+				if exitError, ok := err.(*exec.ExitError); ok && exitError.ExitCode() >= 0 {
+					code = exitError.ExitCode()
+				}
+			}
+			c.conn.Close()
+			c.exited <- code
+		}()
+		w.WriteHeader(http.StatusNoContent)
+	case "kill":
+		sig, err := parseStopSignal(r.URL.Query().Get("signal"))
+		if err == nil && c.cmd != nil && c.cmd.Process != nil {
+			c.cmd.Process.Signal(sig)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		if r.Method == http.MethodDelete {
+			d.removed = append(d.removed, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// frameWriter writes the output of a container in frames of the multiplexed stream of the Docker API.
+type frameWriter struct {
+	w      io.Writer
+	stream byte
+	mu     sync.Mutex
+}
+
+func (f *frameWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	header := make([]byte, 8)
+	header[0] = f.stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(p)))
+	if _, err := f.w.Write(append(header, p...)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func Test_commandProvider_Image(t *testing.T) {
+	docker := newFakeDocker(t, map[string]string{"alpine:3.14": "sha256:aaa"})
+	mountDir := t.TempDir()
+	tests := []struct {
+		name       string
+		image      resource.PropertyMap
+		cmd        resource.PropertyMap
+		wantStdout string
+		wantStderr string
+		wantCode   int
+		wantDigest string
+		wantErr    string
+	}{
+		{
+			name:  "Run in the image",
+			image: resource.PropertyMap{"name": resource.NewStringProperty("alpine:3.14")},
+			cmd: resource.PropertyMap{
+				"script":      resource.NewStringProperty(`echo "$GREETING"; cat; echo oops >&2`),
+				"environment": resource.NewObjectProperty(resource.PropertyMap{"GREETING": resource.NewStringProperty("hello")}),
+				"stdin":       resource.NewStringProperty("from stdin"),
+			},
+			wantStdout: "hello\nfrom stdin",
+			wantStderr: "oops\n",
+			wantDigest: "sha256:aaa",
+		},
+		{
+			name:  "Allowed exit code",
+			image: resource.PropertyMap{"name": resource.NewStringProperty("alpine:3.14")},
+			cmd: resource.PropertyMap{
+				"script":           resource.NewStringProperty("exit 3"),
+				"allowedExitCodes": resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(3)}),
+			},
+			wantCode:   3,
+			wantDigest: "sha256:aaa",
+		},
+		{
+			name:    "Failing command",
+			image:   resource.PropertyMap{"name": resource.NewStringProperty("alpine:3.14")},
+			cmd:     resource.PropertyMap{"script": resource.NewStringProperty("echo failed >&2; exit 2")},
+			wantErr: "failed",
+		},
+		{
+			name:    "Unknown image",
+			image:   resource.PropertyMap{"name": resource.NewStringProperty("missing:latest")},
+			cmd:     resource.PropertyMap{"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")})},
+			wantErr: "could not resolve image missing:latest",
+		},
+		{
+			name: "Mounts, network and user",
+			image: resource.PropertyMap{
+				"name": resource.NewStringProperty("alpine:3.14"),
+				"mounts": resource.NewArrayProperty([]resource.PropertyValue{resource.NewObjectProperty(resource.PropertyMap{
+					"source":   resource.NewStringProperty(mountDir),
+					"target":   resource.NewStringProperty("/work"),
+					"readOnly": resource.NewBoolProperty(true),
+				})}),
+				"networkMode": resource.NewStringProperty("none"),
+				"user":        resource.NewStringProperty("1000:1000"),
+			},
+			cmd:        resource.PropertyMap{"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")})},
+			wantDigest: "sha256:aaa",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			spec := tt.cmd.Copy()
+			spec["image"] = resource.NewObjectProperty(tt.image)
+			resp, err := p.Create(context.Background(), &pulumirpc.CreateRequest{
+				Urn:        "urn:pulumi:command-test::command-test::command:v1:Command::demo",
+				Properties: mustMarshal(t, resource.PropertyMap{"create": resource.NewObjectProperty(spec)}),
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			out := mustUnmarshal(t, resp.GetProperties())
+			if got := out["stdout"].StringValue(); got != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", got, tt.wantStdout)
+			}
+			if got := out["stderr"].StringValue(); got != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", got, tt.wantStderr)
+			}
+			if got := int(out["exitCode"].NumberValue()); got != tt.wantCode {
+				t.Errorf("exitCode = %v, want %v", got, tt.wantCode)
+			}
+			if got := out["imageDigest"].StringValue(); got != tt.wantDigest {
+				t.Errorf("imageDigest = %q, want %q", got, tt.wantDigest)
+			}
+		})
+	}
+
+	docker.mu.Lock()
+	defer docker.mu.Unlock()
+	if !docker.pulled["alpine@sha256:aaa"] {
+		t.Errorf("the image was not pulled by its digest: %v", docker.pulled)
+	}
+	if len(docker.removed) != len(docker.containers) {
+		t.Errorf("removed containers %v, want all of %d", docker.removed, len(docker.containers))
+	}
+	last := docker.containers[fmt.Sprintf("c%d", len(docker.containers))].config
+	if !reflect.DeepEqual(last.HostConfig.Binds, []string{mountDir + ":/work:ro"}) || last.HostConfig.NetworkMode != "none" || last.User != "1000:1000" {
+		t.Errorf("container config = %+v", last)
+	}
+	for _, kv := range last.Env {
+		if strings.HasPrefix(kv, "PATH=") || strings.HasPrefix(kv, "HOME=") {
+			t.Errorf("container inherited %s from the provider", kv)
+		}
+	}
+}
+
+func Test_commandProvider_DiffImageDigest(t *testing.T) {
+	docker := newFakeDocker(t, map[string]string{"alpine:3.14": "sha256:aaa", "busybox:1": "sha256:ccc"})
+	inImage := func(name string) resource.PropertyValue {
+		return resource.NewObjectProperty(resource.PropertyMap{
+			"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")}),
+			"image":   resource.NewObjectProperty(resource.PropertyMap{"name": resource.NewStringProperty(name)}),
+		})
+	}
+	inputs := resource.PropertyMap{"create": inImage("alpine:3.14"), "update": inImage("alpine:3.14")}
+	olds := resource.PropertyMap{
+		"inputs":      resource.NewObjectProperty(inputs),
+		"imageDigest": resource.NewStringProperty("sha256:aaa"),
+		"imageDigests": resource.NewObjectProperty(resource.PropertyMap{
+			"alpine:3.14": resource.NewStringProperty("sha256:aaa"),
+		}),
+	}
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	diff := func() *pulumirpc.DiffResponse {
+		t.Helper()
+		resp, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{
+			Urn:  "urn:pulumi:command-test::command-test::command:v1:Command::demo",
+			Olds: mustMarshal(t, olds),
+			News: mustMarshal(t, inputs),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	if resp := diff(); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_NONE {
+		t.Errorf("Diff() with the same digest = %v, want DIFF_NONE", resp)
+	}
+	// The tag was moved to another image in the registry.
+	docker.mu.Lock()
+	docker.registry["alpine:3.14"] = "sha256:bbb"
+	docker.mu.Unlock()
+	if resp := diff(); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_SOME || resp.GetDetailedDiff()["imageDigest"] == nil {
+		t.Errorf("Diff() with a new digest = %v, want an update of imageDigest", resp)
+	}
+	// The update command runs in an image that no command has run in yet, so the digest of the create command
+	// in another image is not compared with it.
+	inputs["update"] = inImage("busybox:1")
+	olds["inputs"] = resource.NewObjectProperty(inputs)
+	if resp := diff(); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_NONE {
+		t.Errorf("Diff() of an update command in another image = %v, want DIFF_NONE", resp)
+	}
+	inputs["update"] = inImage("alpine:3.14")
+	olds["inputs"] = resource.NewObjectProperty(inputs)
+	// An image that cannot be resolved is not a change.
+	os.Setenv("DOCKER_HOST", "unix:///does/not/exist.sock")
+	if resp := diff(); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_NONE {
+		t.Errorf("Diff() without Docker = %v, want DIFF_NONE", resp)
+	}
+}
+
+func Test_commandProvider_UpdateImageDigests(t *testing.T) {
+	newFakeDocker(t, map[string]string{"alpine:3.14": "sha256:aaa", "busybox:1": "sha256:ccc"})
+	inImage := func(name string) resource.PropertyValue {
+		return resource.NewObjectProperty(resource.PropertyMap{
+			"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")}),
+			"image":   resource.NewObjectProperty(resource.PropertyMap{"name": resource.NewStringProperty(name)}),
+		})
+	}
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	inputs := mustMarshal(t, resource.PropertyMap{"create": inImage("alpine:3.14"), "update": inImage("busybox:1")})
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	created, err := p.Create(context.Background(), &pulumirpc.CreateRequest{Urn: urn, Properties: inputs})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	updated, err := p.Update(context.Background(), &pulumirpc.UpdateRequest{Urn: urn, Id: created.GetId(), Olds: created.GetProperties(), News: inputs})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	// The digest of each command is kept, so that a diff compares the update command with its own image.
+	state := mustUnmarshal(t, updated.GetProperties())
+	want := resource.NewObjectProperty(resource.PropertyMap{
+		"alpine:3.14": resource.NewStringProperty("sha256:aaa"),
+		"busybox:1":   resource.NewStringProperty("sha256:ccc"),
+	})
+	if !state["imageDigests"].DeepEquals(want) || state["imageDigest"].StringValue() != "sha256:ccc" {
+		t.Errorf("Update() imageDigests = %v, imageDigest = %v, want %v", state["imageDigests"], state["imageDigest"], want)
+	}
+}
+
+func Test_commandProvider_CheckImage(t *testing.T) {
+	image := resource.NewObjectProperty(resource.PropertyMap{
+		"name": resource.NewStringProperty(""),
+		"mounts": resource.NewArrayProperty([]resource.PropertyValue{resource.NewObjectProperty(resource.PropertyMap{
+			"source": resource.NewStringProperty("."),
+			"target": resource.NewStringProperty("work"),
+		})}),
+	})
+	create := resource.NewObjectProperty(resource.PropertyMap{
+		"script":     resource.NewStringProperty("echo"),
+		"scriptFile": resource.NewBoolProperty(true),
+		"dir":        resource.NewStringProperty("/in/the/container"),
+		"image":      image,
+	})
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	resp, err := p.Check(context.Background(), &pulumirpc.CheckRequest{
+		Urn:  "urn:pulumi:command-test::command-test::command:v1:Command::demo",
+		News: mustMarshal(t, resource.PropertyMap{"create": create}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, f := range resp.GetFailures() {
+		got = append(got, f.GetProperty())
+	}
+	want := []string{"create.scriptFile", "create.image.name", "create.image.mounts[0].target"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() failures = %v, want %v", resp.GetFailures(), want)
+	}
+
+	// The arguments of the run function are the command itself.
+	invoked, err := p.Invoke(context.Background(), &pulumirpc.InvokeRequest{Tok: runFunction, Args: mustMarshal(t, create.ObjectValue())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = []string{}
+	for _, f := range invoked.GetFailures() {
+		got = append(got, f.GetProperty())
+	}
+	want = []string{"scriptFile", "image.name", "image.mounts[0].target"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Invoke() failures = %v, want %v", invoked.GetFailures(), want)
+	}
+}
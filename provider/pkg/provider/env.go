@@ -0,0 +1,81 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Modes for inheriting the provider's environment.
+const (
+	inheritEnvAll       = "all"
+	inheritEnvAllowlist = "allowlist"
+	inheritEnvNone      = "none"
+)
+
+// inheritEnvModes lists the accepted values of inheritEnv. The first entry is the default.
+var inheritEnvModes = []string{inheritEnvAll, inheritEnvAllowlist, inheritEnvNone}
+
+// inheritMode returns the environment inheritance mode, applying the default.
+func (c cmd) inheritMode() string {
+	if c.InheritEnv == "" {
+		return inheritEnvAll
+	}
+	return c.InheritEnv
+}
+
+// environ computes the environment for the command from the parent environment.
+// Variables are inherited according to inheritEnv, then those listed in unsetEnv are removed,
+// and finally the user provided environment is merged in. The result is sorted by name.
+func (c cmd) environ(parent []string) []string {
+	allowed := make(map[string]bool, len(c.EnvAllowlist))
+	for _, name := range c.EnvAllowlist {
+		allowed[name] = true
+	}
+	env := make(map[string]string)
+	mode := c.inheritMode()
+	for _, kv := range parent {
+		name, value := splitEnv(kv)
+		if mode == inheritEnvAll || (mode == inheritEnvAllowlist && allowed[name]) {
+			env[name] = value
+		}
+	}
+	for _, name := range c.UnsetEnv {
+		delete(env, name)
+	}
+	for k, v := range c.Environment {
+		env[k] = v
+	}
+
+	environment := make([]string, 0, len(env))
+	for k, v := range env {
+		environment = append(environment, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(environment)
+	return environment
+}
+
+func splitEnv(kv string) (string, string) {
+	if kv == "" {
+		return "", ""
+	}
+	// Windows has entries such as "=C:=C:\\" which begin with an equals sign.
+	if i := strings.Index(kv[1:], "="); i >= 0 {
+		return kv[:i+1], kv[i+2:]
+	}
+	return kv, ""
+}
@@ -0,0 +1,57 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_cmd_environ(t *testing.T) {
+	parent := []string{"PATH=/usr/bin", "HOME=/home/me", "SECRET=hunter2", "EMPTY="}
+	tests := []struct {
+		name string
+		cmd  cmd
+		want []string
+	}{
+		{
+			name: "Default inherits all and merges",
+			cmd:  cmd{Environment: map[string]string{"VAR": "Hello", "HOME": "/tmp"}},
+			want: []string{"EMPTY=", "HOME=/tmp", "PATH=/usr/bin", "SECRET=hunter2", "VAR=Hello"},
+		},
+		{
+			name: "Allowlist",
+			cmd:  cmd{InheritEnv: inheritEnvAllowlist, EnvAllowlist: []string{"PATH", "MISSING"}},
+			want: []string{"PATH=/usr/bin"},
+		},
+		{
+			name: "None",
+			cmd:  cmd{InheritEnv: inheritEnvNone, Environment: map[string]string{"VAR": "Hello"}},
+			want: []string{"VAR=Hello"},
+		},
+		{
+			name: "Unset",
+			cmd:  cmd{UnsetEnv: []string{"SECRET", "EMPTY"}},
+			want: []string{"HOME=/home/me", "PATH=/usr/bin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmd.environ(parent); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cmd.environ() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// defaultID is the ID of a resource that does not specify idFrom.
+const defaultID = "id"
+
+// idFrom selects how the ID of a resource is derived. Exactly one field is set.
+type idFrom struct {
+	// Value is a static ID.
+	Value string `pulumi:"value,optional"`
+	// Stdout uses the stdout of the create command, without surrounding whitespace.
+	Stdout bool `pulumi:"stdout,optional"`
+	// Path selects a value from the parsed output of the create command, such as "items[0].id".
+	Path string `pulumi:"path,optional"`
+}
+
+// resourceID derives the ID of a resource from the outputs of its create command.
+func resourceID(from *idFrom, out *structpb.Struct) (string, error) {
+	if from == nil {
+		return defaultID, nil
+	}
+	var id string
+	switch {
+	case from.Value != "":
+		id = from.Value
+	case from.Stdout:
+		outputs, err := plainProperties(out)
+		if err != nil {
+			return "", err
+		}
+		id = strings.TrimSpace(outputs["stdout"].StringValue())
+	case from.Path != "":
+		v, err := lookupParsed(out, from.Path)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case v.IsString():
+			id = v.StringValue()
+		case v.IsNumber():
+			id = strconv.FormatFloat(v.NumberValue(), 'f', -1, 64)
+		default:
+			return "", errors.Errorf("idFrom.path %q selects a %v value, expected a string or number", from.Path, v.TypeString())
+		}
+	}
+	if id == "" {
+		return "", errors.New("idFrom produced an empty ID")
+	}
+	return id, nil
+}
+
+// parseJSONPath parses a path into a parsed output. A leading "$." is accepted so that JSONPath-style
+// paths can be used.
+func parseJSONPath(path string) (resource.PropertyPath, error) {
+	return resource.ParsePropertyPath(strings.TrimPrefix(strings.TrimPrefix(path, "$"), "."))
+}
+
+// decodeIDFrom decodes the idFrom input of a resource, which is nil when it is not set.
+func decodeIDFrom(props resource.PropertyMap) (*idFrom, error) {
+	v, ok := props["idFrom"]
+	if !ok || v.IsNull() {
+		return nil, nil
+	}
+	var from idFrom
+	if err := decodeProperty("idFrom", v, reflect.ValueOf(&from)); err != nil {
+		return nil, err
+	}
+	return &from, nil
+}
+
+// lookupParsed returns the value at path in the parsed output.
+func lookupParsed(out *structpb.Struct, path string) (resource.PropertyValue, error) {
+	parsed, ok := out.GetFields()["parsed"]
+	if !ok {
+		return resource.PropertyValue{}, errors.New("idFrom.path requires the create command to set outputFormat")
+	}
+	v, err := plugin.UnmarshalPropertyValue(parsed, plugin.MarshalOptions{})
+	if err != nil {
+		return resource.PropertyValue{}, err
+	}
+	if v.IsSecret() {
+		*v = v.SecretValue().Element
+	}
+	p, err := parseJSONPath(path)
+	if err != nil {
+		return resource.PropertyValue{}, errors.Wrapf(err, "invalid idFrom.path %q", path)
+	}
+	found, ok := p.Get(*v)
+	if !ok || found.IsNull() {
+		return resource.PropertyValue{}, errors.Errorf("idFrom.path %q does not match the parsed output", path)
+	}
+	return found, nil
+}
+
+// checkIdFrom validates that exactly one ID strategy is selected and that a path can be resolved.
+func (c *checker) checkIDFrom(path string, props resource.PropertyMap) {
+	from := props["idFrom"]
+	if !from.IsObject() {
+		return
+	}
+	spec := from.ObjectValue()
+	set := 0
+	for _, k := range []resource.PropertyKey{"value", "path"} {
+		if v := spec[k]; v.IsString() && v.StringValue() != "" {
+			set++
+		}
+	}
+	if v := spec["stdout"]; v.IsBool() && v.BoolValue() {
+		set++
+	}
+	if set != 1 {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: path,
+			Reason:   fmt.Sprintf("expected exactly one of value, stdout or path, received %d", set),
+		})
+		return
+	}
+	if v := spec["path"]; v.IsString() && v.StringValue() != "" {
+		if _, err := parseJSONPath(v.StringValue()); err != nil {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: propertyPath(path, "path"), Reason: err.Error()})
+		}
+		if create := props["create"]; create.IsObject() && create.ObjectValue()["outputFormat"].IsNull() {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{
+				Property: propertyPath(path, "path"),
+				Reason:   "idFrom.path requires create.outputFormat",
+			})
+		}
+	}
+}
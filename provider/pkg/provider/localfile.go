@@ -0,0 +1,447 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// localFileType is a file on the machine running the provider. Its ID is its path.
+const localFileType = "command:v1:LocalFile"
+
+// localFile are the inputs of a LocalFile resource.
+type localFile struct {
+	Path string `pulumi:"path,forceNew"`
+	// Content or ContentBase64 holds the content of the file.
+	Content       string `pulumi:"content,optional"`
+	ContentBase64 string `pulumi:"contentBase64,optional" structpb:"contentBase64"`
+	// Mode is the octal permission bits of the file.
+	Mode string `pulumi:"mode,optional"`
+	// Owner is the owning user of the file, optionally followed by a colon and its group.
+	Owner string `pulumi:"owner,optional"`
+}
+
+// defaultFileMode is the mode of a file that does not set one.
+const defaultFileMode = "0644"
+
+// decodeLocalFile decodes the inputs or state of a LocalFile resource.
+func decodeLocalFile(props resource.PropertyMap) (localFile, error) {
+	var f localFile
+	err := decodeProperty("", resource.NewObjectProperty(props), reflect.ValueOf(&f))
+	return f, err
+}
+
+// data returns the content of the file.
+func (f localFile) data() ([]byte, error) {
+	if f.ContentBase64 != "" {
+		b, err := base64.StdEncoding.DecodeString(f.ContentBase64)
+		return b, errors.Wrap(err, "invalid contentBase64")
+	}
+	return []byte(f.Content), nil
+}
+
+// perm returns the permission bits of the file.
+func (f localFile) perm() (os.FileMode, error) {
+	return parseFileMode(f.Mode)
+}
+
+// parseFileMode parses octal permission bits such as "0644". An empty mode is the default mode.
+func parseFileMode(mode string) (os.FileMode, error) {
+	if mode == "" {
+		mode = defaultFileMode
+	}
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || m > 0777 {
+		return 0, errors.Errorf("expected octal permission bits such as %q, received %q", defaultFileMode, mode)
+	}
+	return os.FileMode(m), nil
+}
+
+// formatFileMode formats permission bits the way they are written in the inputs.
+func formatFileMode(perm os.FileMode) string {
+	return fmt.Sprintf("%04o", perm.Perm())
+}
+
+// contentHash returns the hex-encoded SHA-256 hash of the content of a file.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// lookupOwner resolves an owner such as "www-data", "www-data:adm" or "33:4" to a user and group ID.
+// Without a group, the primary group of the user is used.
+func lookupOwner(owner string) (uid, gid int, err error) {
+	name, group := owner, ""
+	if i := strings.Index(owner, ":"); i >= 0 {
+		name, group = owner[:i], owner[i+1:]
+	}
+	u, err := user.Lookup(name)
+	if err != nil {
+		if u, err = user.LookupId(name); err != nil {
+			return 0, 0, errors.Wrapf(err, "unknown owner %q", name)
+		}
+	}
+	if uid, err = strconv.Atoi(u.Uid); err != nil {
+		return 0, 0, errors.Errorf("owner %q has no numeric user ID", name)
+	}
+	gidStr := u.Gid
+	if group != "" {
+		g, err := user.LookupGroup(group)
+		if err != nil {
+			if g, err = user.LookupGroupId(group); err != nil {
+				return 0, 0, errors.Wrapf(err, "unknown group %q", group)
+			}
+		}
+		gidStr = g.Gid
+	}
+	if gid, err = strconv.Atoi(gidStr); err != nil {
+		return 0, 0, errors.Errorf("group of owner %q has no numeric ID", owner)
+	}
+	return uid, gid, nil
+}
+
+// write replaces the file with its content atomically: the content is written to a temporary file in the
+// same directory, which is then renamed over the file.
+func (f localFile) write() error {
+	data, err := f.data()
+	if err != nil {
+		return err
+	}
+	perm, err := f.perm()
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(f.Path), "."+filepath.Base(f.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// The mode is set explicitly so that it does not depend on the umask of the provider.
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if f.Owner != "" {
+		uid, gid, err := lookupOwner(f.Owner)
+		if err != nil {
+			return err
+		}
+		if err := os.Chown(tmp.Name(), uid, gid); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// localFileOutputs returns the state of a file: its inputs together with the hash and size of its content.
+// The hash is unknown when the content is not known yet.
+func localFileOutputs(inputs resource.PropertyMap, f localFile) resource.PropertyMap {
+	outputs := inputs.Copy()
+	if _, ok := outputs["mode"]; !ok {
+		outputs["mode"] = resource.NewStringProperty(defaultFileMode)
+	}
+	if inputs["content"].ContainsUnknowns() || inputs["contentBase64"].ContainsUnknowns() {
+		outputs["sha256"] = resource.MakeComputed(resource.NewStringProperty(""))
+		outputs["size"] = resource.MakeComputed(resource.NewNumberProperty(0))
+		return outputs
+	}
+	data, _ := f.data()
+	outputs["sha256"] = resource.NewStringProperty(contentHash(data))
+	outputs["size"] = resource.NewNumberProperty(float64(len(data)))
+	return outputs
+}
+
+// marshalLocalFile marshals the state of a file for the engine.
+func (p *commandProvider) marshalLocalFile(label string, props resource.PropertyMap) (*structpb.Struct, error) {
+	return plugin.MarshalProperties(props, plugin.MarshalOptions{
+		Label: fmt.Sprintf("%s.%s", p.label(), label), KeepUnknowns: true, KeepSecrets: true, SkipNulls: true,
+	})
+}
+
+// checkLocalFile validates the inputs of a LocalFile resource.
+func (p *commandProvider) checkLocalFile(req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	news, err := plugin.UnmarshalProperties(req.GetNews(), plugin.MarshalOptions{
+		KeepUnknowns: true, KeepSecrets: true, SkipNulls: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	plain, err := plainProperties(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	var c checker
+	if err := c.checkProperty("", resource.NewObjectProperty(plain), reflect.TypeOf(localFile{})); err != nil {
+		return nil, err
+	}
+	if path := plain["path"]; path.IsString() && path.StringValue() == "" {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: "path", Reason: "path must not be empty"})
+	}
+	_, hasContent := plain["content"]
+	_, hasBase64 := plain["contentBase64"]
+	if hasContent == hasBase64 {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: "content",
+			Reason:   "expected exactly one of content and contentBase64",
+		})
+	}
+	if b := plain["contentBase64"]; b.IsString() {
+		if _, err := base64.StdEncoding.DecodeString(b.StringValue()); err != nil {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: "contentBase64", Reason: err.Error()})
+		}
+	}
+	if mode := plain["mode"]; mode.IsString() {
+		if _, err := parseFileMode(mode.StringValue()); err != nil {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: "mode", Reason: err.Error()})
+		}
+	} else if !mode.IsComputed() {
+		// Record the mode so that the state does not depend on the provider's defaults.
+		news["mode"] = resource.NewStringProperty(defaultFileMode)
+	}
+	inputs, err := p.marshalLocalFile(fmt.Sprintf("Check(%s).inputs", req.GetUrn()), news)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CheckResponse{Inputs: inputs, Failures: c.failures}, nil
+}
+
+// diffLocalFile compares the content of a file by its hash, so that content moved between content and
+// contentBase64 is not a change. Changing the path replaces the file.
+func (p *commandProvider) diffLocalFile(req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	news, err := plainProperties(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	olds, err := plainProperties(req.GetOlds())
+	if err != nil {
+		return nil, err
+	}
+	detailed := map[string]*pulumirpc.PropertyDiff{}
+	if path := news["path"]; path.ContainsUnknowns() || !path.DeepEquals(olds["path"]) {
+		detailed["path"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE_REPLACE, InputDiff: true}
+	}
+	contentKey := "content"
+	if _, ok := news["contentBase64"]; ok {
+		contentKey = "contentBase64"
+	}
+	if news.ContainsUnknowns() {
+		for _, k := range []string{contentKey, "mode", "owner"} {
+			if news[resource.PropertyKey(k)].ContainsUnknowns() {
+				detailed[k] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE, InputDiff: true}
+			}
+		}
+	} else {
+		f, err := decodeLocalFile(news)
+		if err != nil {
+			return nil, err
+		}
+		data, err := f.data()
+		if err != nil {
+			return nil, err
+		}
+		if oldHash := olds["sha256"]; !oldHash.IsString() || oldHash.StringValue() != contentHash(data) {
+			detailed[contentKey] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE, InputDiff: true}
+		}
+		newMode, err := f.perm()
+		if err != nil {
+			return nil, err
+		}
+		if oldMode, err := parseFileMode(olds["mode"].StringValue()); !olds["mode"].IsString() || err != nil || oldMode != newMode {
+			detailed["mode"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE, InputDiff: true}
+		}
+		if oldOwner := olds["owner"]; f.Owner != "" && (!oldOwner.IsString() || oldOwner.StringValue() != f.Owner) {
+			detailed["owner"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE, InputDiff: true}
+		}
+	}
+	if len(detailed) == 0 {
+		return &pulumirpc.DiffResponse{Changes: pulumirpc.DiffResponse_DIFF_NONE, Replaces: []string{}, Stables: []string{}}, nil
+	}
+	replaces := []string{}
+	if _, ok := detailed["path"]; ok {
+		replaces = append(replaces, "path")
+	}
+	return &pulumirpc.DiffResponse{
+		Changes:         pulumirpc.DiffResponse_DIFF_SOME,
+		Replaces:        replaces,
+		Stables:         []string{},
+		Diffs:           changedKeys(detailed),
+		DetailedDiff:    detailed,
+		HasDetailedDiff: true,
+	}, nil
+}
+
+// writeLocalFile writes the file described by props unless this is a preview, and returns its state.
+func (p *commandProvider) writeLocalFile(label string, props *structpb.Struct, preview bool) (localFile, *structpb.Struct, error) {
+	inputs, err := plugin.UnmarshalProperties(props, plugin.MarshalOptions{
+		KeepUnknowns: true, KeepSecrets: true, SkipNulls: true,
+	})
+	if err != nil {
+		return localFile{}, nil, err
+	}
+	var f localFile
+	if !inputs.ContainsUnknowns() {
+		if f, err = decodeLocalFile(inputs); err != nil {
+			return f, nil, err
+		}
+	} else if !preview {
+		return f, nil, errors.New("the file depends on values that are not known yet")
+	}
+	if !preview {
+		logging.V(9).Infof("%s.%s: writing %s", p.label(), label, f.Path)
+		if err := f.write(); err != nil {
+			return f, nil, errors.Wrapf(err, "failed to write %s", f.Path)
+		}
+	}
+	out, err := p.marshalLocalFile(label, localFileOutputs(inputs, f))
+	return f, out, err
+}
+
+func (p *commandProvider) createLocalFile(req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
+	f, out, err := p.writeLocalFile(fmt.Sprintf("Create(%s)", req.GetUrn()), req.GetProperties(), req.GetPreview())
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CreateResponse{Id: f.Path, Properties: out}, nil
+}
+
+func (p *commandProvider) updateLocalFile(req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
+	_, out, err := p.writeLocalFile(fmt.Sprintf("Update(%s)", req.GetUrn()), req.GetNews(), req.GetPreview())
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.UpdateResponse{Properties: out}, nil
+}
+
+// readLocalFile reads the file at the path in the ID of the resource. A file whose content, mode or owner
+// changed outside of Pulumi reports them in its state so that the next update restores the file. A file
+// that no longer exists is removed from the state.
+func (p *commandProvider) readLocalFile(req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
+	path := req.GetId()
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		logging.V(1).Infof("Read check: %s no longer exists", path)
+		return &pulumirpc.ReadResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	label := fmt.Sprintf("Read(%s)", req.GetUrn())
+	state, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{KeepSecrets: true, SkipNulls: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(state) == 0 {
+		// The file is being imported by its path.
+		inputs := resource.PropertyMap{
+			"path": resource.NewStringProperty(path),
+			"mode": resource.NewStringProperty(formatFileMode(info.Mode())),
+		}
+		if utf8.Valid(data) {
+			inputs["content"] = resource.NewStringProperty(string(data))
+		} else {
+			inputs["contentBase64"] = resource.NewStringProperty(base64.StdEncoding.EncodeToString(data))
+		}
+		f, err := decodeLocalFile(inputs)
+		if err != nil {
+			return nil, err
+		}
+		properties, err := p.marshalLocalFile(label, localFileOutputs(inputs, f))
+		if err != nil {
+			return nil, err
+		}
+		marshalled, err := p.marshalLocalFile(label+".inputs", inputs)
+		if err != nil {
+			return nil, err
+		}
+		return &pulumirpc.ReadResponse{Id: path, Properties: properties, Inputs: marshalled}, nil
+	}
+
+	hash := contentHash(data)
+	if old := state["sha256"]; !old.IsString() || old.StringValue() != hash {
+		logging.V(1).Infof("Read check: the content of %s has drifted", path)
+	}
+	state["sha256"] = resource.NewStringProperty(hash)
+	state["size"] = resource.NewNumberProperty(float64(len(data)))
+	if mode, err := parseFileMode(state["mode"].StringValue()); err != nil || mode != info.Mode().Perm() {
+		state["mode"] = resource.NewStringProperty(formatFileMode(info.Mode()))
+	}
+	if owner := state["owner"]; owner.IsString() && owner.StringValue() != "" {
+		uid, gid, err := lookupOwner(owner.StringValue())
+		if actualUID, actualGID, ok := fileOwner(info); ok && (err != nil || uid != actualUID || gid != actualGID) {
+			state["owner"] = resource.NewStringProperty(fmt.Sprintf("%d:%d", actualUID, actualGID))
+		}
+	}
+	properties, err := p.marshalLocalFile(label, state)
+	if err != nil {
+		return nil, err
+	}
+	inputs := req.GetInputs()
+	if len(inputs.GetFields()) == 0 {
+		// Older engines do not send the inputs, which the state of a file includes.
+		inputs = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		for k, v := range req.GetProperties().GetFields() {
+			if k != "sha256" && k != "size" {
+				inputs.Fields[k] = v
+			}
+		}
+	}
+	return &pulumirpc.ReadResponse{Id: path, Properties: properties, Inputs: inputs}, nil
+}
+
+// deleteLocalFile removes the file. A file that no longer exists is already deleted.
+func (p *commandProvider) deleteLocalFile(req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
+	if err := os.Remove(req.GetId()); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &pbempty.Empty{}, nil
+}
+
+// isLocalFile reports whether a request is for a LocalFile resource.
+func isLocalFile(req hasUrn) bool {
+	return resource.URN(req.GetUrn()).Type() == localFileType
+}
@@ -0,0 +1,218 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+const localFileURN = "urn:pulumi:command-test::command-test::command:v1:LocalFile::demo"
+
+func mustMarshal(t *testing.T, props resource.PropertyMap) *structpb.Struct {
+	t.Helper()
+	s, err := plugin.MarshalProperties(props, plugin.MarshalOptions{KeepSecrets: true, KeepUnknowns: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func Test_commandProvider_CheckLocalFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		news         resource.PropertyMap
+		wantFailures []string
+		wantMode     string
+	}{
+		{
+			name:     "Default mode",
+			news:     resource.PropertyMap{"path": resource.NewStringProperty("a.txt"), "content": resource.NewStringProperty("")},
+			wantMode: defaultFileMode,
+		},
+		{
+			name: "Invalid",
+			news: resource.PropertyMap{
+				"path":          resource.NewStringProperty(""),
+				"content":       resource.NewStringProperty("a"),
+				"contentBase64": resource.NewStringProperty("!"),
+				"mode":          resource.NewStringProperty("0999"),
+			},
+			wantFailures: []string{"path", "content", "contentBase64", "mode"},
+			wantMode:     "0999",
+		},
+		{
+			name:         "Missing",
+			news:         resource.PropertyMap{},
+			wantFailures: []string{"", "content"},
+			wantMode:     defaultFileMode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			resp, err := p.Check(context.Background(), &pulumirpc.CheckRequest{Urn: localFileURN, News: mustMarshal(t, tt.news)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, f := range resp.GetFailures() {
+				got = append(got, f.GetProperty())
+			}
+			if tt.wantFailures == nil {
+				tt.wantFailures = []string{}
+			}
+			if !reflect.DeepEqual(got, tt.wantFailures) {
+				t.Errorf("Check() failures = %v, want %v", resp.GetFailures(), tt.wantFailures)
+			}
+			if mode := resp.GetInputs().GetFields()["mode"].GetStringValue(); mode != tt.wantMode {
+				t.Errorf("Check() mode = %q, want %q", mode, tt.wantMode)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_LocalFile(t *testing.T) {
+	ctx := context.Background()
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	path := filepath.Join(t.TempDir(), "config.txt")
+	inputs := resource.PropertyMap{
+		"path":    resource.NewStringProperty(path),
+		"content": resource.MakeSecret(resource.NewStringProperty("hello\n")),
+		"mode":    resource.NewStringProperty("0600"),
+	}
+
+	created, err := p.Create(ctx, &pulumirpc.CreateRequest{Urn: localFileURN, Properties: mustMarshal(t, inputs)})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.GetId() != path {
+		t.Errorf("Create() id = %q, want %q", created.GetId(), path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Create() mode = %v, want 0600", info.Mode().Perm())
+	}
+	state, err := plugin.UnmarshalProperties(created.GetProperties(), plugin.MarshalOptions{KeepSecrets: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state["content"].IsSecret() {
+		t.Errorf("Create() content is not secret")
+	}
+	if state["sha256"].StringValue() != contentHash([]byte("hello\n")) {
+		t.Errorf("Create() sha256 = %v", state["sha256"])
+	}
+
+	// The same content in base64 is not a change, a new mode is.
+	diff := func(olds *structpb.Struct, news resource.PropertyMap) *pulumirpc.DiffResponse {
+		t.Helper()
+		resp, err := p.Diff(ctx, &pulumirpc.DiffRequest{Urn: localFileURN, Id: path, Olds: olds, News: mustMarshal(t, news)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	sameContent := resource.PropertyMap{
+		"path":          resource.NewStringProperty(path),
+		"contentBase64": resource.NewStringProperty(base64.StdEncoding.EncodeToString([]byte("hello\n"))),
+		"mode":          resource.NewStringProperty("600"),
+	}
+	if resp := diff(created.GetProperties(), sameContent); resp.GetChanges() != pulumirpc.DiffResponse_DIFF_NONE {
+		t.Errorf("Diff() = %v, want no changes", resp)
+	}
+	newMode := inputs.Copy()
+	newMode["mode"] = resource.NewStringProperty("0640")
+	if resp := diff(created.GetProperties(), newMode); !reflect.DeepEqual(resp.GetDiffs(), []string{"mode"}) {
+		t.Errorf("Diff() = %v, want a change to mode", resp)
+	}
+	newPath := inputs.Copy()
+	newPath["path"] = resource.NewStringProperty(path + ".new")
+	if resp := diff(created.GetProperties(), newPath); !reflect.DeepEqual(resp.GetReplaces(), []string{"path"}) {
+		t.Errorf("Diff() = %v, want a replacement", resp)
+	}
+
+	// A change made outside of Pulumi is found by refresh and reported by the next diff.
+	if err := ioutil.WriteFile(path, []byte("changed\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	read, err := p.Read(ctx, &pulumirpc.ReadRequest{Urn: localFileURN, Id: path, Properties: created.GetProperties(), Inputs: mustMarshal(t, inputs)})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if hash := read.GetProperties().GetFields()["sha256"].GetStringValue(); hash != contentHash([]byte("changed\n")) {
+		t.Errorf("Read() sha256 = %q", hash)
+	}
+	if resp := diff(read.GetProperties(), inputs); !reflect.DeepEqual(resp.GetDiffs(), []string{"content"}) {
+		t.Errorf("Diff() after drift = %v, want a change to content", resp)
+	}
+
+	if _, err := p.Update(ctx, &pulumirpc.UpdateRequest{Urn: localFileURN, Id: path, Olds: read.GetProperties(), News: mustMarshal(t, newMode)}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello\n" {
+		t.Errorf("Update() content = %q", data)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0640 {
+		t.Errorf("Update() mode = %v, %v, want 0640", info.Mode().Perm(), err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".config.txt.tmp-*")); len(matches) > 0 {
+		t.Errorf("Update() left temporary files %v", matches)
+	}
+
+	if _, err := p.Delete(ctx, &pulumirpc.DeleteRequest{Urn: localFileURN, Id: path, Properties: read.GetProperties()}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Delete() left %s: %v", path, err)
+	}
+	read, err = p.Read(ctx, &pulumirpc.ReadRequest{Urn: localFileURN, Id: path, Properties: created.GetProperties()})
+	if err != nil || read.GetId() != "" {
+		t.Errorf("Read() of a deleted file = %v, %v, want no ID", read, err)
+	}
+}
+
+func Test_commandProvider_ImportLocalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "binary")
+	if err := ioutil.WriteFile(path, []byte{0xff, 0x00}, 0755); err != nil {
+		t.Fatal(err)
+	}
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	read, err := p.Read(context.Background(), &pulumirpc.ReadRequest{Urn: localFileURN, Id: path})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	inputs := read.GetInputs().GetFields()
+	if inputs["contentBase64"].GetStringValue() != "/wA=" || inputs["mode"].GetStringValue() != "0755" || inputs["path"].GetStringValue() != path {
+		t.Errorf("Read() inputs = %v", read.GetInputs())
+	}
+}
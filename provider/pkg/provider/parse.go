@@ -0,0 +1,163 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats stdout can be parsed as.
+const (
+	outputFormatText   = "text"
+	outputFormatJSON   = "json"
+	outputFormatYAML   = "yaml"
+	outputFormatDotenv = "dotenv"
+	outputFormatLines  = "lines"
+)
+
+var outputFormats = []string{outputFormatText, outputFormatJSON, outputFormatYAML, outputFormatDotenv, outputFormatLines}
+
+// outputError reports that the stdout of a command that succeeded could not be parsed.
+type outputError struct {
+	op  string
+	err error
+}
+
+func (e *outputError) Error() string {
+	return fmt.Sprintf("%s command: %v", e.op, e.err)
+}
+
+// parseOutput decodes stdout according to format into a value for the parsed output.
+func parseOutput(format, stdout string) (*structpb.Value, error) {
+	var v interface{}
+	switch format {
+	case outputFormatText:
+		v = stdout
+	case outputFormatJSON:
+		if err := json.Unmarshal([]byte(stdout), &v); err != nil {
+			return nil, errors.Wrap(err, "failed to parse stdout as json")
+		}
+	case outputFormatYAML:
+		if err := yaml.Unmarshal([]byte(stdout), &v); err != nil {
+			return nil, errors.Wrap(err, "failed to parse stdout as yaml")
+		}
+	case outputFormatDotenv:
+		env, err := parseDotenv(stdout)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse stdout as dotenv")
+		}
+		v = env
+	case outputFormatLines:
+		lines := []interface{}{}
+		for _, line := range strings.Split(strings.TrimSuffix(stdout, "\n"), "\n") {
+			lines = append(lines, strings.TrimSuffix(line, "\r"))
+		}
+		if stdout == "" {
+			lines = lines[:0]
+		}
+		v = lines
+	default:
+		return nil, errors.Errorf("unknown output format %q", format)
+	}
+	return toValue(v)
+}
+
+// parseDotenv parses KEY=VALUE lines. Blank lines, comments and an "export " prefix are ignored,
+// and values may be wrapped in single or double quotes.
+func parseDotenv(s string) (map[string]interface{}, error) {
+	env := map[string]interface{}{}
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		i := strings.Index(line, "=")
+		if i <= 0 {
+			return nil, errors.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		key, value := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", n)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		}
+		env[key] = value
+	}
+	return env, scanner.Err()
+}
+
+// toValue converts a decoded JSON or YAML document to a protobuf value.
+func toValue(v interface{}) (*structpb.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return &structpb.Value{Kind: &structpb.Value_NullValue{}}, nil
+	case bool:
+		return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: v}}, nil
+	case int:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(v)}}, nil
+	case uint64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(v)}}, nil
+	case float64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: v}}, nil
+	case string:
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v}}, nil
+	case time.Time:
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v.Format(time.RFC3339Nano)}}, nil
+	case []interface{}:
+		values := make([]*structpb.Value, len(v))
+		for i, e := range v {
+			value, err := toValue(e)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: values}}}, nil
+	case map[string]interface{}:
+		fields := make(map[string]*structpb.Value, len(v))
+		for k, e := range v {
+			value, err := toValue(e)
+			if err != nil {
+				return nil, err
+			}
+			fields[k] = value
+		}
+		return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: fields}}}, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = e
+		}
+		return toValue(m)
+	default:
+		return nil, errors.Errorf("unsupported value of type %T", v)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+)
+
+func Test_parseOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		stdout  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Text",
+			format: outputFormatText,
+			stdout: "hello\n",
+			want:   `"hello\n"`,
+		},
+		{
+			name:   "JSON",
+			format: outputFormatJSON,
+			stdout: `{"a":[1,"two",null,true]}`,
+			want:   `{"a":[1,"two",null,true]}`,
+		},
+		{
+			name:    "Invalid JSON",
+			format:  outputFormatJSON,
+			stdout:  `{"a":`,
+			wantErr: true,
+		},
+		{
+			name:   "YAML",
+			format: outputFormatYAML,
+			stdout: "a:\n  - 1\n  - two\n3: x\n",
+			want:   `{"3":"x","a":[1,"two"]}`,
+		},
+		{
+			name:   "Dotenv",
+			format: outputFormatDotenv,
+			stdout: "# comment\nexport A=1\nB=\"two\\nlines\"\n\nC='x=y'\n",
+			want:   `{"A":"1","B":"two\nlines","C":"x=y"}`,
+		},
+		{
+			name:    "Invalid dotenv",
+			format:  outputFormatDotenv,
+			stdout:  "A\n",
+			wantErr: true,
+		},
+		{
+			name:   "Lines",
+			format: outputFormatLines,
+			stdout: "one\r\ntwo\n",
+			want:   `["one","two"]`,
+		},
+		{
+			name:   "No lines",
+			format: outputFormatLines,
+			stdout: "",
+			want:   `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOutput(tt.format, tt.stdout)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			s, err := (&jsonpb.Marshaler{}).MarshalToString(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.want {
+				t.Errorf("parseOutput() = %s, want %s", s, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultStopGracePeriod is how long a stopped command is given to exit before it is killed.
+const defaultStopGracePeriod = 10 * time.Second
+
+// stopSignals are the signals a command may be stopped with.
+var stopSignals = map[string]syscall.Signal{
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGINT":  syscall.SIGINT,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGKILL": syscall.SIGKILL,
+	"SIGTERM": syscall.SIGTERM,
+}
+
+// parseStopSignal parses a signal name such as "SIGINT" or "INT".
+func parseStopSignal(name string) (syscall.Signal, error) {
+	name = strings.ToUpper(name)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	if sig, ok := stopSignals[name]; ok {
+		return sig, nil
+	}
+	names := make([]string, 0, len(stopSignals))
+	for n := range stopSignals {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return 0, errors.Errorf("unsupported signal %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// stopPolicy describes how a running command is stopped.
+type stopPolicy struct {
+	signal syscall.Signal
+	grace  time.Duration
+}
+
+// stopPolicy returns how the command is stopped on timeout or cancellation.
+func (c cmd) stopPolicy() (stopPolicy, error) {
+	policy := stopPolicy{signal: syscall.SIGTERM, grace: defaultStopGracePeriod}
+	if c.StopSignal != "" {
+		sig, err := parseStopSignal(c.StopSignal)
+		if err != nil {
+			return policy, err
+		}
+		policy.signal = sig
+	}
+	if c.StopGracePeriod != "" {
+		d, err := time.ParseDuration(c.StopGracePeriod)
+		if err != nil {
+			return policy, errors.Wrap(err, "invalid stopGracePeriod")
+		}
+		policy.grace = d
+	}
+	return policy, nil
+}
+
+// timeoutError reports that a command was terminated because it ran longer than its timeout.
+type timeoutError struct {
+	op      string
+	timeout time.Duration
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("%s command timed out after %v", e.op, e.timeout)
+}
+
+// runProcess starts cmd in a new process group and waits for it to exit. If ctx is done first, the
+// whole process group is sent the stop signal and, if it is still running after the grace period, killed.
+func runProcess(ctx context.Context, cmd *exec.Cmd, stop stopPolicy) error {
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+	_ = signalProcessGroup(cmd.Process, stop.signal)
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(stop.grace):
+		_ = signalProcessGroup(cmd.Process, syscall.SIGKILL)
+		return <-done
+	}
+}
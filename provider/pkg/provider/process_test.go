@@ -0,0 +1,116 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// processExited reports whether pid has exited. Zombies count as exited as they are only awaiting their reaper.
+func processExited(pid int) bool {
+	if err := syscall.Kill(pid, 0); err == syscall.ESRCH {
+		return true
+	}
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return true
+	}
+	fields := strings.Fields(string(stat[strings.LastIndex(string(stat), ")")+1:]))
+	return len(fields) > 0 && fields[0] == "Z"
+}
+
+func waitForPid(t *testing.T, path string) int {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if b, err := ioutil.ReadFile(path); err == nil && strings.HasSuffix(string(b), "\n") {
+			pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return pid
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", path)
+	return 0
+}
+
+func Test_commandProvider_CancelReapsGrandchildren(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{
+			name:   "Grandchild",
+			script: `sleep 60 & echo $! > %[1]s; wait`,
+		},
+		{
+			name:   "Grandchild ignoring stop signal",
+			script: `(trap "" TERM; exec sleep 60) & echo $! > %[1]s; wait`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pidFile := filepath.Join(t.TempDir(), "pid")
+			script := fmt.Sprintf(tt.script, pidFile)
+			req := &pulumirpc.CreateRequest{}
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":"urn:pulumi:command-test::command-test::command:v1:Command::demo","properties":{"create":{"command":["sh","-c",%q],"stopGracePeriod":"200ms"}}}`, script), req)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			done := make(chan error, 1)
+			go func() {
+				_, err, _ := p.execCommand(context.Background(), req, "create", req.GetProperties(), "properties")
+				done <- err
+			}()
+
+			pid := waitForPid(t, pidFile)
+			if _, err := p.Cancel(context.Background(), nil); err != nil {
+				t.Fatalf("commandProvider.Cancel() error = %v", err)
+			}
+			select {
+			case err := <-done:
+				if err == nil {
+					t.Errorf("execCommand() succeeded after Cancel")
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("execCommand() did not return after Cancel")
+			}
+			deadline := time.Now().Add(5 * time.Second)
+			for !processExited(pid) {
+				if time.Now().After(deadline) {
+					_ = syscall.Kill(pid, syscall.SIGKILL)
+					t.Fatalf("grandchild %d is still running", pid)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
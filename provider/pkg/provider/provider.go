@@ -18,14 +18,22 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/brandonkal/pulumi-command/provider/pkg/structpbconv"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/v3/resource/provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
@@ -35,9 +43,45 @@ import (
 )
 
 type cmd struct {
-	Command     []string          `pulumi:"command"`
+	Command     []string          `pulumi:"command,optional"`
 	Stdin       string            `pulumi:"stdin,optional"`
 	Environment map[string]string `pulumi:"environment,optional"`
+	Dir         string            `pulumi:"dir,optional"`
+	// InheritEnv controls which variables of the provider's environment are passed to the command.
+	InheritEnv   string   `pulumi:"inheritEnv,optional" structpb:"inheritEnv"`
+	EnvAllowlist []string `pulumi:"envAllowlist,optional" structpb:"envAllowlist"`
+	UnsetEnv     []string `pulumi:"unsetEnv,optional" structpb:"unsetEnv"`
+	Timeout      string   `pulumi:"timeout,optional"`
+	// StopSignal and StopGracePeriod control how the command is stopped on timeout or cancellation.
+	StopSignal      string `pulumi:"stopSignal,optional" structpb:"stopSignal"`
+	StopGracePeriod string `pulumi:"stopGracePeriod,optional" structpb:"stopGracePeriod"`
+	// AllowedExitCodes lists non-zero exit codes that are treated as success.
+	AllowedExitCodes []int `pulumi:"allowedExitCodes,optional" structpb:"allowedExitCodes"`
+	// OutputFormat decodes stdout into the parsed output.
+	OutputFormat string `pulumi:"outputFormat,optional" structpb:"outputFormat"`
+	// StdinFrom feeds the previous state of the resource to stdin instead of Stdin.
+	StdinFrom string `pulumi:"stdinFrom,optional" structpb:"stdinFrom"`
+	// Script is run by Interpreter instead of Command.
+	Script      string `pulumi:"script,optional"`
+	Interpreter string `pulumi:"interpreter,optional"`
+	// ScriptFile runs Script from a temporary file rather than passing it as an argument.
+	ScriptFile bool `pulumi:"scriptFile,optional" structpb:"scriptFile"`
+	// Retry runs the command again when it fails.
+	Retry *retry `pulumi:"retry,optional"`
+	// Image runs the command in a container instead of on the machine running the provider.
+	Image *image `pulumi:"image,optional"`
+	// Sandbox restricts what a command that runs on the machine running the provider may do.
+	Sandbox *sandbox `pulumi:"sandbox,optional"`
+}
+
+// allowsExitCode reports whether the command succeeded when it exits with code.
+func (c cmd) allowsExitCode(code int) bool {
+	for _, allowed := range c.AllowedExitCodes {
+		if code == allowed {
+			return true
+		}
+	}
+	return code == 0
 }
 
 const (
@@ -59,13 +103,20 @@ func makeCancellationContext() *cancellationContext {
 }
 
 type commandProvider struct {
+	host     *provider.HostClient
 	canceler *cancellationContext
 	name     string
 	version  string
+	// mu guards cancelled and additions to running, so that no command starts once Cancel waits.
+	mu        sync.Mutex
+	cancelled bool
+	// running tracks the commands in flight so that Cancel can wait for them to be stopped.
+	running sync.WaitGroup
 }
 
-func makeCommandProvider(name, version string) (pulumirpc.ResourceProviderServer, error) {
+func makeCommandProvider(host *provider.HostClient, name, version string) (pulumirpc.ResourceProviderServer, error) {
 	return &commandProvider{
+		host:     host,
 		canceler: makeCancellationContext(),
 		name:     name,
 		version:  version,
@@ -76,15 +127,29 @@ func (p *commandProvider) prepare(req hasUrn, op string, props *structpb.Struct,
 	urn := resource.URN(req.GetUrn())
 	label := fmt.Sprintf("%s.%s(%s)", p.label(), op, urn)
 	logging.V(9).Infof("%s executing", label)
-	if urn.Type() != commandType && urn.Type() != backwardCompatCommandType {
+	if urn.Type() != commandType && urn.Type() != backwardCompatCommandType && urn.Type() != remoteCommandType {
 		return nil, errors.Errorf("unknown resource type %v", urn.Type())
 	}
 	input, err := plugin.UnmarshalProperties(props, plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.%s", label, path), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.%s", label, path), KeepUnknowns: true, KeepSecrets: true, SkipNulls: true,
 	})
 	return input, err
 }
 
+// plainProperties unmarshals props, replacing secrets with their plain values.
+func plainProperties(props *structpb.Struct) (resource.PropertyMap, error) {
+	return plugin.UnmarshalProperties(props, plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true})
+}
+
+// plainStruct strips the secret markers from props so that they can be converted with structpbconv.
+func plainStruct(props *structpb.Struct) (*structpb.Struct, error) {
+	m, err := plainProperties(props)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.MarshalProperties(m, plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true})
+}
+
 // execCommand runs the specified command and returns a proto structure containing stderr and stdout
 // if exitCode is zero and an error is returned, it is an internal error
 func (p *commandProvider) execCommand(ctx context.Context, req hasUrn, op string, props *structpb.Struct, path string) (out *structpb.Struct, err error, code int) {
@@ -105,37 +170,203 @@ func (p *commandProvider) execCommand(ctx context.Context, req hasUrn, op string
 	if what.V == nil {
 		return nil, errors.Errorf("%s command unspecified", op), code
 	}
+	if what.ContainsUnknowns() {
+		return nil, errors.Errorf("%s command depends on values that are not known yet", op), code
+	}
 	err = decodeProperty("", what, reflect.ValueOf(&this))
 	if err != nil {
 		return nil, err, code
 	}
+	return p.run(ctx, req, op, this, input)
+}
 
-	envs := this.Environment
-	var environment = []string{}
-	for k, v := range envs {
-		environment = append(environment, fmt.Sprintf("%s=%s", k, v))
+// run executes this for the op and returns a proto structure containing its outputs. input holds the
+// properties the command was decoded from, which determine the secrets to redact and the outputs to mark secret.
+// A failing command is retried according to its retry policy, within the timeout of the command.
+// if exitCode is zero and an error is returned, it is an internal error
+func (p *commandProvider) run(ctx context.Context, req hasUrn, op string, this cmd, input resource.PropertyMap) (out *structpb.Struct, err error, code int) {
+	policy, err := this.retryPolicy()
+	if err != nil {
+		return nil, err, code
 	}
+	if op == "diff" {
+		// A diff command fails to report that nothing changed, which another attempt would only repeat.
+		policy.attempts = 1
+	}
+	timeout, err := commandTimeout(req, this)
+	if err != nil {
+		return nil, err, code
+	}
+	ctx, cancel := p.withCancellation(ctx)
+	defer cancel()
+	started := time.Now()
+	attempt := 1
+	for {
+		out, err, code = p.runOnce(ctx, req, op, this, input, started)
+		if err == nil || out == nil || attempt >= policy.attempts || !policy.retries(code, stderrOf(out)) {
+			break
+		}
+		delay := policy.delay(attempt)
+		if timeout > 0 && time.Since(started)+delay >= timeout {
+			logging.V(1).Infof("%s command attempt %d of %d failed, not retrying as the timeout of %v would pass", op, attempt, policy.attempts, timeout)
+			break
+		}
+		p.warner(ctx, req)(fmt.Sprintf("%s command attempt %d of %d failed, retrying in %v: %v",
+			op, attempt, policy.attempts, delay, err))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, errors.Errorf("%s command was cancelled", op), 0
+		}
+		attempt++
+	}
+	if out != nil {
+		out.Fields["attempts"] = &structpb.Value{
+			Kind: &structpb.Value_NumberValue{NumberValue: float64(attempt)},
+		}
+	}
+	return out, err, code
+}
 
-	args := this.Command
+// stderrOf returns the stderr output of a command, which may be marked secret.
+func stderrOf(out *structpb.Struct) string {
+	return stringOutput(out, "stderr")
+}
+
+// stringOutput returns the named string output of a command, which may be marked secret.
+func stringOutput(out *structpb.Struct, name string) string {
+	v, err := plugin.UnmarshalPropertyValue(out.Fields[name], plugin.MarshalOptions{})
+	if err != nil || v == nil || !v.IsString() {
+		return ""
+	}
+	return v.StringValue()
+}
 
-	// Prepare the Command
-	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
-	if len(environment) > 0 {
-		cmd.Env = environment
+// runOnce executes a single attempt of the op command, which times out when the timeout of the command has passed
+// since started.
+// if exitCode is zero and an error is returned, it is an internal error
+func (p *commandProvider) runOnce(ctx context.Context, req hasUrn, op string, this cmd, input resource.PropertyMap, started time.Time) (out *structpb.Struct, err error, code int) {
+	timeout, err := commandTimeout(req, this)
+	if err != nil {
+		return nil, err, code
+	}
+	stop, err := this.stopPolicy()
+	if err != nil {
+		return nil, err, code
+	}
+	ctx, cancel := p.withCancellation(ctx)
+	defer cancel()
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithDeadline(ctx, started.Add(timeout))
+		defer cancel()
 	}
+
+	args, err := this.args()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s command", op), code
+	}
+	if this.ScriptFile && isRemoteCommand(req) {
+		return nil, errors.Errorf("%s command: scriptFile is not supported by RemoteCommand", op), code
+	}
+	if this.ScriptFile && this.Image != nil {
+		return nil, errors.Errorf("%s command: scriptFile is not supported with image", op), code
+	}
+	// rename replaces the path of a script file, which changes on every run, in the output of the command.
+	rename := func(s string) string { return s }
+	if this.ScriptFile {
+		path, err := this.writeScriptFile()
+		if err != nil {
+			return nil, err, code
+		}
+		defer os.Remove(path)
+		args = this.scriptFileArgs(path)
+		rename = strings.NewReplacer(path, fmt.Sprintf("%s script", op)).Replace
+	}
+
+	conn, err := decodeConnection(input)
+	if err != nil {
+		return nil, err, code
+	}
+	env, err := contextEnv(req, op)
+	if err != nil {
+		return nil, err, code
+	}
+	var stdin io.Reader
 	if len(this.Stdin) > 0 {
-		r := strings.NewReader(this.Stdin)
-		cmd.Stdin = r
+		stdin = strings.NewReader(this.Stdin)
+	}
+	if this.StdinFrom == stdinFromPreviousState {
+		doc, err := previousStateJSON(req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s command", op), code
+		}
+		stdin = bytes.NewReader(doc)
+	}
+	secrets := newRedactor(collectSecrets(input))
+	redact := func(s string) string { return secrets(rename(s)) }
+	streams := newOutputStreams(ctx, p.host, resource.URN(req.GetUrn()), redact)
+	if !p.startRun() {
+		return nil, errors.Errorf("%s command was cancelled", op), code
+	}
+	startedAt := time.Now()
+	var digest string
+	switch {
+	case this.Sandbox != nil && (conn != nil || this.Image != nil):
+		err = errors.Errorf("%s command: sandbox is only supported by commands that run on the machine running the provider", op)
+	case conn != nil && this.Image != nil:
+		err = errors.Errorf("%s command: image is not supported by RemoteCommand", op)
+	case conn != nil:
+		// A remote command does not inherit the environment of the provider.
+		line, vars := remoteCommandLine(args, this.Dir, append(this.environ(nil), env...))
+		remoteStdin := io.Reader(bytes.NewReader(vars))
+		if stdin != nil {
+			remoteStdin = io.MultiReader(remoteStdin, stdin)
+		}
+		err = runRemote(runCtx, *conn, p.warner(ctx, req), line, remoteStdin, streams.Stdout(), streams.Stderr(), stop)
+	case this.Image != nil:
+		// Neither does a command in a container.
+		digest, err = runContainer(runCtx, *this.Image, args, this.Dir, append(this.environ(nil), env...), stdin, streams.Stdout(), streams.Stderr(), stop)
+	default:
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = append(this.environ(os.Environ()), env...)
+		if this.Dir != "" {
+			cmd.Dir = this.Dir
+		}
+		if stdin != nil {
+			cmd.Stdin = stdin
+		}
+		cmd.Stdout = streams.Stdout()
+		cmd.Stderr = streams.Stderr()
+		cleanup := func() {}
+		if this.Sandbox != nil {
+			cleanup, err = this.Sandbox.wrap(cmd)
+		}
+		if err == nil {
+			err = runProcess(runCtx, cmd, stop)
+			cleanup()
+		}
+	}
+	finishedAt := time.Now()
+	p.running.Done()
+	streams.Flush()
+	if err != nil && timeout > 0 && runCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return nil, &timeoutError{op: op, timeout: timeout}, code
+	}
+	if err != nil && ctx.Err() != nil {
+		return nil, errors.Errorf("%s command was cancelled", op), code
 	}
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	err = cmd.Run()
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
+		if exitError, ok := err.(exitCoder); ok {
 			code = exitError.ExitCode()
-			err = errors.Wrap(err, stderr.String())
-			logging.V(1).Infof("Command exit with code: %v", code)
+			if this.allowsExitCode(code) {
+				err = nil
+				logging.V(1).Infof("Command exit with allowed code: %v", code)
+			} else {
+				err = errors.Wrap(err, redact(streams.stderr.String()))
+				logging.V(1).Infof("Command exit with code: %v", code)
+			}
 		} else {
 			return nil, err, code
 		}
@@ -143,10 +374,49 @@ func (p *commandProvider) execCommand(ctx context.Context, req hasUrn, op string
 
 	m := make(map[string]*structpb.Value)
 	m["stdout"] = &structpb.Value{
-		Kind: &structpb.Value_StringValue{StringValue: stdout.String()},
+		Kind: &structpb.Value_StringValue{StringValue: rename(streams.stdout.String())},
 	}
 	m["stderr"] = &structpb.Value{
-		Kind: &structpb.Value_StringValue{StringValue: stderr.String()},
+		Kind: &structpb.Value_StringValue{StringValue: rename(streams.stderr.String())},
+	}
+	m["exitCode"] = &structpb.Value{
+		Kind: &structpb.Value_NumberValue{NumberValue: float64(code)},
+	}
+	m["startedAt"] = &structpb.Value{
+		Kind: &structpb.Value_StringValue{StringValue: startedAt.UTC().Format(time.RFC3339Nano)},
+	}
+	m["finishedAt"] = &structpb.Value{
+		Kind: &structpb.Value_StringValue{StringValue: finishedAt.UTC().Format(time.RFC3339Nano)},
+	}
+	m["durationMs"] = &structpb.Value{
+		Kind: &structpb.Value_NumberValue{NumberValue: float64(finishedAt.Sub(startedAt).Milliseconds())},
+	}
+	if digest != "" {
+		m["imageDigest"] = &structpb.Value{
+			Kind: &structpb.Value_StringValue{StringValue: digest},
+		}
+		m[imageDigestsKey] = imageDigests(this.Image.Name, digest)
+	}
+	if this.Script != "" {
+		m["scriptHash"] = &structpb.Value{
+			Kind: &structpb.Value_StringValue{StringValue: scriptHash(this.Script)},
+		}
+	}
+	if this.OutputFormat != "" && err == nil {
+		parsed, parseErr := parseOutput(this.OutputFormat, streams.stdout.String())
+		if parseErr != nil {
+			// The command has run, so its outputs are returned with the error.
+			err = &outputError{op: op, err: parseErr}
+		} else {
+			m["parsed"] = parsed
+		}
+	}
+	if secretOutputs, ok := input["secretOutputs"]; ok && secretOutputs.IsArray() {
+		for _, name := range secretOutputs.ArrayValue() {
+			if name.IsString() && m[name.StringValue()] != nil {
+				m[name.StringValue()] = makeSecret(m[name.StringValue()])
+			}
+		}
 	}
 	out = &structpb.Struct{
 		Fields: m,
@@ -155,6 +425,117 @@ func (p *commandProvider) execCommand(ctx context.Context, req hasUrn, op string
 	return out, err, code
 }
 
+// commandOutputNames are the outputs of a command that are unknown until it has run.
+var commandOutputNames = []string{"stdout", "stderr", "exitCode", "startedAt", "finishedAt", "durationMs", "attempts"}
+
+// previewCommand returns the outputs of the op command during a preview, where the command is not run and
+// its outputs are unknown.
+func (p *commandProvider) previewCommand(req hasUrn, op string, props *structpb.Struct) (*structpb.Struct, error) {
+	input, err := p.prepare(req, op, props, "properties")
+	if err != nil {
+		return nil, err
+	}
+	names := commandOutputNames
+	if what := input[resource.PropertyKey(op)]; what.IsObject() {
+		if format, ok := what.ObjectValue()["outputFormat"]; ok && !format.IsNull() {
+			names = append(names[:len(names):len(names)], "parsed")
+		}
+		if img, ok := what.ObjectValue()["image"]; ok && !img.IsNull() {
+			names = append(names[:len(names):len(names)], "imageDigest")
+		}
+		if script, ok := what.ObjectValue()["script"]; ok && !script.IsNull() {
+			names = append(names[:len(names):len(names)], "scriptHash")
+		}
+	}
+	secret := secretOutputSet(input)
+	outputs := resource.PropertyMap{}
+	for _, name := range names {
+		v := resource.MakeComputed(resource.NewStringProperty(""))
+		if secret[name] {
+			v = resource.MakeSecret(v)
+		}
+		outputs[resource.PropertyKey(name)] = v
+	}
+	return plugin.MarshalProperties(outputs, plugin.MarshalOptions{
+		Label:        fmt.Sprintf("%s.%s(%s).preview", p.label(), op, req.GetUrn()),
+		KeepUnknowns: true, KeepSecrets: true,
+	})
+}
+
+// warner returns a function that shows a message to the user as a warning on the resource of req.
+func (p *commandProvider) warner(ctx context.Context, req hasUrn) func(string) {
+	return func(msg string) {
+		logging.V(1).Infof("%s", msg)
+		if p.host != nil && req.GetUrn() != "" {
+			if err := p.host.Log(ctx, diag.Warning, resource.URN(req.GetUrn()), msg); err != nil {
+				logging.V(5).Infof("failed to log warning: %v", err)
+			}
+		}
+	}
+}
+
+// startRun adds a command to running, unless the provider has been cancelled.
+func (p *commandProvider) startRun() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancelled {
+		return false
+	}
+	p.running.Add(1)
+	return true
+}
+
+// withCancellation returns a context that is also done when the provider is cancelled.
+func (p *commandProvider) withCancellation(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-p.canceler.context.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// exitCoder is an error reporting the exit code of a command that ran.
+type exitCoder interface {
+	ExitCode() int
+}
+
+type hasTimeout interface {
+	GetTimeout() float64
+}
+
+// commandTimeout returns how long a command may run. It is the shorter of the command's own timeout
+// and the custom timeout of the operation, or zero if neither is set.
+func commandTimeout(req hasUrn, this cmd) (time.Duration, error) {
+	var timeout time.Duration
+	if this.Timeout != "" {
+		d, err := time.ParseDuration(this.Timeout)
+		if err != nil {
+			return 0, errors.Wrap(err, "invalid timeout")
+		}
+		timeout = d
+	}
+	if r, ok := req.(hasTimeout); ok && r.GetTimeout() > 0 {
+		d := time.Duration(r.GetTimeout() * float64(time.Second))
+		if timeout == 0 || d < timeout {
+			timeout = d
+		}
+	}
+	return timeout, nil
+}
+
+// requestDeadline returns when an operation that started at started times out according to the timeout of its
+// request, which is zero when the request has none.
+func requestDeadline(req hasUrn, started time.Time) time.Time {
+	if r, ok := req.(hasTimeout); ok && r.GetTimeout() > 0 {
+		return started.Add(time.Duration(r.GetTimeout() * float64(time.Second)))
+	}
+	return time.Time{}
+}
+
 // Call dynamically executes a method in the provider associated with a component resource.
 func (k *commandProvider) Call(ctx context.Context, req *pulumirpc.CallRequest) (*pulumirpc.CallResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "Call is not yet implemented")
@@ -180,7 +561,8 @@ func (p *commandProvider) DiffConfig(ctx context.Context, req *pulumirpc.DiffReq
 // Configure configures the resource provider with "globals" that control its behavior.
 func (p *commandProvider) Configure(ctx context.Context, req *pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
 	return &pulumirpc.ConfigureResponse{
-		AcceptSecrets: false,
+		AcceptSecrets:   true,
+		SupportsPreview: true,
 	}, nil
 }
 
@@ -190,7 +572,58 @@ func (p *commandProvider) label() string {
 
 // Invoke dynamically executes a built-in command in the provider.
 func (p *commandProvider) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Invoke is not yet implemented")
+	label := fmt.Sprintf("%s.Invoke(%s)", p.label(), req.GetTok())
+	logging.V(9).Infof("%s executing", label)
+	if req.GetTok() != runFunction {
+		return nil, errors.Errorf("unknown function %v", req.GetTok())
+	}
+	args, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{
+		Label: fmt.Sprintf("%s.args", label), KeepSecrets: true, SkipNulls: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var c checker
+	if err := c.checkProperty("", resource.NewObjectProperty(args), reflect.TypeOf(cmd{})); err != nil {
+		return nil, err
+	}
+	plain, err := plainProperties(req.GetArgs())
+	if err != nil {
+		return nil, err
+	}
+	c.checkCmd("", plain)
+	if len(c.failures) > 0 {
+		return &pulumirpc.InvokeResponse{Failures: c.failures}, nil
+	}
+	var this cmd
+	if err := decodeProperty("", resource.NewObjectProperty(args), reflect.ValueOf(&this)); err != nil {
+		return nil, err
+	}
+	out, err, _ := p.run(ctx, invokeRequest{req}, "run", this, args)
+	if err != nil {
+		return nil, err
+	}
+	for k := range out.Fields {
+		if !runResultFields[k] {
+			delete(out.Fields, k)
+		}
+	}
+	return &pulumirpc.InvokeResponse{Return: out}, nil
+}
+
+// runResultFields are the outputs of a command returned by the run function.
+var runResultFields = map[string]bool{"stdout": true, "stderr": true, "exitCode": true, "parsed": true, "attempts": true, "imageDigest": true}
+
+// runFunction runs a command without creating a resource.
+const runFunction = "command:v1:run"
+
+// invokeRequest adapts an InvokeRequest to run a command that does not belong to a resource.
+type invokeRequest struct {
+	*pulumirpc.InvokeRequest
+}
+
+func (invokeRequest) GetUrn() string {
+	return ""
 }
 
 // StreamInvoke dynamically executes a built-in function in the provider, which returns a stream
@@ -206,20 +639,298 @@ func (p *commandProvider) StreamInvoke(*pulumirpc.InvokeRequest, pulumirpc.Resou
 // required for correctness, violations thereof can negatively impact the end-user experience, as
 // the provider inputs are using for detecting and rendering diffs.
 func (p *commandProvider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
-	// We currently don't change the inputs during check.
-	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
+	if isLocalFile(req) {
+		return p.checkLocalFile(req)
+	}
+	if isCopy(req) {
+		return p.checkCopy(req)
+	}
+	news, err := p.prepare(req, "Check", req.GetNews(), "news")
+	if err != nil {
+		return nil, err
+	}
+	// Validate the plain values; the inputs returned keep their secrets.
+	plain, err := plainProperties(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+	c := checker{remote: isRemoteCommand(req)}
+	if err := c.checkProperty("", resource.NewObjectProperty(plain), reflect.TypeOf(Input{})); err != nil {
+		return nil, err
+	}
+	c.checkConnection("connection", plain, c.remote)
+	for _, op := range commandOps {
+		if what := plain[resource.PropertyKey(op)]; what.IsObject() {
+			c.checkCmd(op, what.ObjectValue())
+		}
+	}
+	// Record the inheritance mode so that state does not depend on the provider's defaults.
+	news = withDefaultInputs(news)
+	if secretOutputs := plain["secretOutputs"]; secretOutputs.IsArray() {
+		for i, name := range secretOutputs.ArrayValue() {
+			c.checkOneOf(fmt.Sprintf("secretOutputs[%d]", i), name, secretOutputNames)
+		}
+	}
+	c.checkIDFrom("idFrom", plain)
+	c.checkWaitFor("waitFor", plain)
+	if replaceOn := plain["replaceOn"]; replaceOn.IsArray() {
+		for i, name := range replaceOn.ArrayValue() {
+			c.checkOneOf(fmt.Sprintf("replaceOn[%d]", i), name, replaceOnNames)
+		}
+	}
+	inputs, err := plugin.MarshalProperties(news, plugin.MarshalOptions{
+		Label:        fmt.Sprintf("%s.Check(%s).inputs", p.label(), req.GetUrn()),
+		KeepUnknowns: true, KeepSecrets: true, SkipNulls: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.CheckResponse{Inputs: inputs, Failures: c.failures}, nil
+}
+
+// checkOneOf validates that a string property is one of options. It returns false if the property is
+// a string with another value.
+func (c *checker) checkOneOf(path string, v resource.PropertyValue, options []string) bool {
+	if !v.IsString() {
+		return true
+	}
+	for _, o := range options {
+		if v.StringValue() == o {
+			return true
+		}
+	}
+	c.failures = append(c.failures, &pulumirpc.CheckFailure{
+		Property: path,
+		Reason:   fmt.Sprintf("expected one of %s, received %q", strings.Join(options, ", "), v.StringValue()),
+	})
+	return false
+}
+
+// checkTimeout validates that a command timeout is a positive duration.
+func (c *checker) checkTimeout(path string, v resource.PropertyValue) {
+	if !v.IsString() {
+		return
+	}
+	if d, err := time.ParseDuration(v.StringValue()); err != nil || d <= 0 {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: path,
+			Reason:   fmt.Sprintf("expected a positive duration such as \"30s\" or \"5m\", received %q", v.StringValue()),
+		})
+	}
+}
+
+// checkStopPolicy validates the stop signal and grace period of a command.
+func (c *checker) checkStopPolicy(path string, spec resource.PropertyMap) {
+	if sig := spec["stopSignal"]; sig.IsString() {
+		if _, err := parseStopSignal(sig.StringValue()); err != nil {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: propertyPath(path, "stopSignal"), Reason: err.Error()})
+		}
+	}
+	if grace := spec["stopGracePeriod"]; grace.IsString() {
+		if d, err := time.ParseDuration(grace.StringValue()); err != nil || d < 0 {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{
+				Property: propertyPath(path, "stopGracePeriod"),
+				Reason:   fmt.Sprintf("expected a duration such as \"10s\", received %q", grace.StringValue()),
+			})
+		}
+	}
+}
+
+// checkInheritEnv validates the environment inheritance settings of a command.
+func (c *checker) checkInheritEnv(path string, spec resource.PropertyMap) {
+	mode := spec["inheritEnv"]
+	if !mode.IsString() || !c.checkOneOf(propertyPath(path, "inheritEnv"), mode, inheritEnvModes) {
+		return
+	}
+	if allowlist := spec["envAllowlist"]; mode.StringValue() == inheritEnvAllowlist && allowlist.IsNull() {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "envAllowlist"),
+			Reason:   "envAllowlist is required when inheritEnv is \"allowlist\"",
+		})
+	}
+}
+
+// checkCmd validates the values of a command specification.
+func (c *checker) checkCmd(path string, spec resource.PropertyMap) {
+	c.checkScript(path, spec)
+	if c.remote {
+		// The working directory of a remote command is on the remote host.
+		if scriptFile := spec["scriptFile"]; scriptFile.IsBool() && scriptFile.BoolValue() {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{
+				Property: propertyPath(path, "scriptFile"),
+				Reason:   "scriptFile is not supported by RemoteCommand",
+			})
+		}
+	} else if img := spec["image"]; !img.IsObject() && !img.IsComputed() {
+		// The working directory of a command in a container is in the container.
+		c.checkDir(propertyPath(path, "dir"), spec["dir"])
+	}
+	c.checkImage(path, spec)
+	c.checkSandbox(path, spec)
+	c.checkInheritEnv(path, spec)
+	c.checkTimeout(propertyPath(path, "timeout"), spec["timeout"])
+	c.checkStopPolicy(path, spec)
+	c.checkOneOf(propertyPath(path, "outputFormat"), spec["outputFormat"], outputFormats)
+	c.checkStdinFrom(path, spec)
+	c.checkRetry(path, spec)
+}
+
+// checkScript validates that a command specifies exactly one of command and script.
+func (c *checker) checkScript(path string, spec resource.PropertyMap) {
+	command, script := spec["command"], spec["script"]
+	hasCommand := command.IsComputed() || command.IsArray()
+	hasScript := script.IsComputed() || (script.IsString() && script.StringValue() != "")
+	switch {
+	case hasCommand && hasScript:
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "script"),
+			Reason:   "command and script cannot both be set",
+		})
+	case !hasCommand && !hasScript:
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: path,
+			Reason:   "one of command or script is required",
+		})
+	case command.IsArray() && len(command.ArrayValue()) == 0:
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "command"),
+			Reason:   "command must not be empty",
+		})
+	}
+	if scriptFile := spec["scriptFile"]; scriptFile.IsBool() && scriptFile.BoolValue() && !hasScript {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "scriptFile"),
+			Reason:   "scriptFile requires script",
+		})
+	}
+	interpreter := spec["interpreter"]
+	if !interpreter.IsString() || !c.checkOneOf(propertyPath(path, "interpreter"), interpreter, interpreters) {
+		return
+	}
+	if !hasScript {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "interpreter"),
+			Reason:   "interpreter requires script",
+		})
+	}
+}
+
+// checkStdinFrom validates the stdin source of a command. The create command has no previous state.
+func (c *checker) checkStdinFrom(path string, spec resource.PropertyMap) {
+	from := spec["stdinFrom"]
+	if !from.IsString() || !c.checkOneOf(propertyPath(path, "stdinFrom"), from, stdinFromSources) {
+		return
+	}
+	switch {
+	case spec["stdin"].IsString() && spec["stdin"].StringValue() != "":
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "stdinFrom"),
+			Reason:   "stdin and stdinFrom cannot both be set",
+		})
+	case path == "create":
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "stdinFrom"),
+			Reason:   "the create command has no previous state",
+		})
+	}
+}
+
+// commandOps lists the input properties that hold a command specification.
+var commandOps = []string{"create", "read", "update", "delete", "diff"}
+
+// checkDir validates a working directory. Relative paths are resolved against the Pulumi project root, which is
+// the working directory of the provider. A directory that does not exist yet is accepted as an earlier resource
+// may create it.
+func (c *checker) checkDir(path string, v resource.PropertyValue) {
+	if !v.IsString() || v.StringValue() == "" {
+		return
+	}
+	dir, err := filepath.Abs(v.StringValue())
+	if err != nil {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: path,
+			Reason:   fmt.Sprintf("could not resolve %q relative to the project root: %v", v.StringValue(), err),
+		})
+		return
+	}
+	info, err := os.Stat(dir)
+	switch {
+	case os.IsNotExist(err):
+		logging.V(5).Infof("%s: directory %q does not exist yet", path, dir)
+	case err != nil:
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: path, Reason: err.Error()})
+	case !info.IsDir():
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: path,
+			Reason:   fmt.Sprintf("%q is not a directory", dir),
+		})
+	}
 }
 
 type Input struct {
-	Compare string
-	Create  cmd
-	Read    cmd
-	Update  cmd
-	Delete  cmd
+	Compare string `pulumi:"compare,optional"`
+	Create  cmd    `pulumi:"create"`
+	Read    cmd    `pulumi:"read,optional"`
+	Update  cmd    `pulumi:"update,optional"`
+	Delete  cmd    `pulumi:"delete,optional"`
+	Diff    cmd    `pulumi:"diff,optional"`
+	// Expected is the output the read command prints when the resource has not drifted.
+	Expected string `pulumi:"expected,optional"`
+	// IdFrom selects how the ID of the resource is derived. Changing it replaces the resource.
+	IdFrom *idFrom `pulumi:"idFrom,optional,forceNew" structpb:"idFrom"`
+	// SecretOutputs lists the outputs to mark as secret.
+	SecretOutputs []string `pulumi:"secretOutputs,optional" structpb:"secretOutputs"`
+	// ReplaceOn lists the inputs whose changes replace the resource instead of updating it.
+	ReplaceOn []string `pulumi:"replaceOn,optional" structpb:"replaceOn"`
+	// DeleteBeforeReplace deletes the resource before its replacement is created. It defaults to true.
+	DeleteBeforeReplace *bool `pulumi:"deleteBeforeReplace,optional" structpb:"deleteBeforeReplace"`
+	// WaitFor runs a probe after create and update until the resource is ready.
+	WaitFor *waitFor `pulumi:"waitFor,optional" structpb:"waitFor"`
+	// Connection is the host the commands of a RemoteCommand run on.
+	Connection *connection `pulumi:"connection,optional"`
+}
+
+// replaceOnNames are the inputs that may be listed in replaceOn.
+var replaceOnNames = append([]string{"compare", "connection"}, commandOps...)
+
+// replaceKeys returns the inputs whose changes replace the resource: those listed in replaceOn and
+// the fields of Input tagged forceNew.
+func replaceKeys(props resource.PropertyMap) map[string]bool {
+	keys := map[string]bool{}
+	t := reflect.TypeOf(Input{})
+	for i := 0; i < t.NumField(); i++ {
+		if desc, err := getFieldDesc(t.Field(i)); err == nil && desc != nil && desc.forceNew {
+			keys[desc.name] = true
+		}
+	}
+	if replaceOn := props["replaceOn"]; replaceOn.IsArray() {
+		for _, name := range replaceOn.ArrayValue() {
+			if name.IsString() {
+				keys[name.StringValue()] = true
+			}
+		}
+	}
+	return keys
+}
+
+// deleteBeforeReplace reports whether the resource is deleted before its replacement is created.
+func deleteBeforeReplace(props resource.PropertyMap) bool {
+	if v := props["deleteBeforeReplace"]; v.IsBool() {
+		return v.BoolValue()
+	}
+	return true
+}
+
+// applyDefaults fills in defaulted command settings so that states written before a setting
+// existed compare equal to inputs that record the default.
+func (in *Input) applyDefaults() {
+	for _, c := range []*cmd{&in.Create, &in.Read, &in.Update, &in.Delete, &in.Diff} {
+		c.InheritEnv = c.inheritMode()
+	}
 }
 
 func isEmpty(item Input) bool {
-	if item.Compare == "" && len(item.Create.Command) == 0 && item.Read.Command == nil && item.Update.Command == nil && item.Delete.Command == nil {
+	if item.Compare == "" && len(item.Create.Command) == 0 && item.Create.Script == "" && item.Read.Command == nil && item.Update.Command == nil && item.Delete.Command == nil {
 		return true
 	}
 	return false
@@ -229,40 +940,182 @@ type OldDiff struct {
 	Inputs Input
 }
 
+// inputChanges lists how the inputs saved in a state differ from new inputs.
+type inputChanges struct {
+	// rerun are the changes for which the update command runs again.
+	rerun []string
+	// saved are the changes that an update only saves, keeping the outputs of the last command.
+	saved []string
+}
+
+// reruns reports whether the update command runs again for a change of name.
+func (c inputChanges) reruns(name string) bool {
+	for _, n := range c.rerun {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// changedInputs compares the inputs saved in the state olds with the new inputs news.
+func changedInputs(ctx context.Context, olds, news *structpb.Struct) (inputChanges, error) {
+	var changes inputChanges
+	oldProps, err := plainProperties(olds)
+	if err != nil {
+		return changes, err
+	}
+	newProps, err := plainProperties(news)
+	if err != nil {
+		return changes, err
+	}
+	oldInputs := resource.PropertyMap{}
+	if inputs := oldProps["inputs"]; inputs.IsObject() {
+		oldInputs = inputs.ObjectValue()
+	}
+	var oldDiff = OldDiff{}
+	plainOlds, err := plainStruct(olds)
+	if err != nil {
+		return changes, err
+	}
+	if err := structpbconv.Convert(plainOlds, &oldDiff); err != nil {
+		return changes, errors.Wrap(err, "Could not convert input")
+	}
+	var newInput = Input{}
+	plainNews, err := plainStruct(news)
+	if err != nil {
+		return changes, err
+	}
+	if err := structpbconv.Convert(plainNews, &newInput); err != nil {
+		return changes, errors.Wrap(err, "Could not convert input")
+	}
+	oldDiff.Inputs.applyDefaults()
+	newInput.applyDefaults()
+	if isEmpty(oldDiff.Inputs) {
+		// The resource was imported without its inputs, which the update saves so that the delete command runs.
+		changes.rerun = append(changes.rerun, "inputs")
+		return changes, nil
+	}
+	add := func(list *[]string, name string, changed bool) {
+		if changed {
+			*list = append(*list, name)
+		}
+	}
+	updateCmdChanged := !reflect.DeepEqual(oldDiff.Inputs.Update, newInput.Update)
+	add(&changes.rerun, "compare", oldDiff.Inputs.Compare != newInput.Compare)
+	add(&changes.rerun, "update", updateCmdChanged)
+	add(&changes.rerun, "create.dir", oldDiff.Inputs.Create.Dir != newInput.Create.Dir)
+	// The read, delete and diff commands run in their new directories once these are saved; the directory of
+	// the update command is compared with the rest of it.
+	add(&changes.saved, "read.dir", oldDiff.Inputs.Read.Dir != newInput.Read.Dir)
+	add(&changes.saved, "delete.dir", oldDiff.Inputs.Delete.Dir != newInput.Delete.Dir)
+	add(&changes.saved, "diff.dir", oldDiff.Inputs.Diff.Dir != newInput.Diff.Dir)
+	// A new target reruns the update command on the new host. Other changes of the connection, such as new
+	// credentials, are saved so that the state never keeps credentials that no longer work for the read
+	// and delete commands.
+	targetChanged := oldDiff.Inputs.Connection.target() != newInput.Connection.target()
+	add(&changes.rerun, "connection", targetChanged)
+	add(&changes.saved, "connection", !targetChanged && !reflect.DeepEqual(oldDiff.Inputs.Connection, newInput.Connection))
+	add(&changes.rerun, "imageDigest", !updateCmdChanged && imageChanged(ctx, newInput.Update, oldProps))
+	// The update runs the new probe, which the resource must pass.
+	add(&changes.rerun, "waitFor", !reflect.DeepEqual(oldDiff.Inputs.WaitFor, newInput.WaitFor))
+	// The output of the last read is compared with the output that the new inputs expect. Without an update
+	// command nothing can reconcile a drift, which is then only reported by drifted.
+	add(&changes.rerun, "readStdout", lastReadDrifted(oldProps, newProps) && !newProps["update"].IsNull())
+	// The kept outputs are marked secret again according to the new secretOutputs, and the output of the last
+	// read is compared with the new expected output.
+	add(&changes.saved, "expected", oldDiff.Inputs.Expected != newInput.Expected)
+	add(&changes.saved, "secretOutputs", !reflect.DeepEqual(secretOutputSet(oldInputs), secretOutputSet(newProps)))
+	return changes, nil
+}
+
 // Diff checks what impacts a hypothetical update will have on the resource's properties.
 // It first checks to see if inputs have changed. If they have not, it executes the diff command.
 func (p *commandProvider) Diff(ctx context.Context, req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	if isLocalFile(req) {
+		return p.diffLocalFile(req)
+	}
+	if isCopy(req) {
+		return p.diffCopy(req)
+	}
 	urn := resource.URN(req.GetUrn())
 	label := fmt.Sprintf("%s.Diff(%s)", p.label(), urn)
 	logging.V(9).Infof("%s executing", label)
 
-	var oldDiff = OldDiff{}
-	olds := req.GetOlds()
 	news := req.GetNews()
-	err := structpbconv.Convert(olds, &oldDiff)
+	newProps, err := plainProperties(news)
 	if err != nil {
-		return nil, errors.Wrap(err, "Could not convert input")
+		return nil, err
 	}
-	var newInput = Input{}
-	err = structpbconv.Convert(news, &newInput)
+	replace := replaceKeys(newProps)
+	dbr := deleteBeforeReplace(newProps)
+	// Values that are not known until other resources are created cannot be compared, nor passed to the
+	// diff command. Let the engine decide based on the inputs alone, unless they force a replacement.
+	if newProps.ContainsUnknowns() {
+		logging.V(1).Info("Diff check: inputs contain unknown values")
+		var replaces []string
+		for key := range replace {
+			if newProps[resource.PropertyKey(key)].ContainsUnknowns() {
+				replaces = append(replaces, key)
+			}
+		}
+		if len(replaces) > 0 {
+			sort.Strings(replaces)
+			return &pulumirpc.DiffResponse{
+				Replaces:            replaces,
+				Changes:             pulumirpc.DiffResponse_DIFF_SOME,
+				Stables:             []string{},
+				DeleteBeforeReplace: dbr,
+				Diffs:               replaces,
+			}, nil
+		}
+		return &pulumirpc.DiffResponse{
+			Replaces:            []string{},
+			Changes:             pulumirpc.DiffResponse_DIFF_UNKNOWN,
+			Stables:             []string{},
+			DeleteBeforeReplace: dbr,
+		}, nil
+	}
+
+	oldProps, err := plainProperties(req.GetOlds())
 	if err != nil {
-		return nil, errors.Wrap(err, "Could not convert input")
+		return nil, err
+	}
+	oldInputs := resource.PropertyMap{}
+	if inputs := oldProps["inputs"]; inputs.IsObject() {
+		oldInputs = inputs.ObjectValue()
+	}
+	detailed := detailedDiff(withDefaultInputs(oldInputs), withDefaultInputs(newProps))
+	if len(oldInputs) > 0 {
+		if replaces := markReplacements(detailed, replace); len(replaces) > 0 {
+			logging.V(1).Infof("Diff check: replacing on changes to %v", replaces)
+			return &pulumirpc.DiffResponse{
+				Replaces:            replaces,
+				Changes:             pulumirpc.DiffResponse_DIFF_SOME,
+				Stables:             []string{},
+				DeleteBeforeReplace: dbr,
+				Diffs:               changedKeys(detailed),
+				DetailedDiff:        detailed,
+				HasDetailedDiff:     true,
+			}, nil
+		}
 	}
-	logging.V(9).Info("===OLD-DIFF===")
-	logging.V(9).Info(oldDiff)
-	logging.V(9).Info("===newInput===")
-	logging.V(9).Info(newInput)
 
-	var needsUpdate = false
-	wasEmpty := isEmpty(oldDiff.Inputs)
-	if !wasEmpty {
-		depChanged := oldDiff.Inputs.Compare != newInput.Compare
-		updateCmdChanged := !reflect.DeepEqual(oldDiff.Inputs.Update, newInput.Update)
-		logging.V(1).Infof("Diff check: depChanged: %v. updateCmdChanged: %v", depChanged, updateCmdChanged)
-		needsUpdate = depChanged || updateCmdChanged
-	} else {
-		logging.V(1).Info("oldDiff empty")
+	changes, err := changedInputs(ctx, req.GetOlds(), news)
+	if err != nil {
+		return nil, err
 	}
+	logging.V(1).Infof("Diff check: rerun on changes to %v. saved changes to %v", changes.rerun, changes.saved)
+	needsUpdate := len(changes.rerun) > 0 || len(changes.saved) > 0
+	if changes.reruns("imageDigest") {
+		// The image of the update command now resolves to another image than the one the last command ran in.
+		detailed["imageDigest"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE}
+	}
+	if changes.reruns("readStdout") {
+		// The read command reported a different output during the last refresh.
+		detailed["readStdout"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE}
+	}
+	probed := false
 	if !needsUpdate {
 		_, err, code := p.execCommand(ctx, req, "diff", news, "news")
 		// If the user doesn't provide a diff command, we never run update
@@ -270,36 +1123,92 @@ func (p *commandProvider) Diff(ctx context.Context, req *pulumirpc.DiffRequest)
 			return nil, err
 		}
 		unspecified := (err != nil && err.Error() == "diff command unspecified")
-		if code == 0 && !unspecified {
-			// code is zero if no command specified or process returned success (update)
+		if err == nil {
+			// err is nil if the process returned success or an allowed exit code (update)
 			needsUpdate = true
+			probed = true
 			logging.V(1).Infof("Diff check update required: return code: %v. unspecified? %v", code, unspecified)
 		}
 	}
-	diff := pulumirpc.DiffResponse_DIFF_SOME
+	logging.V(1).Infof("Diff check needs update: %v", needsUpdate)
 	if !needsUpdate {
-		diff = pulumirpc.DiffResponse_DIFF_NONE
+		return &pulumirpc.DiffResponse{
+			Replaces:            []string{},
+			Changes:             pulumirpc.DiffResponse_DIFF_NONE,
+			Stables:             []string{},
+			DeleteBeforeReplace: dbr,
+		}, nil
+	}
+	if probed {
+		// The inputs may be unchanged; the diff command reported that the resource needs an update.
+		detailed["diff"] = &pulumirpc.PropertyDiff{Kind: pulumirpc.PropertyDiff_UPDATE, InputDiff: true}
 	}
-	logging.V(1).Infof("Diff check needs update: %v", needsUpdate)
 
 	return &pulumirpc.DiffResponse{
 		Replaces:            []string{},
-		Changes:             diff,
+		Changes:             pulumirpc.DiffResponse_DIFF_SOME,
 		Stables:             []string{},
-		DeleteBeforeReplace: true,
+		DeleteBeforeReplace: dbr,
+		Diffs:               changedKeys(detailed),
+		DetailedDiff:        detailed,
+		HasDetailedDiff:     true,
 	}, nil
 }
 
 func (p *commandProvider) Create(ctx context.Context, req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
-	out, err, _ := p.execCommand(ctx, req, "create", req.GetProperties(), "properties")
+	if isLocalFile(req) {
+		return p.createLocalFile(req)
+	}
+	if isCopy(req) {
+		return p.createCopy(ctx, req)
+	}
+	started := time.Now()
+	props, err := plainProperties(req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+	from, err := decodeIDFrom(props)
 	if err != nil {
 		return nil, err
 	}
+	var out *structpb.Struct
+	if req.GetPreview() {
+		// Commands are never run during a preview.
+		out, err = p.previewCommand(req, "create", req.GetProperties())
+		if err != nil {
+			return nil, err
+		}
+		id := ""
+		if from == nil || from.Value != "" {
+			id, _ = resourceID(from, out)
+		}
+		out.Fields["inputs"] = &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: req.Properties}}
+		return &pulumirpc.CreateResponse{Id: id, Properties: out}, nil
+	}
+	out, err, _ = p.execCommand(ctx, req, "create", req.GetProperties(), "properties")
+	if _, ok := err.(*outputError); err != nil && !ok {
+		return nil, err
+	}
 	// Save inputs to state
 	out.Fields["inputs"] = &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: req.Properties}}
+	// The create command has succeeded, so the resource exists even if its output is not what was expected. It
+	// is recorded, with its default ID if no other can be derived, so that it can be updated or deleted.
+	id, idErr := resourceID(from, out)
+	if idErr != nil {
+		id = defaultID
+	}
+	if err != nil {
+		return nil, initFailed(id, out, req.GetProperties(), err)
+	}
+	if idErr != nil {
+		return nil, initFailed(id, out, req.GetProperties(), errors.Wrap(idErr, "create command"))
+	}
+	if err := p.waitUntilReady(ctx, req, req.GetProperties(), started); err != nil {
+		return nil, initFailed(id, out, req.GetProperties(), err)
+	}
 
 	return &pulumirpc.CreateResponse{
-		Id: "id", Properties: out,
+		Id: id, Properties: out,
 	}, nil
 }
 
@@ -307,34 +1216,151 @@ type hasUrn interface {
 	GetUrn() string
 }
 
+type hasID interface {
+	GetId() string
+}
+
 // Read the current live state associated with a resource.  Enough state must be include in the inputs to uniquely
 // identify the resource; this is typically just the resource ID, but may also include some properties.
+// The output of the read command is saved as readStdout and compared to the expected output to detect drift.
 func (p *commandProvider) Read(ctx context.Context, req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
-	out, err, _ := p.execCommand(ctx, req, "read", req.GetInputs(), "olds")
-	if err != nil && err.Error() != "read command unspecified" {
+	if isLocalFile(req) {
+		return p.readLocalFile(req)
+	}
+	if isCopy(req) {
+		return p.readCopy(ctx, req)
+	}
+	state := req.GetProperties()
+	inputs := readInputs(req.GetInputs(), state)
+	if len(state.GetFields()) == 0 {
+		// The resource is being imported by its ID: nothing is run. The inputs of the import, if the engine
+		// sends them, are saved so that its commands are known; otherwise they are saved on its first update.
+		logging.V(9).Infof("Importing resource %s", req.GetId())
+		if inputs == nil {
+			inputs = &structpb.Struct{}
+		}
+		return &pulumirpc.ReadResponse{Id: req.GetId(), Properties: importedState(inputs), Inputs: inputs}, nil
+	}
+	out, err, _ := p.execCommand(ctx, req, "read", state, "olds")
+	if err != nil && err.Error() == "read command unspecified" {
+		logging.V(9).Infof("Skipping reading resource: %v", err)
+		return &pulumirpc.ReadResponse{Id: req.GetId(), Properties: state, Inputs: inputs}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	plainState, err := plainProperties(state)
+	if err != nil {
+		return nil, err
+	}
+	plainOut, err := plainProperties(out)
+	if err != nil {
 		return nil, err
 	}
-	properties := req.GetProperties()
-	if err.Error() != "read command unspecified" {
-		properties = out
+	drifted := hasDrifted(plainState, plainOut["stdout"].StringValue())
+	if drifted {
+		logging.V(1).Infof("Read check: %s has drifted", req.GetUrn())
 	}
-	return &pulumirpc.ReadResponse{Id: req.GetId(), Properties: properties}, nil
+
+	properties := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for k, v := range state.GetFields() {
+		properties.Fields[k] = v
+	}
+	properties.Fields["readStdout"] = out.Fields["stdout"]
+	properties.Fields["drifted"] = &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: drifted}}
+	return &pulumirpc.ReadResponse{Id: req.GetId(), Properties: properties, Inputs: inputs}, nil
 }
 
 func (p *commandProvider) Update(ctx context.Context, req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
+	if isLocalFile(req) {
+		return p.updateLocalFile(req)
+	}
+	if isCopy(req) {
+		return p.updateCopy(ctx, req)
+	}
+	started := time.Now()
 	news := req.GetNews()
-	out, err, _ := p.execCommand(ctx, req, "update", news, "properties")
+	newProps, err := plainProperties(news)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := changedInputs(ctx, req.GetOlds(), news)
 	if err != nil {
 		return nil, err
 	}
+	var out *structpb.Struct
+	switch {
+	case newProps["update"].IsNull(), len(changes.rerun) == 0 && len(changes.saved) > 0:
+		// Without an update command, or when only inputs that apply to the saved state changed, the
+		// resource keeps its outputs and only records its new inputs.
+		out, err = keptOutputs(req.GetOlds(), newProps)
+	case req.GetPreview():
+		out, err = p.previewCommand(req, "update", news)
+	default:
+		out, err, _ = p.execCommand(ctx, req, "update", news, "properties")
+		if out != nil {
+			keepImageDigests(out, req.GetOlds())
+		}
+	}
+	if _, ok := err.(*outputError); err != nil && !ok {
+		return nil, err
+	}
 	// Save inputs to state
 	out.Fields["inputs"] = &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: news}}
+	if err != nil {
+		// The update command has succeeded, but its output could not be parsed.
+		return nil, initFailed(req.GetId(), out, news, err)
+	}
+	if !req.GetPreview() {
+		if err := p.waitUntilReady(ctx, req, news, started); err != nil {
+			return nil, initFailed(req.GetId(), out, news, err)
+		}
+	}
 	return &pulumirpc.UpdateResponse{Properties: out}, nil
 }
 
+// keptOutputs returns the outputs of the previous state olds, marked secret according to the secretOutputs of
+// the new inputs, which also determine whether the output of the last read has drifted.
+func keptOutputs(olds *structpb.Struct, inputs resource.PropertyMap) (*structpb.Struct, error) {
+	state, err := plugin.UnmarshalProperties(olds, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipNulls: true})
+	if err != nil {
+		return nil, err
+	}
+	delete(state, "inputs")
+	secret := secretOutputSet(inputs)
+	for _, name := range secretOutputNames {
+		v, ok := state[resource.PropertyKey(name)]
+		if !ok {
+			continue
+		}
+		if v.IsSecret() {
+			v = v.SecretValue().Element
+		}
+		if secret[name] {
+			v = resource.MakeSecret(v)
+		}
+		state[resource.PropertyKey(name)] = v
+	}
+	// The output of the last read is compared again, as the new inputs may expect another output.
+	plain, err := plainProperties(olds)
+	if err != nil {
+		return nil, err
+	}
+	if plain["readStdout"].IsString() {
+		state["drifted"] = resource.NewBoolProperty(lastReadDrifted(plain, inputs))
+	}
+	return plugin.MarshalProperties(state, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+}
+
 // Delete tears down an existing resource with the given ID.
 // If it fails, the resource is assumed to still exist.
 func (p *commandProvider) Delete(ctx context.Context, req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
+	if isLocalFile(req) {
+		return p.deleteLocalFile(req)
+	}
+	if isCopy(req) {
+		return p.deleteCopy(ctx, req)
+	}
 	_, err, _ := p.execCommand(ctx, req, "delete", req.GetProperties(), "olds")
 	if err != nil && err.Error() != "delete command unspecified" {
 		return nil, err
@@ -349,7 +1375,12 @@ func (p *commandProvider) Delete(ctx context.Context, req *pulumirpc.DeleteReque
 
 // Cancel signals the provider to gracefully shut down and abort any ongoing resource operations.
 func (p *commandProvider) Cancel(context.Context, *pbempty.Empty) (*pbempty.Empty, error) {
+	p.mu.Lock()
+	p.cancelled = true
+	p.mu.Unlock()
 	p.canceler.cancel()
+	// Running commands observe the cancellation; wait for them to be stopped.
+	p.running.Wait()
 	return &pbempty.Empty{}, nil
 }
 
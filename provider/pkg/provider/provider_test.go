@@ -16,18 +16,29 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/jsonpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 )
 
-var json string = "{\"id\":\"id\",\"urn\":\"urn:pulumi:command-test::command-test::command:v1:exec::demo\",\"olds\":{\"inputs\":{\"compare\":\"eb045d78d273107348b0300c01d29b7552d622abbc6faf81b3ec55359aa9950c\",\"create\":{\"command\":[\"ls\"]},\"diff\":{\"command\":[\"bash\",\"-c\",\"exit 1\"]},\"update\":{\"command\":[\"bash\",\"-c\",\"echo $VAR\"],\"environment\":{\"VAR\":\"Hello Pulumi!\"}}},\"stderr\":\"\",\"stdout\":\"Pulumi.command-test.yaml\\nPulumi.yaml\\nindex.ts\\nnode_modules\\npackage.json\\ntsconfig.json\\nyarn.lock\\n\"},\"news\":{\"compare\":\"eb045d78d273107348b0300c01d29b7552d622abbc6faf81b3ec55359aa9950c\",\"create\":{\"command\":[\"ls\"]},\"diff\":{\"command\":[\"bash\",\"-c\",\"exit 1\"]},\"update\":{\"command\":[\"bash\",\"-c\",\"echo $VAR\"],\"environment\":{\"VAR\":\"Hello Pulumi!\"}}}}"
+var diffRequestJSON string = "{\"id\":\"id\",\"urn\":\"urn:pulumi:command-test::command-test::command:v1:exec::demo\",\"olds\":{\"inputs\":{\"compare\":\"eb045d78d273107348b0300c01d29b7552d622abbc6faf81b3ec55359aa9950c\",\"create\":{\"command\":[\"ls\"]},\"diff\":{\"command\":[\"bash\",\"-c\",\"exit 1\"]},\"update\":{\"command\":[\"bash\",\"-c\",\"echo $VAR\"],\"environment\":{\"VAR\":\"Hello Pulumi!\"}}},\"stderr\":\"\",\"stdout\":\"Pulumi.command-test.yaml\\nPulumi.yaml\\nindex.ts\\nnode_modules\\npackage.json\\ntsconfig.json\\nyarn.lock\\n\"},\"news\":{\"compare\":\"eb045d78d273107348b0300c01d29b7552d622abbc6faf81b3ec55359aa9950c\",\"create\":{\"command\":[\"ls\"]},\"diff\":{\"command\":[\"bash\",\"-c\",\"exit 1\"]},\"update\":{\"command\":[\"bash\",\"-c\",\"echo $VAR\"],\"environment\":{\"VAR\":\"Hello Pulumi!\"}}}}"
 
 func Test_commandProvider_Diff(t *testing.T) {
 	var req *pulumirpc.DiffRequest = &pulumirpc.DiffRequest{}
-	err := jsonpb.UnmarshalString(json, req)
+	err := jsonpb.UnmarshalString(diffRequestJSON, req)
 	if err != nil {
 		panic("Could not unmarshal json string")
 	}
@@ -83,3 +94,962 @@ func Test_commandProvider_Diff(t *testing.T) {
 		})
 	}
 }
+
+func Test_commandProvider_Check(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := ioutil.WriteFile(file, []byte("hello"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	tests := []struct {
+		name         string
+		news         string
+		wantFailures []string
+	}{
+		{
+			name: "Valid dir",
+			news: fmt.Sprintf(`{"create":{"command":["ls"],"dir":%q}}`, dir),
+		},
+		{
+			name: "Missing dir is accepted",
+			news: fmt.Sprintf(`{"create":{"command":["ls"],"dir":%q}}`, filepath.Join(dir, "later")),
+		},
+		{
+			name:         "Dir is a file",
+			news:         fmt.Sprintf(`{"create":{"command":["ls"]},"delete":{"command":["ls"],"dir":%q}}`, file),
+			wantFailures: []string{"delete.dir"},
+		},
+		{
+			name:         "Missing command",
+			news:         `{"create":{"dir":"."}}`,
+			wantFailures: []string{"create"},
+		},
+		{
+			name:         "Two ID strategies",
+			news:         `{"create":{"command":["ls"]},"idFrom":{"value":"a","stdout":true}}`,
+			wantFailures: []string{"idFrom"},
+		},
+		{
+			name:         "ID path without output format",
+			news:         `{"create":{"command":["ls"]},"idFrom":{"path":"id"}}`,
+			wantFailures: []string{"idFrom.path"},
+		},
+		{
+			name:         "Create has no previous state",
+			news:         `{"create":{"command":["cat"],"stdinFrom":"previousState"},"delete":{"command":["cat"],"stdinFrom":"previousState"}}`,
+			wantFailures: []string{"create.stdinFrom"},
+		},
+		{
+			name:         "Stdin and stdinFrom",
+			news:         `{"create":{"command":["ls"]},"delete":{"command":["cat"],"stdin":"x","stdinFrom":"previousState"}}`,
+			wantFailures: []string{"delete.stdinFrom"},
+		},
+		{
+			name: "Script",
+			news: `{"create":{"script":"echo hello","interpreter":"bash"}}`,
+		},
+		{
+			name:         "Command and script",
+			news:         `{"create":{"command":["ls"],"script":"ls"}}`,
+			wantFailures: []string{"create.script"},
+		},
+		{
+			name:         "Empty command",
+			news:         `{"create":{"command":[]}}`,
+			wantFailures: []string{"create.command"},
+		},
+		{
+			name:         "Interpreter without script",
+			news:         `{"create":{"command":["ls"],"interpreter":"ruby"},"delete":{"command":["ls"],"interpreter":"sh"}}`,
+			wantFailures: []string{"create.interpreter", "delete.interpreter"},
+		},
+		{
+			name:         "Invalid replaceOn",
+			news:         `{"create":{"command":["ls"]},"replaceOn":["create","stdout"]}`,
+			wantFailures: []string{"replaceOn[1]"},
+		},
+		{
+			name:         "Retry",
+			news:         `{"create":{"command":["ls"],"retry":{"attempts":0,"backoffFactor":0.5,"maxDelay":"soon","retryOnStderrMatch":"("}}}`,
+			wantFailures: []string{"create.retry.attempts", "create.retry.backoffFactor", "create.retry.maxDelay", "create.retry.retryOnStderrMatch"},
+		},
+		{
+			name:         "Wait for",
+			news:         `{"create":{"command":["ls"]},"waitFor":{"probe":{"command":[]},"interval":"0s","exitCode":0,"stdoutMatch":"ok","jsonPath":"a[","equals":"b"}}`,
+			wantFailures: []string{"waitFor.probe.command", "waitFor.interval", "waitFor", "waitFor.jsonPath"},
+		},
+		{
+			name:         "Wait for without probe",
+			news:         `{"create":{"command":["ls"]},"waitFor":{"jsonPath":"ready"}}`,
+			wantFailures: []string{"waitFor", "waitFor.equals"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.CheckRequest{}
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":%q,"news":%s}`, urn, tt.news), req)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			got, err := p.Check(context.Background(), req)
+			if err != nil {
+				t.Fatalf("commandProvider.Check() error = %v", err)
+			}
+			var failures []string
+			for _, f := range got.Failures {
+				failures = append(failures, f.Property)
+			}
+			if !reflect.DeepEqual(failures, tt.wantFailures) {
+				t.Errorf("commandProvider.Check() failures = %v, want %v", got.Failures, tt.wantFailures)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_execCommandDir(t *testing.T) {
+	dir := t.TempDir()
+	req := &pulumirpc.CreateRequest{}
+	err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":"urn:pulumi:command-test::command-test::command:v1:Command::demo","properties":{"create":{"command":["pwd"],"dir":%q}}}`, dir), req)
+	if err != nil {
+		t.Fatalf("Could not unmarshal json string: %v", err)
+	}
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	out, err, _ := p.execCommand(context.Background(), req, "create", req.GetProperties(), "properties")
+	if err != nil {
+		t.Fatalf("execCommand() error = %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(dir)
+	if got := strings.TrimSpace(out.Fields["stdout"].GetStringValue()); got != want {
+		t.Errorf("execCommand() stdout = %q, want %q", got, want)
+	}
+}
+
+func Test_commandProvider_execCommandTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     string
+		wantErr bool
+	}{
+		{
+			name:    "Cmd timeout",
+			req:     `{"urn":"urn:pulumi:command-test::command-test::command:v1:Command::demo","properties":{"create":{"command":["sleep","5"],"timeout":"100ms"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Custom timeout",
+			req:     `{"urn":"urn:pulumi:command-test::command-test::command:v1:Command::demo","properties":{"create":{"command":["sleep","5"]}},"timeout":0.1}`,
+			wantErr: true,
+		},
+		{
+			name: "Within timeout",
+			req:  `{"urn":"urn:pulumi:command-test::command-test::command:v1:Command::demo","properties":{"create":{"command":["true"],"timeout":"5s"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.CreateRequest{}
+			if err := jsonpb.UnmarshalString(tt.req, req); err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			start := time.Now()
+			_, err, _ := p.execCommand(context.Background(), req, "create", req.GetProperties(), "properties")
+			if _, ok := err.(*timeoutError); ok != tt.wantErr {
+				t.Errorf("execCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("execCommand() took %v", elapsed)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_execCommandAfterCancel(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	req := &pulumirpc.CreateRequest{}
+	err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":"urn:pulumi:command-test::command-test::command:v1:Command::demo","properties":{"create":{"command":["touch",%q]}}}`, marker), req)
+	if err != nil {
+		t.Fatalf("Could not unmarshal json string: %v", err)
+	}
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	if _, err := p.Cancel(context.Background(), nil); err != nil {
+		t.Fatalf("commandProvider.Cancel() error = %v", err)
+	}
+	_, err, _ = p.execCommand(context.Background(), req, "create", req.GetProperties(), "properties")
+	if err == nil || err.Error() != "create command was cancelled" {
+		t.Errorf("execCommand() error = %v, want create command was cancelled", err)
+	}
+	if _, err := ioutil.ReadFile(marker); err == nil {
+		t.Errorf("execCommand() ran the command after Cancel")
+	}
+}
+
+func Test_commandProvider_execCommandExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		create   string
+		wantErr  bool
+		wantCode float64
+	}{
+		{
+			name:   "Success",
+			create: `{"command":["true"]}`,
+		},
+		{
+			name:     "Failure",
+			create:   `{"command":["sh","-c","exit 3"]}`,
+			wantErr:  true,
+			wantCode: 3,
+		},
+		{
+			name:     "Allowed",
+			create:   `{"command":["sh","-c","exit 3"],"allowedExitCodes":[1,3]}`,
+			wantCode: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.CreateRequest{}
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":"urn:pulumi:command-test::command-test::command:v1:Command::demo","properties":{"create":%s}}`, tt.create), req)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			out, err, _ := p.execCommand(context.Background(), req, "create", req.GetProperties(), "properties")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("execCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := out.Fields["exitCode"].GetNumberValue(); got != tt.wantCode {
+				t.Errorf("execCommand() exitCode = %v, want %v", got, tt.wantCode)
+			}
+			for _, key := range []string{"startedAt", "finishedAt"} {
+				if _, err := time.Parse(time.RFC3339Nano, out.Fields[key].GetStringValue()); err != nil {
+					t.Errorf("execCommand() %s: %v", key, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_commandProvider_execCommandSecrets(t *testing.T) {
+	secret := func(v string) string {
+		return fmt.Sprintf(`{%q:%q,"value":%q}`, resource.SigKey, resource.SecretSig, v)
+	}
+	tests := []struct {
+		name       string
+		properties string
+		wantSecret bool
+		wantErr    bool
+	}{
+		{
+			name:       "Secret stdout",
+			properties: fmt.Sprintf(`{"create":{"command":["cat"],"stdin":%s},"secretOutputs":["stdout"]}`, secret("hunter2")),
+			wantSecret: true,
+		},
+		{
+			name:       "Plain stdout",
+			properties: fmt.Sprintf(`{"create":{"command":["cat"],"stdin":%s}}`, secret("hunter2")),
+		},
+		{
+			name:       "Redacted error",
+			properties: fmt.Sprintf(`{"create":{"command":["sh","-c","echo $TOKEN >&2; exit 1"],"environment":{"TOKEN":%s}}}`, secret("hunter2")),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.CreateRequest{}
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":"urn:pulumi:command-test::command-test::command:v1:Command::demo","properties":%s}`, tt.properties), req)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			out, err, _ := p.execCommand(context.Background(), req, "create", req.GetProperties(), "properties")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("execCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if strings.Contains(err.Error(), "hunter2") {
+					t.Errorf("execCommand() error = %v, contains a secret", err)
+				}
+				return
+			}
+			stdout := out.Fields["stdout"]
+			isSecret := stdout.GetStructValue().GetFields()[resource.SigKey].GetStringValue() == resource.SecretSig
+			if isSecret != tt.wantSecret {
+				t.Errorf("execCommand() stdout = %v, wantSecret %v", stdout, tt.wantSecret)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_Invoke(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          string
+		want         string
+		wantFailures []string
+		wantErr      bool
+	}{
+		{
+			name: "Run",
+			req:  `{"tok":"command:v1:run","args":{"command":["echo","hello"],"outputFormat":"lines"}}`,
+			want: `{"attempts":1,"exitCode":0,"parsed":["hello"],"stderr":"","stdout":"hello\n"}`,
+		},
+		{
+			name:         "Invalid args",
+			req:          `{"tok":"command:v1:run","args":{"command":["true"],"outputFormat":"xml"}}`,
+			wantFailures: []string{"outputFormat"},
+		},
+		{
+			name:    "Failure",
+			req:     `{"tok":"command:v1:run","args":{"command":["false"]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown function",
+			req:     `{"tok":"command:v1:walk","args":{}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.InvokeRequest{}
+			if err := jsonpb.UnmarshalString(tt.req, req); err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			got, err := p.Invoke(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("commandProvider.Invoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var failures []string
+			for _, f := range got.Failures {
+				failures = append(failures, f.Property)
+			}
+			if !reflect.DeepEqual(failures, tt.wantFailures) {
+				t.Errorf("commandProvider.Invoke() failures = %v, want %v", got.Failures, tt.wantFailures)
+			}
+			if tt.want == "" {
+				return
+			}
+			s, err := (&jsonpb.Marshaler{}).MarshalToString(got.Return)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.want {
+				t.Errorf("commandProvider.Invoke() = %s, want %s", s, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_Preview(t *testing.T) {
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	unknown := fmt.Sprintf("%q", plugin.UnknownStringValue)
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+
+	t.Run("Create is not run", func(t *testing.T) {
+		req := &pulumirpc.CreateRequest{}
+		err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":%q,"preview":true,"properties":{"create":{"command":["false"],"stdin":%s,"outputFormat":"json"},"secretOutputs":["stdout"]}}`, urn, unknown), req)
+		if err != nil {
+			t.Fatalf("Could not unmarshal json string: %v", err)
+		}
+		got, err := p.Create(context.Background(), req)
+		if err != nil {
+			t.Fatalf("commandProvider.Create() error = %v", err)
+		}
+		props, err := plugin.UnmarshalProperties(got.Properties, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []resource.PropertyKey{"stdout", "stderr", "exitCode", "parsed"} {
+			if !props[name].ContainsUnknowns() {
+				t.Errorf("commandProvider.Create() %s = %v, want unknown", name, props[name])
+			}
+		}
+		if !props["stdout"].IsSecret() {
+			t.Errorf("commandProvider.Create() stdout = %v, want secret", props["stdout"])
+		}
+	})
+
+	t.Run("Script hash is unknown", func(t *testing.T) {
+		req := &pulumirpc.CreateRequest{}
+		err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":%q,"preview":true,"properties":{"create":{"script":"exit 1","scriptFile":true}}}`, urn), req)
+		if err != nil {
+			t.Fatalf("Could not unmarshal json string: %v", err)
+		}
+		got, err := p.Create(context.Background(), req)
+		if err != nil {
+			t.Fatalf("commandProvider.Create() error = %v", err)
+		}
+		props, err := plugin.UnmarshalProperties(got.Properties, plugin.MarshalOptions{KeepUnknowns: true, KeepSecrets: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !props["scriptHash"].ContainsUnknowns() {
+			t.Errorf("commandProvider.Create() scriptHash = %v, want unknown", props["scriptHash"])
+		}
+	})
+
+	t.Run("Diff with unknown inputs", func(t *testing.T) {
+		req := &pulumirpc.DiffRequest{}
+		olds := `{"inputs":{"create":{"command":["true"]},"diff":{"command":["true"]}},"stdout":"","stderr":""}`
+		news := fmt.Sprintf(`{"create":{"command":["true"]},"diff":{"command":["sh","-c",%s]}}`, unknown)
+		err := jsonpb.UnmarshalString(fmt.Sprintf(`{"id":"id","urn":%q,"olds":%s,"news":%s}`, urn, olds, news), req)
+		if err != nil {
+			t.Fatalf("Could not unmarshal json string: %v", err)
+		}
+		got, err := p.Diff(context.Background(), req)
+		if err != nil {
+			t.Fatalf("commandProvider.Diff() error = %v", err)
+		}
+		if got.Changes != pulumirpc.DiffResponse_DIFF_UNKNOWN {
+			t.Errorf("commandProvider.Diff() = %v, want %v", got.Changes, pulumirpc.DiffResponse_DIFF_UNKNOWN)
+		}
+	})
+}
+
+func Test_commandProvider_DiffDetailed(t *testing.T) {
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	olds := `{"inputs":{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]}},"stdout":"","stderr":""}`
+	tests := []struct {
+		name     string
+		olds     string
+		news     string
+		want     pulumirpc.DiffResponse_DiffChanges
+		wantDiff []string
+	}{
+		{
+			name: "No changes",
+			news: `{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]}}`,
+			want: pulumirpc.DiffResponse_DIFF_NONE,
+		},
+		{
+			name:     "Compare changed",
+			news:     `{"compare":"b","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]}}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"compare"},
+		},
+		{
+			name:     "Update environment changed",
+			news:     `{"compare":"a","create":{"command":["true"]},"update":{"command":["true"],"environment":{"VAR":"1"}},"diff":{"command":["false"]}}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"update.environment"},
+		},
+		{
+			name:     "Read dir changed",
+			olds:     `{"inputs":{"compare":"a","create":{"command":["true"]},"read":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]}},"stdout":"","stderr":""}`,
+			news:     `{"compare":"a","create":{"command":["true"]},"read":{"command":["true"],"dir":"/tmp"},"update":{"command":["true"]},"diff":{"command":["false"]}}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"read.dir"},
+		},
+		{
+			name:     "Delete dir changed",
+			olds:     `{"inputs":{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"delete":{"command":["true"]},"diff":{"command":["false"]}},"stdout":"","stderr":""}`,
+			news:     `{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"delete":{"command":["true"],"dir":"/tmp"},"diff":{"command":["false"]}}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"delete.dir"},
+		},
+		{
+			name:     "Secret outputs changed",
+			news:     `{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]},"secretOutputs":["stdout"]}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"secretOutputs"},
+		},
+		{
+			name:     "Expected changed",
+			news:     `{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]},"expected":"ok"}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"expected"},
+		},
+		{
+			name:     "WaitFor changed",
+			news:     `{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]},"waitFor":{"probe":{"command":["true"]}}}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"waitFor"},
+		},
+		{
+			name:     "Drifted",
+			olds:     `{"inputs":{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]}},"stdout":"","stderr":"","readStdout":"b","drifted":true}`,
+			news:     `{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["false"]}}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"readStdout"},
+		},
+		{
+			// Nothing reconciles the drift, which is only reported by the drifted output.
+			name: "Drifted without an update command",
+			olds: `{"inputs":{"compare":"a","create":{"command":["true"]},"diff":{"command":["false"]}},"stdout":"","stderr":"","readStdout":"b","drifted":true}`,
+			news: `{"compare":"a","create":{"command":["true"]},"diff":{"command":["false"]}}`,
+			want: pulumirpc.DiffResponse_DIFF_NONE,
+		},
+		{
+			name:     "Diff command reports a change",
+			news:     `{"compare":"a","create":{"command":["true"]},"update":{"command":["true"]},"diff":{"command":["true"]}}`,
+			want:     pulumirpc.DiffResponse_DIFF_SOME,
+			wantDiff: []string{"diff", "diff.command[0]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			olds := olds
+			if tt.olds != "" {
+				olds = tt.olds
+			}
+			req := &pulumirpc.DiffRequest{}
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"id":"id","urn":%q,"olds":%s,"news":%s}`, urn, olds, tt.news), req)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			got, err := p.Diff(context.Background(), req)
+			if err != nil {
+				t.Fatalf("commandProvider.Diff() error = %v", err)
+			}
+			if got.Changes != tt.want {
+				t.Errorf("commandProvider.Diff() = %v, want %v", got.Changes, tt.want)
+			}
+			var paths []string
+			for path := range got.DetailedDiff {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			if !reflect.DeepEqual(paths, tt.wantDiff) {
+				t.Errorf("commandProvider.Diff() detailed diff = %v, want %v", paths, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_UpdateSecretOutputs(t *testing.T) {
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	create := resource.NewObjectProperty(resource.PropertyMap{"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")})})
+	marker := filepath.Join(t.TempDir(), "updated")
+	tests := []struct {
+		name   string
+		inputs resource.PropertyMap
+	}{
+		{
+			name:   "No update command",
+			inputs: resource.PropertyMap{"create": create},
+		},
+		{
+			// The update command is not run again only to mark the outputs.
+			name: "Update command",
+			inputs: resource.PropertyMap{
+				"create": create,
+				"update": resource.NewObjectProperty(resource.PropertyMap{"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("touch"), resource.NewStringProperty(marker)})}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldInputs := tt.inputs.Copy()
+			oldInputs["secretOutputs"] = resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("stderr")})
+			olds := mustMarshal(t, resource.PropertyMap{
+				"inputs": resource.NewObjectProperty(oldInputs),
+				"stdout": resource.NewStringProperty("token"),
+				"stderr": resource.MakeSecret(resource.NewStringProperty("")),
+			})
+			news := tt.inputs.Copy()
+			news["secretOutputs"] = resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("stdout")})
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			diff, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{Id: "id", Urn: urn, Olds: olds, News: mustMarshal(t, news)})
+			if err != nil {
+				t.Fatalf("commandProvider.Diff() error = %v", err)
+			}
+			if diff.GetChanges() != pulumirpc.DiffResponse_DIFF_SOME || diff.GetDetailedDiff()["secretOutputs[0]"] == nil {
+				t.Fatalf("commandProvider.Diff() = %v, want a change to secretOutputs", diff)
+			}
+			// The update marks the outputs it keeps according to the new secretOutputs.
+			got, err := p.Update(context.Background(), &pulumirpc.UpdateRequest{Id: "id", Urn: urn, Olds: olds, News: mustMarshal(t, news)})
+			if err != nil {
+				t.Fatalf("commandProvider.Update() error = %v", err)
+			}
+			state := mustUnmarshal(t, got.GetProperties())
+			if !state["stdout"].IsSecret() || state["stdout"].SecretValue().Element.StringValue() != "token" || state["stderr"].IsSecret() {
+				t.Errorf("commandProvider.Update() = %v, want only stdout to be secret", state)
+			}
+			if _, err := ioutil.ReadFile(marker); err == nil {
+				t.Errorf("commandProvider.Update() ran the update command")
+			}
+		})
+	}
+}
+
+func Test_commandProvider_UpdateExpected(t *testing.T) {
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	create := resource.NewObjectProperty(resource.PropertyMap{"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")})})
+	marker := filepath.Join(t.TempDir(), "updated")
+	tests := []struct {
+		name   string
+		inputs resource.PropertyMap
+	}{
+		{
+			name:   "No update command",
+			inputs: resource.PropertyMap{"create": create},
+		},
+		{
+			// The update command is not run again only to compare the last read with the new expected output.
+			name: "Update command",
+			inputs: resource.PropertyMap{
+				"create": create,
+				"update": resource.NewObjectProperty(resource.PropertyMap{"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("touch"), resource.NewStringProperty(marker)})}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The last refresh read "v2" where the create printed "v1".
+			olds := mustMarshal(t, resource.PropertyMap{
+				"inputs":     resource.NewObjectProperty(tt.inputs),
+				"stdout":     resource.NewStringProperty("v1"),
+				"stderr":     resource.NewStringProperty(""),
+				"readStdout": resource.NewStringProperty("v2"),
+				"drifted":    resource.NewBoolProperty(true),
+			})
+			inputs := tt.inputs.Copy()
+			inputs["expected"] = resource.NewStringProperty("v2")
+			news := mustMarshal(t, inputs)
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			diff, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{Id: "id", Urn: urn, Olds: olds, News: news})
+			if err != nil {
+				t.Fatalf("commandProvider.Diff() error = %v", err)
+			}
+			if diff.GetChanges() != pulumirpc.DiffResponse_DIFF_SOME || diff.GetDetailedDiff()["expected"] == nil || diff.GetDetailedDiff()["readStdout"] != nil {
+				t.Fatalf("commandProvider.Diff() = %v, want a change to expected", diff)
+			}
+			got, err := p.Update(context.Background(), &pulumirpc.UpdateRequest{Id: "id", Urn: urn, Olds: olds, News: news})
+			if err != nil {
+				t.Fatalf("commandProvider.Update() error = %v", err)
+			}
+			if drifted := mustUnmarshal(t, got.GetProperties())["drifted"]; !drifted.IsBool() || drifted.BoolValue() {
+				t.Errorf("commandProvider.Update() drifted = %v, want false as the read output is now expected", drifted)
+			}
+			if _, err := ioutil.ReadFile(marker); err == nil {
+				t.Errorf("commandProvider.Update() ran the update command")
+			}
+			diff, err = p.Diff(context.Background(), &pulumirpc.DiffRequest{Id: "id", Urn: urn, Olds: got.GetProperties(), News: news})
+			if err != nil {
+				t.Fatalf("commandProvider.Diff() error = %v", err)
+			}
+			if diff.GetChanges() != pulumirpc.DiffResponse_DIFF_NONE {
+				t.Errorf("commandProvider.Diff() after the update = %v, want DIFF_NONE", diff)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_DiffReplace(t *testing.T) {
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	olds := `{"inputs":{"compare":"a","create":{"command":["echo","a"]},"replaceOn":["create","compare"]},"stdout":"","stderr":""}`
+	tests := []struct {
+		name         string
+		news         string
+		want         pulumirpc.DiffResponse_DiffChanges
+		wantReplaces []string
+		wantDBR      bool
+	}{
+		{
+			name:         "Create changed",
+			news:         `{"compare":"a","create":{"command":["echo","b"]},"replaceOn":["create","compare"]}`,
+			want:         pulumirpc.DiffResponse_DIFF_SOME,
+			wantReplaces: []string{"create"},
+			wantDBR:      true,
+		},
+		{
+			name:         "Create before delete",
+			news:         `{"compare":"b","create":{"command":["echo","a"]},"replaceOn":["create","compare"],"deleteBeforeReplace":false}`,
+			want:         pulumirpc.DiffResponse_DIFF_SOME,
+			wantReplaces: []string{"compare"},
+		},
+		{
+			name:         "Unknown compare",
+			news:         fmt.Sprintf(`{"compare":%q,"create":{"command":["echo","a"]},"replaceOn":["compare"]}`, plugin.UnknownStringValue),
+			want:         pulumirpc.DiffResponse_DIFF_SOME,
+			wantReplaces: []string{"compare"},
+			wantDBR:      true,
+		},
+		{
+			name:         "ID strategy changed",
+			news:         `{"compare":"a","create":{"command":["echo","a"]},"replaceOn":["create","compare"],"idFrom":{"stdout":true}}`,
+			want:         pulumirpc.DiffResponse_DIFF_SOME,
+			wantReplaces: []string{"idFrom"},
+			wantDBR:      true,
+		},
+		{
+			name:    "Update in place without deleteBeforeReplace",
+			news:    `{"compare":"b","create":{"command":["echo","a"]},"replaceOn":["create"],"deleteBeforeReplace":false}`,
+			want:    pulumirpc.DiffResponse_DIFF_SOME,
+			wantDBR: false,
+		},
+		{
+			name:    "Update in place",
+			news:    `{"compare":"b","create":{"command":["echo","a"]},"replaceOn":["create"]}`,
+			want:    pulumirpc.DiffResponse_DIFF_SOME,
+			wantDBR: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.DiffRequest{}
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"id":"id","urn":%q,"olds":%s,"news":%s}`, urn, olds, tt.news), req)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			got, err := p.Diff(context.Background(), req)
+			if err != nil {
+				t.Fatalf("commandProvider.Diff() error = %v", err)
+			}
+			if got.Changes != tt.want {
+				t.Errorf("commandProvider.Diff() = %v, want %v", got.Changes, tt.want)
+			}
+			if len(got.Replaces) != 0 || len(tt.wantReplaces) != 0 {
+				if !reflect.DeepEqual(got.Replaces, tt.wantReplaces) {
+					t.Errorf("commandProvider.Diff() replaces = %v, want %v", got.Replaces, tt.wantReplaces)
+				}
+			}
+			if got.DeleteBeforeReplace != tt.wantDBR {
+				t.Errorf("commandProvider.Diff() deleteBeforeReplace = %v, want %v", got.DeleteBeforeReplace, tt.wantDBR)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_Read(t *testing.T) {
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	tests := []struct {
+		name        string
+		inputs      string
+		wantRead    string
+		wantDrifted bool
+	}{
+		{
+			name:     "No read command",
+			inputs:   `{"create":{"command":["echo","v1"]}}`,
+			wantRead: "",
+		},
+		{
+			name:     "In sync",
+			inputs:   `{"create":{"command":["echo","v1"]},"read":{"command":["echo","v1"]}}`,
+			wantRead: "v1\n",
+		},
+		{
+			name:        "Drifted",
+			inputs:      `{"create":{"command":["echo","v1"]},"read":{"command":["echo","v2"]}}`,
+			wantRead:    "v2\n",
+			wantDrifted: true,
+		},
+		{
+			name:     "Expected",
+			inputs:   `{"create":{"command":["echo","v1"]},"read":{"command":["echo","ok"]},"expected":"ok"}`,
+			wantRead: "ok\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.ReadRequest{}
+			state := fmt.Sprintf(`{"inputs":%s,"stdout":"v1\n","stderr":""}`, tt.inputs)
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"id":"id","urn":%q,"properties":%s,"inputs":%s}`, urn, state, tt.inputs), req)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			got, err := p.Read(context.Background(), req)
+			if err != nil {
+				t.Fatalf("commandProvider.Read() error = %v", err)
+			}
+			if got.Properties.Fields["inputs"] == nil || len(got.Inputs.GetFields()) == 0 {
+				t.Errorf("commandProvider.Read() dropped the inputs: %v", got)
+			}
+			if read := got.Properties.Fields["readStdout"].GetStringValue(); read != tt.wantRead {
+				t.Errorf("commandProvider.Read() readStdout = %q, want %q", read, tt.wantRead)
+			}
+			if drifted := got.Properties.Fields["drifted"].GetBoolValue(); drifted != tt.wantDrifted {
+				t.Errorf("commandProvider.Read() drifted = %v, want %v", drifted, tt.wantDrifted)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_CreateID(t *testing.T) {
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	tests := []struct {
+		name       string
+		properties string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "Default",
+			properties: `{"create":{"command":["echo","abc"]}}`,
+			want:       "id",
+		},
+		{
+			name:       "Static",
+			properties: `{"create":{"command":["echo","abc"]},"idFrom":{"value":"static"}}`,
+			want:       "static",
+		},
+		{
+			name:       "Stdout",
+			properties: `{"create":{"command":["echo","abc"]},"idFrom":{"stdout":true}}`,
+			want:       "abc",
+		},
+		{
+			name:       "Path",
+			properties: `{"create":{"command":["echo","{\"items\":[{\"id\":42}]}"],"outputFormat":"json"},"idFrom":{"path":"$.items[0].id"}}`,
+			want:       "42",
+		},
+		{
+			name:       "Empty stdout",
+			properties: `{"create":{"command":["true"]},"idFrom":{"stdout":true}}`,
+			want:       "id",
+			wantErr:    true,
+		},
+		{
+			name:       "Invalid output",
+			properties: `{"create":{"command":["echo","not json"],"outputFormat":"json"},"idFrom":{"value":"static"}}`,
+			want:       "static",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.CreateRequest{}
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"urn":%q,"properties":%s}`, urn, tt.properties), req)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			got, err := p.Create(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("commandProvider.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Id != tt.want {
+				t.Errorf("commandProvider.Create() id = %q, want %q", got.Id, tt.want)
+			}
+			if err != nil {
+				// The create command has run, so the resource must be recorded in the state.
+				failed := initFailure(t, err)
+				if failed.GetId() != tt.want || failed.GetProperties().GetFields()["stdout"] == nil || failed.GetProperties().GetFields()["inputs"] == nil {
+					t.Errorf("commandProvider.Create() initialization failure = %v, want the ID %q and the outputs", failed, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// initFailure returns the details of an error that reports a resource that was created or updated but did
+// not initialize.
+func initFailure(t *testing.T, err error) *pulumirpc.ErrorResourceInitFailed {
+	t.Helper()
+	rpcErr, ok := rpcerror.FromError(err)
+	if !ok {
+		t.Fatalf("error = %v, want a gRPC error", err)
+	}
+	for _, detail := range rpcErr.Details() {
+		if failed, ok := detail.(*pulumirpc.ErrorResourceInitFailed); ok {
+			return failed
+		}
+	}
+	t.Fatalf("error = %v, want an initialization failure", err)
+	return nil
+}
+
+func Test_commandProvider_Import(t *testing.T) {
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	tests := []struct {
+		name string
+		// sendInputs is set when the engine sends the inputs of the import to Read.
+		sendInputs bool
+		want       pulumirpc.DiffResponse_DiffChanges
+	}{
+		{name: "Without inputs", want: pulumirpc.DiffResponse_DIFF_SOME},
+		{name: "With inputs", sendInputs: true, want: pulumirpc.DiffResponse_DIFF_NONE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := filepath.Join(t.TempDir(), "deleted")
+			news := &structpb.Struct{}
+			err := jsonpb.UnmarshalString(fmt.Sprintf(`{"create":{"command":["true"]},"update":{"command":["sh","-c","echo $PULUMI_COMMAND_ID"]},"delete":{"command":["sh","-c","echo $PULUMI_COMMAND_ID > %s"]}}`, deleted), news)
+			if err != nil {
+				t.Fatalf("Could not unmarshal json string: %v", err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			readReq := &pulumirpc.ReadRequest{Id: "existing", Urn: urn}
+			if tt.sendInputs {
+				readReq.Inputs = news
+			}
+			read, err := p.Read(context.Background(), readReq)
+			if err != nil {
+				t.Fatalf("commandProvider.Read() error = %v", err)
+			}
+			if read.Id != "existing" || read.Properties == nil || read.Inputs == nil {
+				t.Fatalf("commandProvider.Read() = %v", read)
+			}
+
+			diff, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{Id: "existing", Urn: urn, Olds: read.Properties, News: news})
+			if err != nil {
+				t.Fatalf("commandProvider.Diff() error = %v", err)
+			}
+			if diff.GetChanges() != tt.want {
+				t.Fatalf("commandProvider.Diff() = %v, want %v", diff, tt.want)
+			}
+			state := read.Properties
+			if diff.GetChanges() == pulumirpc.DiffResponse_DIFF_SOME {
+				// The update saves the inputs that the import did not have.
+				got, err := p.Update(context.Background(), &pulumirpc.UpdateRequest{Id: "existing", Urn: urn, Olds: state, News: news})
+				if err != nil {
+					t.Fatalf("commandProvider.Update() error = %v", err)
+				}
+				if stdout := got.Properties.Fields["stdout"].GetStringValue(); stdout != "existing\n" {
+					t.Errorf("commandProvider.Update() stdout = %q, want the resource ID", stdout)
+				}
+				state = got.Properties
+			}
+
+			if _, err := p.Delete(context.Background(), &pulumirpc.DeleteRequest{Id: "existing", Urn: urn, Properties: state}); err != nil {
+				t.Fatalf("commandProvider.Delete() error = %v", err)
+			}
+			if b, err := ioutil.ReadFile(deleted); err != nil || string(b) != "existing\n" {
+				t.Errorf("commandProvider.Delete() did not run the delete command: %v %q", err, b)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_DeleteStdinFrom(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "state.json")
+	urn := "urn:pulumi:command-test::command-test::command:v1:Command::demo"
+	inputs := fmt.Sprintf(`{"create":{"command":["echo","{\"id\":\"i-123\"}"],"outputFormat":"json"},"delete":{"command":["sh","-c","cat > %s"],"stdinFrom":"previousState"}}`, out)
+	req := &pulumirpc.DeleteRequest{}
+	err := jsonpb.UnmarshalString(fmt.Sprintf(`{"id":"id","urn":%q,"properties":{"inputs":%s,"stdout":"{\"id\":\"i-123\"}\n","stderr":"","parsed":{"id":"i-123"}}}`, urn, inputs), req)
+	if err != nil {
+		t.Fatalf("Could not unmarshal json string: %v", err)
+	}
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	if _, err := p.Delete(context.Background(), req); err != nil {
+		t.Fatalf("commandProvider.Delete() error = %v", err)
+	}
+	b, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Stdout string
+		Parsed map[string]string
+		Inputs map[string]interface{}
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("stdin is not JSON: %v: %s", err, b)
+	}
+	if got.Stdout != "{\"id\":\"i-123\"}\n" || got.Parsed["id"] != "i-123" || got.Inputs["delete"] == nil {
+		t.Errorf("commandProvider.Delete() stdin = %s", b)
+	}
+}
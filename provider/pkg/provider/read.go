@@ -0,0 +1,75 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// readInputs returns the inputs of a resource being read. Older engines do not send the inputs, in
+// which case they are taken from the state.
+func readInputs(inputs, state *structpb.Struct) *structpb.Struct {
+	if len(inputs.GetFields()) > 0 {
+		return inputs
+	}
+	if saved := state.GetFields()["inputs"].GetStructValue(); saved != nil {
+		return saved
+	}
+	return inputs
+}
+
+// expectedStdout returns the output the read command is expected to print: the expected input if it is
+// set, otherwise the stdout of the last create or update.
+func expectedStdout(state resource.PropertyMap) string {
+	if inputs := state["inputs"]; inputs.IsObject() {
+		if expected := inputs.ObjectValue()["expected"]; expected.IsString() {
+			return expected.StringValue()
+		}
+	}
+	if stdout := state["stdout"]; stdout.IsString() {
+		return stdout.StringValue()
+	}
+	return ""
+}
+
+// hasDrifted reports whether the output of the read command differs from the expected output, ignoring
+// leading and trailing whitespace.
+func hasDrifted(state resource.PropertyMap, readStdout string) bool {
+	return strings.TrimSpace(readStdout) != strings.TrimSpace(expectedStdout(state))
+}
+
+// lastReadDrifted reports whether the output that the last read of the plain state saved differs from the
+// output that inputs expect. It is false when no read has been saved.
+func lastReadDrifted(state, inputs resource.PropertyMap) bool {
+	readStdout := state["readStdout"]
+	if !readStdout.IsString() {
+		return false
+	}
+	current := resource.PropertyMap{"inputs": resource.NewObjectProperty(inputs), "stdout": state["stdout"]}
+	return hasDrifted(current, readStdout.StringValue())
+}
+
+// importedState returns the state of a resource imported by its ID with inputs, before any command has run.
+func importedState(inputs *structpb.Struct) *structpb.Struct {
+	empty := &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: ""}}
+	state := &structpb.Struct{Fields: map[string]*structpb.Value{"stdout": empty, "stderr": empty}}
+	if len(inputs.GetFields()) > 0 {
+		state.Fields["inputs"] = &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: inputs}}
+	}
+	return state
+}
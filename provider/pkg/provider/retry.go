@@ -0,0 +1,162 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// retry configures how a failing command is retried.
+type retry struct {
+	// Attempts is the maximum number of times the command runs, including the first.
+	Attempts int `pulumi:"attempts,optional"`
+	// InitialDelay is the time to wait before the first retry. Each retry waits BackoffFactor times longer
+	// than the previous one, up to MaxDelay.
+	InitialDelay  string  `pulumi:"initialDelay,optional" structpb:"initialDelay"`
+	MaxDelay      string  `pulumi:"maxDelay,optional" structpb:"maxDelay"`
+	BackoffFactor float64 `pulumi:"backoffFactor,optional" structpb:"backoffFactor"`
+	// RetryOnExitCodes and RetryOnStderrMatch restrict the failures that are retried.
+	RetryOnExitCodes   []int  `pulumi:"retryOnExitCodes,optional" structpb:"retryOnExitCodes"`
+	RetryOnStderrMatch string `pulumi:"retryOnStderrMatch,optional" structpb:"retryOnStderrMatch"`
+}
+
+// The defaults of a retry block.
+const (
+	defaultRetryAttempts      = 3
+	defaultRetryInitialDelay  = time.Second
+	defaultRetryMaxDelay      = 30 * time.Second
+	defaultRetryBackoffFactor = 2
+)
+
+// retryPolicy is a parsed retry block.
+type retryPolicy struct {
+	attempts      int
+	initialDelay  time.Duration
+	maxDelay      time.Duration
+	backoffFactor float64
+	exitCodes     []int
+	stderrMatch   *regexp.Regexp
+}
+
+// retryPolicy returns the retry policy of the command. A command without a retry block runs once.
+func (c cmd) retryPolicy() (retryPolicy, error) {
+	if c.Retry == nil {
+		return retryPolicy{attempts: 1}, nil
+	}
+	r := retryPolicy{
+		attempts:      defaultRetryAttempts,
+		initialDelay:  defaultRetryInitialDelay,
+		maxDelay:      defaultRetryMaxDelay,
+		backoffFactor: defaultRetryBackoffFactor,
+		exitCodes:     c.Retry.RetryOnExitCodes,
+	}
+	if c.Retry.Attempts > 0 {
+		r.attempts = c.Retry.Attempts
+	}
+	if c.Retry.BackoffFactor > 0 {
+		r.backoffFactor = c.Retry.BackoffFactor
+	}
+	var err error
+	if c.Retry.InitialDelay != "" {
+		if r.initialDelay, err = time.ParseDuration(c.Retry.InitialDelay); err != nil {
+			return r, errors.Wrap(err, "invalid retry.initialDelay")
+		}
+	}
+	if c.Retry.MaxDelay != "" {
+		if r.maxDelay, err = time.ParseDuration(c.Retry.MaxDelay); err != nil {
+			return r, errors.Wrap(err, "invalid retry.maxDelay")
+		}
+	}
+	if c.Retry.RetryOnStderrMatch != "" {
+		if r.stderrMatch, err = regexp.Compile(c.Retry.RetryOnStderrMatch); err != nil {
+			return r, errors.Wrap(err, "invalid retry.retryOnStderrMatch")
+		}
+	}
+	return r, nil
+}
+
+// retries reports whether a command that exited with code and printed stderr is retried.
+func (r retryPolicy) retries(code int, stderr string) bool {
+	if code == 0 {
+		// The command did not exit with an error, for example because it timed out.
+		return false
+	}
+	if len(r.exitCodes) > 0 {
+		matched := false
+		for _, c := range r.exitCodes {
+			matched = matched || c == code
+		}
+		if !matched {
+			return false
+		}
+	}
+	return r.stderrMatch == nil || r.stderrMatch.MatchString(stderr)
+}
+
+// delay returns how long to wait after the failure of attempt, counting from one.
+func (r retryPolicy) delay(attempt int) time.Duration {
+	d := float64(r.initialDelay) * math.Pow(r.backoffFactor, float64(attempt-1))
+	if d > float64(r.maxDelay) {
+		return r.maxDelay
+	}
+	return time.Duration(d)
+}
+
+// checkRetry validates the retry block of a command.
+func (c *checker) checkRetry(path string, spec resource.PropertyMap) {
+	v := spec["retry"]
+	if !v.IsObject() {
+		return
+	}
+	path = propertyPath(path, "retry")
+	r := v.ObjectValue()
+	if attempts := r["attempts"]; attempts.IsNumber() && attempts.NumberValue() < 1 {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "attempts"),
+			Reason:   fmt.Sprintf("expected at least 1 attempt, received %v", attempts.NumberValue()),
+		})
+	}
+	if factor := r["backoffFactor"]; factor.IsNumber() && factor.NumberValue() < 1 {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "backoffFactor"),
+			Reason:   fmt.Sprintf("expected a factor of at least 1, received %v", factor.NumberValue()),
+		})
+	}
+	for _, k := range []resource.PropertyKey{"initialDelay", "maxDelay"} {
+		if d := r[k]; d.IsString() {
+			if parsed, err := time.ParseDuration(d.StringValue()); err != nil || parsed < 0 {
+				c.failures = append(c.failures, &pulumirpc.CheckFailure{
+					Property: propertyPath(path, string(k)),
+					Reason:   fmt.Sprintf("expected a duration such as \"5s\", received %q", d.StringValue()),
+				})
+			}
+		}
+	}
+	if match := r["retryOnStderrMatch"]; match.IsString() {
+		if _, err := regexp.Compile(match.StringValue()); err != nil {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{
+				Property: propertyPath(path, "retryOnStderrMatch"),
+				Reason:   err.Error(),
+			})
+		}
+	}
+}
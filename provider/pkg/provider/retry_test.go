@@ -0,0 +1,134 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+func Test_retryPolicy_delay(t *testing.T) {
+	policy, err := cmd{Retry: &retry{InitialDelay: "1s", MaxDelay: "5s", BackoffFactor: 3}}.retryPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Duration{time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := policy.delay(i + 1); got != w {
+			t.Errorf("delay(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func Test_retryPolicy_retries(t *testing.T) {
+	tests := []struct {
+		name   string
+		retry  *retry
+		code   int
+		stderr string
+		want   bool
+	}{
+		{name: "Any failure", retry: &retry{}, code: 1, want: true},
+		{name: "Not a failure", retry: &retry{}, code: 0, want: false},
+		{name: "Listed exit code", retry: &retry{RetryOnExitCodes: []int{2, 75}}, code: 75, want: true},
+		{name: "Unlisted exit code", retry: &retry{RetryOnExitCodes: []int{2, 75}}, code: 1, want: false},
+		{name: "Matching stderr", retry: &retry{RetryOnStderrMatch: "(?i)timed? ?out"}, code: 1, stderr: "connection timed out", want: true},
+		{name: "Other stderr", retry: &retry{RetryOnStderrMatch: "(?i)timed? ?out"}, code: 1, stderr: "permission denied", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := cmd{Retry: tt.retry}.retryPolicy()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := policy.retries(tt.code, tt.stderr); got != tt.want {
+				t.Errorf("retries(%d, %q) = %v, want %v", tt.code, tt.stderr, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_execCommandRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		op           string
+		failures     int
+		attempts     float64
+		delay        string
+		timeout      string
+		wantErr      bool
+		wantAttempts float64
+	}{
+		{name: "First attempt", failures: 0, attempts: 3, wantAttempts: 1},
+		{name: "Transient failure", failures: 2, attempts: 3, wantAttempts: 3},
+		{name: "Attempts exhausted", failures: 3, attempts: 2, wantErr: true, wantAttempts: 2},
+		// The second retry would wait until after the timeout of the command, which covers every attempt.
+		{name: "Timeout", failures: 9, attempts: 9, delay: "200ms", timeout: "500ms", wantErr: true, wantAttempts: 2},
+		{name: "Diff command", op: "diff", failures: 3, attempts: 3, wantErr: true, wantAttempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The script fails until it has recorded tt.failures runs in the counter file.
+			counter := filepath.Join(t.TempDir(), "runs")
+			script := `n=$(cat "$COUNTER" 2>/dev/null || echo 0)
+echo $((n + 1)) > "$COUNTER"
+if [ "$n" -lt "$FAILURES" ]; then echo "attempt $n failed" >&2; exit 3; fi
+echo ok`
+			op, delay := tt.op, tt.delay
+			if op == "" {
+				op = "create"
+			}
+			if delay == "" {
+				delay = "1ms"
+			}
+			spec := resource.PropertyMap{
+				"script": resource.NewStringProperty(script),
+				"environment": resource.NewObjectProperty(resource.PropertyMap{
+					"COUNTER":  resource.NewStringProperty(counter),
+					"FAILURES": resource.NewStringProperty(string(rune('0' + tt.failures))),
+				}),
+				"retry": resource.NewObjectProperty(resource.PropertyMap{
+					"attempts":         resource.NewNumberProperty(tt.attempts),
+					"initialDelay":     resource.NewStringProperty(delay),
+					"retryOnExitCodes": resource.NewArrayProperty([]resource.PropertyValue{resource.NewNumberProperty(3)}),
+				}),
+			}
+			if tt.timeout != "" {
+				spec["timeout"] = resource.NewStringProperty(tt.timeout)
+			}
+			props := resource.PropertyMap{resource.PropertyKey(op): resource.NewObjectProperty(spec)}
+			req := &pulumirpc.CreateRequest{Urn: "urn:pulumi:command-test::command-test::command:v1:Command::demo"}
+			var err error
+			req.Properties, err = plugin.MarshalProperties(props, plugin.MarshalOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			out, err, _ := p.execCommand(context.Background(), req, op, req.GetProperties(), "properties")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("execCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := out.Fields["attempts"].GetNumberValue(); got != tt.wantAttempts {
+				t.Errorf("execCommand() attempts = %v, want %v", got, tt.wantAttempts)
+			}
+		})
+	}
+}
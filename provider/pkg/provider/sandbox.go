@@ -0,0 +1,69 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// sandbox restricts a command that runs on the machine running the provider. The command sees the file
+// system read-only, except for a scratch directory, has no network, cannot gain privileges and is subject
+// to the resource limits that are set. Zero limits are not applied.
+type sandbox struct {
+	// CPUSeconds is the CPU time each process may use, after which it is killed.
+	CPUSeconds int `pulumi:"cpuSeconds,optional" structpb:"cpuSeconds"`
+	// MemoryMB is the size of the address space of each process, in mebibytes.
+	MemoryMB int `pulumi:"memoryMB,optional" structpb:"memoryMB"`
+	// OpenFiles is the number of files each process may have open.
+	OpenFiles int `pulumi:"openFiles,optional" structpb:"openFiles"`
+	// MaxProcesses is the number of processes the user of the command may run.
+	MaxProcesses int `pulumi:"maxProcesses,optional" structpb:"maxProcesses"`
+	// Network keeps the network of the provider instead of running the command in an empty network namespace.
+	Network bool `pulumi:"network,optional"`
+}
+
+// sandboxLimits are the fields of a sandbox that hold resource limits.
+var sandboxLimits = []resource.PropertyKey{"cpuSeconds", "memoryMB", "openFiles", "maxProcesses"}
+
+// checkSandbox validates the sandbox of a command, which only applies to commands that run on Linux
+// machines running the provider.
+func (c *checker) checkSandbox(path string, spec resource.PropertyMap) {
+	v := spec["sandbox"]
+	if !v.IsObject() {
+		return
+	}
+	path = propertyPath(path, "sandbox")
+	switch {
+	case c.remote:
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: path, Reason: "sandbox is not supported by RemoteCommand"})
+	case spec["image"].IsObject():
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: path, Reason: "sandbox is not supported with image"})
+	case runtime.GOOS != "linux":
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: path, Reason: "sandbox is only supported on Linux"})
+	}
+	s := v.ObjectValue()
+	for _, k := range sandboxLimits {
+		if limit := s[k]; limit.IsNumber() && limit.NumberValue() < 0 {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{
+				Property: propertyPath(path, string(k)),
+				Reason:   fmt.Sprintf("expected a positive limit, received %v", limit.NumberValue()),
+			})
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// sandboxEnv holds the sandboxSpec of a sandboxed command. A command with a sandbox is started as the
+// provider itself in new namespaces, which sets up the sandbox and then executes the command.
+const sandboxEnv = "_PULUMI_COMMAND_SANDBOX"
+
+// sandboxSpec is how the provider passes a sandbox to the process that sets it up.
+type sandboxSpec struct {
+	// Path is the executable of the command. Its arguments are those of the process.
+	Path string
+	// Scratch is the directory that stays writable.
+	Scratch string
+	// Limits are the resource limits to set, by resource.
+	Limits map[int]uint64
+}
+
+func init() {
+	data, ok := os.LookupEnv(sandboxEnv)
+	if !ok {
+		return
+	}
+	// No new privileges is a property of the thread, which must be the one that executes the command.
+	runtime.LockOSThread()
+	err := enterSandbox(data)
+	fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+	os.Exit(126)
+}
+
+// limits returns the resource limits of the sandbox.
+func (s sandbox) limits() map[int]uint64 {
+	limits := map[int]uint64{}
+	if s.CPUSeconds > 0 {
+		limits[unix.RLIMIT_CPU] = uint64(s.CPUSeconds)
+	}
+	if s.MemoryMB > 0 {
+		limits[unix.RLIMIT_AS] = uint64(s.MemoryMB) << 20
+	}
+	if s.OpenFiles > 0 {
+		limits[unix.RLIMIT_NOFILE] = uint64(s.OpenFiles)
+	}
+	if s.MaxProcesses > 0 {
+		limits[unix.RLIMIT_NPROC] = uint64(s.MaxProcesses)
+	}
+	return limits
+}
+
+// wrap makes cmd start in the sandbox. The command runs as the same user in new user and mount
+// namespaces and, unless the sandbox keeps the network, a new network namespace. Its TMPDIR is a scratch
+// directory, which the returned function removes.
+func (s sandbox) wrap(cmd *exec.Cmd) (func(), error) {
+	path, err := exec.LookPath(cmd.Path)
+	if err != nil {
+		return nil, err
+	}
+	scratch, err := ioutil.TempDir("", "pulumi-command-sandbox")
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(sandboxSpec{Path: path, Scratch: scratch, Limits: s.limits()})
+	if err != nil {
+		os.RemoveAll(scratch)
+		return nil, err
+	}
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, "TMPDIR="+scratch, sandboxEnv+"="+string(data))
+	cmd.Path = "/proc/self/exe"
+	flags := uintptr(syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS)
+	if !s.Network {
+		flags |= syscall.CLONE_NEWNET
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:                 flags,
+		UidMappings:                []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}},
+		GidMappings:                []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}},
+		GidMappingsEnableSetgroups: false,
+	}
+	return func() { os.RemoveAll(scratch) }, nil
+}
+
+// enterSandbox sets up the sandbox described by data in the current process and executes the command. It
+// only returns when that fails.
+func enterSandbox(data string) error {
+	var spec sandboxSpec
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+		return err
+	}
+	var env []string
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, sandboxEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+	if err := mountReadOnly(spec.Scratch); err != nil {
+		return err
+	}
+	// The process has every capability in its user namespace until it executes the command, which should
+	// not keep them even when it runs as root.
+	if err := dropCapabilities(); err != nil {
+		return err
+	}
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return errors.Wrap(err, "could not set no_new_privs")
+	}
+	// The limits are set last, as they may keep the process itself from allocating memory or threads.
+	for resource, value := range spec.Limits {
+		if err := syscall.Setrlimit(resource, &syscall.Rlimit{Cur: value, Max: value}); err != nil {
+			return errors.Wrapf(err, "could not set resource limit %d", resource)
+		}
+	}
+	return syscall.Exec(spec.Path, os.Args, env)
+}
+
+// mountFlags are the options of a mount that are kept when it is made read-only. A user namespace may not
+// change them.
+var mountFlags = map[string]uintptr{
+	"nosuid":     unix.MS_NOSUID,
+	"nodev":      unix.MS_NODEV,
+	"noexec":     unix.MS_NOEXEC,
+	"noatime":    unix.MS_NOATIME,
+	"nodiratime": unix.MS_NODIRATIME,
+	"relatime":   unix.MS_RELATIME,
+}
+
+// mountReadOnly binds scratch over itself and makes every other mount read-only, except for those of /proc
+// and /dev. The mounts are private to the mount namespace of the process.
+func mountReadOnly(scratch string) error {
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return errors.Wrap(err, "could not make the mounts private")
+	}
+	// A bind mount is read-only when its source is, so scratch is bound before the others are remounted.
+	if err := unix.Mount(scratch, scratch, "", unix.MS_BIND, ""); err != nil {
+		return errors.Wrap(err, "could not mount the scratch directory")
+	}
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// The fifth field is the mount point and the sixth its options.
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		target := unescapeMountPoint(fields[4])
+		if underPath(target, "/proc") || underPath(target, "/dev") || underPath(target, scratch) {
+			continue
+		}
+		flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY)
+		for _, option := range strings.Split(fields[5], ",") {
+			flags |= mountFlags[option]
+		}
+		if err := unix.Mount("", target, "", flags, ""); err != nil {
+			return errors.Wrapf(err, "could not make %s read-only", target)
+		}
+	}
+	return scanner.Err()
+}
+
+// underPath reports whether path is dir or inside it.
+func underPath(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+"/")
+}
+
+// unescapeMountPoint decodes the octal escapes of spaces and other characters in a mount point of
+// /proc/self/mountinfo.
+func unescapeMountPoint(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// dropCapabilities removes every capability from the bounding set of the process, so that the command
+// gains none when it is executed.
+func dropCapabilities() error {
+	data, err := ioutil.ReadFile("/proc/sys/kernel/cap_last_cap")
+	if err != nil {
+		return err
+	}
+	last, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return err
+	}
+	for capability := 0; capability <= last; capability++ {
+		if err := unix.Prctl(unix.PR_CAPBSET_DROP, uintptr(capability), 0, 0, 0); err != nil {
+			return errors.Wrapf(err, "could not drop capability %d", capability)
+		}
+	}
+	return nil
+}
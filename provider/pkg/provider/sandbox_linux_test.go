@@ -0,0 +1,216 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// skipWithoutUserNamespaces skips a test on machines where unprivileged user namespaces are disabled.
+func skipWithoutUserNamespaces(t *testing.T) {
+	t.Helper()
+	cmd := exec.Command("true")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:  syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}},
+	}
+	if err := cmd.Run(); err != nil {
+		t.Skipf("user namespaces are not available: %v", err)
+	}
+}
+
+// hostInterfaces returns the line the test script prints for the network interfaces of the test process.
+func hostInterfaces(t *testing.T) string {
+	t.Helper()
+	return strings.TrimSpace(mustOutput(t, "sh", "-c", `echo "interfaces:" $(tail -n +3 /proc/net/dev | cut -d: -f1)`))
+}
+
+func Test_commandProvider_Sandbox(t *testing.T) {
+	skipWithoutUserNamespaces(t)
+	// The script reports what the command may do, one line each.
+	script := strings.Join([]string{
+		`grep NoNewPrivs /proc/self/status | tr -d '\t'`,
+		`grep -E '^Max (cpu time|address space|open files|processes) ' /proc/self/limits | tr -s ' ' | sed 's/ $//'`,
+		`echo "interfaces:" $(tail -n +3 /proc/net/dev | cut -d: -f1)`,
+		`echo scratch > "$TMPDIR/file" && cat "$TMPDIR/file"`,
+		`touch sandbox-test 2>/dev/null || echo read-only`,
+		`[ "$(id -u)" = "` + strings.TrimSpace(mustOutput(t, "id", "-u")) + `" ] && echo same-user`,
+	}, "\n")
+	tests := []struct {
+		name    string
+		sandbox resource.PropertyMap
+		// want are lines that the script prints.
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "Limits",
+			sandbox: resource.PropertyMap{
+				"cpuSeconds":   resource.NewNumberProperty(30),
+				"memoryMB":     resource.NewNumberProperty(1024),
+				"openFiles":    resource.NewNumberProperty(64),
+				"maxProcesses": resource.NewNumberProperty(4096),
+			},
+			want: []string{
+				"NoNewPrivs:1",
+				"Max cpu time 30 30 seconds",
+				"Max processes 4096 4096 processes",
+				"Max open files 64 64 files",
+				"Max address space 1073741824 1073741824 bytes",
+				"interfaces: lo",
+				"scratch",
+				"read-only",
+				"same-user",
+			},
+		},
+		{
+			name:    "Network",
+			sandbox: resource.PropertyMap{"network": resource.NewBoolProperty(true)},
+			want:    []string{"NoNewPrivs:1", hostInterfaces(t), "scratch", "read-only", "same-user"},
+		},
+		{
+			name:    "Too many open files",
+			sandbox: resource.PropertyMap{"openFiles": resource.NewNumberProperty(3)},
+			wantErr: "exit status",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			create := resource.PropertyMap{
+				"script":  resource.NewStringProperty(script),
+				"sandbox": resource.NewObjectProperty(tt.sandbox),
+			}
+			resp, err := p.Create(context.Background(), &pulumirpc.CreateRequest{
+				Urn:        "urn:pulumi:command-test::command-test::command:v1:Command::demo",
+				Properties: mustMarshal(t, resource.PropertyMap{"create": resource.NewObjectProperty(create)}),
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			stdout := mustUnmarshal(t, resp.GetProperties())["stdout"].StringValue()
+			lines := map[string]bool{}
+			for _, line := range strings.Split(stdout, "\n") {
+				lines[line] = true
+			}
+			for _, want := range tt.want {
+				if !lines[want] {
+					t.Errorf("stdout = %q, want a line %q", stdout, want)
+				}
+			}
+		})
+	}
+	if _, err := os.Stat("sandbox-test"); err == nil {
+		os.Remove("sandbox-test")
+		t.Error("the sandboxed command wrote to the project directory")
+	}
+	leftovers, _ := ioutil.ReadDir(os.TempDir())
+	for _, info := range leftovers {
+		if strings.HasPrefix(info.Name(), "pulumi-command-sandbox") {
+			t.Errorf("the scratch directory %s was not removed", info.Name())
+		}
+	}
+}
+
+func mustOutput(t *testing.T, name string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func Test_commandProvider_CheckSandbox(t *testing.T) {
+	sandbox := resource.NewObjectProperty(resource.PropertyMap{
+		"cpuSeconds": resource.NewNumberProperty(-1),
+		"memoryMB":   resource.NewNumberProperty(512),
+	})
+	tests := []struct {
+		name         string
+		urn          string
+		create       resource.PropertyMap
+		wantFailures []string
+	}{
+		{
+			name:         "Local command",
+			urn:          "urn:pulumi:command-test::command-test::command:v1:Command::demo",
+			create:       resource.PropertyMap{"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")}), "sandbox": sandbox},
+			wantFailures: []string{"create.sandbox.cpuSeconds"},
+		},
+		{
+			name: "Image",
+			urn:  "urn:pulumi:command-test::command-test::command:v1:Command::demo",
+			create: resource.PropertyMap{
+				"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")}),
+				"image":   resource.NewObjectProperty(resource.PropertyMap{"name": resource.NewStringProperty("alpine")}),
+				"sandbox": sandbox,
+			},
+			wantFailures: []string{"create.sandbox", "create.sandbox.cpuSeconds"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			resp, err := p.Check(context.Background(), &pulumirpc.CheckRequest{
+				Urn:  tt.urn,
+				News: mustMarshal(t, resource.PropertyMap{"create": resource.NewObjectProperty(tt.create)}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, f := range resp.GetFailures() {
+				got = append(got, f.GetProperty())
+			}
+			if !reflect.DeepEqual(got, tt.wantFailures) {
+				t.Errorf("Check() failures = %v, want %v", resp.GetFailures(), tt.wantFailures)
+			}
+		})
+	}
+}
+
+func Test_unescapeMountPoint(t *testing.T) {
+	for in, want := range map[string]string{
+		"/":                   "/",
+		`/mnt/my\040disk`:     "/mnt/my disk",
+		`/mnt/tab\011and\134`: "/mnt/tab\tand\\",
+		`/trailing\04`:        `/trailing\04`,
+	} {
+		if got := unescapeMountPoint(in); got != want {
+			t.Errorf("unescapeMountPoint(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if !underPath("/proc/sys", "/proc") || underPath("/process", "/proc") {
+		t.Error("underPath() does not match the directories under a path")
+	}
+}
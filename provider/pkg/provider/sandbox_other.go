@@ -0,0 +1,29 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package provider
+
+import (
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// wrap fails, as the sandbox relies on Linux namespaces.
+func (s sandbox) wrap(cmd *exec.Cmd) (func(), error) {
+	return nil, errors.New("sandbox is only supported on Linux")
+}
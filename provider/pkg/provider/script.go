@@ -0,0 +1,136 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The interpreters that can run a script.
+const (
+	interpreterSh      = "sh"
+	interpreterBash    = "bash"
+	interpreterPython3 = "python3"
+	interpreterPwsh    = "pwsh"
+)
+
+// interpreters are the valid values of interpreter.
+var interpreters = []string{interpreterSh, interpreterBash, interpreterPython3, interpreterPwsh}
+
+// strictPrelude makes a POSIX shell script exit on the first failing command, unset variable or failing
+// pipeline. pipefail is only enabled where the shell supports it.
+const strictPrelude = "set -eu\n(set -o pipefail) 2>/dev/null && set -o pipefail\n"
+
+// args returns the arguments of the process that runs the command or script.
+func (c cmd) args() ([]string, error) {
+	if c.Script == "" {
+		if len(c.Command) == 0 {
+			return nil, errors.New("command is empty")
+		}
+		return c.Command, nil
+	}
+	switch c.interpreter() {
+	case interpreterSh, interpreterBash:
+		return []string{c.interpreter(), "-c", strictPrelude + c.Script}, nil
+	case interpreterPython3:
+		return []string{interpreterPython3, "-c", c.Script}, nil
+	case interpreterPwsh:
+		return []string{interpreterPwsh, "-NoProfile", "-NonInteractive", "-Command", c.Script}, nil
+	}
+	return nil, errors.Errorf("unknown interpreter %q", c.Interpreter)
+}
+
+// interpreter returns the interpreter of a script, which defaults to sh.
+func (c cmd) interpreter() string {
+	if c.Interpreter == "" {
+		return interpreterSh
+	}
+	return c.Interpreter
+}
+
+// scriptHash returns the SHA-256 hash of a script, saved in state so that changes to long scripts are easy to spot.
+func scriptHash(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeScriptFile writes the script of c to a temporary file that only the current user can access and
+// returns its path. The file is read by an interpreter rather than executed, so it may be in a temporary
+// directory mounted noexec. The caller removes the file.
+func (c cmd) writeScriptFile() (string, error) {
+	pattern := "pulumi-command-*"
+	if c.interpreter() == interpreterPwsh {
+		// pwsh only runs files with the .ps1 extension.
+		pattern += ".ps1"
+	}
+	f, err := ioutil.TempFile("", pattern)
+	if err != nil {
+		return "", errors.Wrap(err, "could not create script file")
+	}
+	_, err = f.WriteString(c.Script)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "could not write script file")
+	}
+	return f.Name(), nil
+}
+
+// scriptFileArgs returns the arguments of the process that runs the script of c from path. A script
+// starting with a shebang runs with the interpreter it names, except on Windows.
+func (c cmd) scriptFileArgs(path string) []string {
+	if args := shebangArgs(c.Script); args != nil && runtime.GOOS != "windows" {
+		return append(args, path)
+	}
+	switch c.interpreter() {
+	case interpreterSh, interpreterBash:
+		// Source the file so that the strict prelude applies without shifting its line numbers.
+		return []string{c.interpreter(), "-c", strictPrelude + `. "$0"`, path}
+	case interpreterPwsh:
+		return []string{interpreterPwsh, "-NoProfile", "-NonInteractive", "-File", path}
+	}
+	return []string{c.interpreter(), path}
+}
+
+// shebangArgs returns the interpreter that the shebang line of script names, followed by its argument if it
+// has one, or nil if script has no shebang. Like the kernel, it passes everything after the interpreter as a
+// single argument.
+func shebangArgs(script string) []string {
+	if !strings.HasPrefix(script, "#!") {
+		return nil
+	}
+	line := strings.TrimSpace(strings.SplitN(script[2:], "\n", 2)[0])
+	if line == "" {
+		return nil
+	}
+	i := strings.IndexAny(line, " \t")
+	if i < 0 {
+		return []string{line}
+	}
+	args := []string{line[:i]}
+	if arg := strings.TrimSpace(line[i+1:]); arg != "" {
+		args = append(args, arg)
+	}
+	return args
+}
@@ -0,0 +1,167 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+func Test_cmd_args(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     cmd
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Command",
+			cmd:  cmd{Command: []string{"echo", "hello"}},
+			want: []string{"echo", "hello"},
+		},
+		{
+			name:    "Empty command",
+			cmd:     cmd{Command: []string{}},
+			wantErr: true,
+		},
+		{
+			name: "Default interpreter",
+			cmd:  cmd{Script: "echo hello"},
+			want: []string{"sh", "-c", strictPrelude + "echo hello"},
+		},
+		{
+			name: "Python",
+			cmd:  cmd{Script: "print('hello')", Interpreter: interpreterPython3},
+			want: []string{"python3", "-c", "print('hello')"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cmd.args()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cmd.args() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cmd.args() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_strictPrelude(t *testing.T) {
+	for _, interpreter := range []string{interpreterSh, interpreterBash} {
+		if _, err := exec.LookPath(interpreter); err != nil {
+			continue
+		}
+		t.Run(interpreter, func(t *testing.T) {
+			for _, script := range []string{"false\necho reached", "echo $UNSET_VARIABLE_FOR_TEST"} {
+				args, err := cmd{Script: script, Interpreter: interpreter}.args()
+				if err != nil {
+					t.Fatal(err)
+				}
+				out, err := exec.Command(args[0], args[1:]...).Output()
+				if err == nil {
+					t.Errorf("script %q succeeded with %q, want failure", script, out)
+				}
+			}
+		})
+	}
+}
+
+func Test_shebangArgs(t *testing.T) {
+	for script, want := range map[string][]string{
+		"echo hi":                          nil,
+		"#!\necho hi":                      nil,
+		"#!/bin/sh\necho hi":               {"/bin/sh"},
+		"#! /usr/bin/env python3\nprint()": {"/usr/bin/env", "python3"},
+		"#!/usr/bin/env -S bash -eu\r\n":   {"/usr/bin/env", "-S bash -eu"},
+		"#!/bin/bash\t-x":                  {"/bin/bash", "-x"},
+	} {
+		if got := shebangArgs(script); !reflect.DeepEqual(got, want) {
+			t.Errorf("shebangArgs(%q) = %q, want %q", script, got, want)
+		}
+	}
+}
+
+func Test_commandProvider_execCommandScriptFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		script     string
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name:       "Shell",
+			script:     "echo one\necho two",
+			wantStdout: "one\ntwo\n",
+		},
+		{
+			name:    "Line numbers",
+			script:  "echo one\n\nfalse_command_for_test\necho unreachable",
+			wantErr: `create script: (line )?3:`,
+		},
+		{
+			// The script file is not executable, as the temporary directory may be mounted noexec.
+			name:       "Shebang",
+			script:     "#!/bin/sh\necho \"$0\"",
+			wantStdout: "create script\n",
+		},
+		{
+			name:    "Shebang argument",
+			script:  "#!/bin/sh -e\nfalse\necho unreachable",
+			wantErr: `exit status 1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			props := resource.PropertyMap{
+				"create": resource.NewObjectProperty(resource.PropertyMap{
+					"script":     resource.NewStringProperty(tt.script),
+					"scriptFile": resource.NewBoolProperty(true),
+				}),
+			}
+			req := &pulumirpc.CreateRequest{Urn: "urn:pulumi:command-test::command-test::command:v1:Command::demo"}
+			var err error
+			req.Properties, err = plugin.MarshalProperties(props, plugin.MarshalOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			out, err, _ := p.execCommand(context.Background(), req, "create", req.GetProperties(), "properties")
+			if tt.wantErr != "" {
+				if err == nil || !regexp.MustCompile(tt.wantErr).MatchString(err.Error()) {
+					t.Fatalf("execCommand() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("execCommand() error = %v", err)
+			}
+			if stdout := out.Fields["stdout"].GetStringValue(); stdout != tt.wantStdout {
+				t.Errorf("execCommand() stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+			if hash := out.Fields["scriptHash"].GetStringValue(); hash != scriptHash(tt.script) {
+				t.Errorf("execCommand() scriptHash = %q", hash)
+			}
+		})
+	}
+}
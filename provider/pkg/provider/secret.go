@@ -0,0 +1,117 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"sort"
+	"strings"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// secretOutputNames are the outputs that may be marked secret with secretOutputs.
+var secretOutputNames = []string{"stdout", "stderr", "parsed"}
+
+// secretOutputSet returns the names of the outputs that the secretOutputs of input mark as secret.
+func secretOutputSet(input resource.PropertyMap) map[string]bool {
+	secret := map[string]bool{}
+	if secretOutputs := input["secretOutputs"]; secretOutputs.IsArray() {
+		for _, name := range secretOutputs.ArrayValue() {
+			if name.IsString() {
+				secret[name.StringValue()] = true
+			}
+		}
+	}
+	return secret
+}
+
+// secretRedaction replaces secret values in output that is logged.
+const secretRedaction = "[secret]"
+
+// makeSecret wraps v so that the engine treats it as a secret.
+func makeSecret(v *structpb.Value) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			resource.SigKey: {Kind: &structpb.Value_StringValue{StringValue: resource.SecretSig}},
+			"value":         v,
+		},
+	}}}
+}
+
+// collectSecrets returns the string values of the secrets in props.
+func collectSecrets(props resource.PropertyMap) []string {
+	var secrets []string
+	var walk func(v resource.PropertyValue, secret bool)
+	walk = func(v resource.PropertyValue, secret bool) {
+		switch {
+		case v.IsSecret():
+			walk(v.SecretValue().Element, true)
+		case v.IsString():
+			if secret && v.StringValue() != "" {
+				secrets = append(secrets, v.StringValue())
+			}
+		case v.IsArray():
+			for _, e := range v.ArrayValue() {
+				walk(e, secret)
+			}
+		case v.IsObject():
+			for _, e := range v.ObjectValue() {
+				walk(e, secret)
+			}
+		}
+	}
+	walk(resource.NewObjectProperty(props), false)
+	return secrets
+}
+
+// withoutSecrets returns v without its secret values. Secret elements of arrays are replaced by null so that
+// the indexes of the others do not change.
+func withoutSecrets(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsSecret():
+		return resource.NewNullProperty()
+	case v.IsArray():
+		elems := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, e := range v.ArrayValue() {
+			elems[i] = withoutSecrets(e)
+		}
+		return resource.NewArrayProperty(elems)
+	case v.IsObject():
+		obj := resource.PropertyMap{}
+		for k, e := range v.ObjectValue() {
+			if !e.IsSecret() {
+				obj[k] = withoutSecrets(e)
+			}
+		}
+		return resource.NewObjectProperty(obj)
+	}
+	return v
+}
+
+// newRedactor returns a function that replaces every occurrence of secrets in a string.
+func newRedactor(secrets []string) func(string) string {
+	if len(secrets) == 0 {
+		return func(s string) string { return s }
+	}
+	// Replace longer secrets first so that a secret containing another is fully redacted.
+	sorted := append([]string(nil), secrets...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	oldnew := make([]string, 0, 2*len(sorted))
+	for _, s := range sorted {
+		oldnew = append(oldnew, s, secretRedaction)
+	}
+	return strings.NewReplacer(oldnew...).Replace
+}
@@ -25,7 +25,7 @@ func Serve(providerName, version string) {
 	// Start gRPC service.
 	err := provider.Main(
 		providerName, func(host *provider.HostClient) (pulumirpc.ResourceProviderServer, error) {
-			return makeCommandProvider(providerName, version)
+			return makeCommandProvider(host, providerName, version)
 		})
 
 	if err != nil {
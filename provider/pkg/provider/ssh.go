@@ -0,0 +1,438 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// remoteCommandType runs its commands on a remote host over SSH.
+const remoteCommandType = "command:v1:RemoteCommand"
+
+// connection describes how to reach a host over SSH.
+type connection struct {
+	Host string `pulumi:"host"`
+	Port int    `pulumi:"port,optional"`
+	User string `pulumi:"user,optional"`
+	// Password, PrivateKey and the SSH agent at AgentSocketPath authenticate the user.
+	Password           string `pulumi:"password,optional"`
+	PrivateKey         string `pulumi:"privateKey,optional" structpb:"privateKey"`
+	PrivateKeyPassword string `pulumi:"privateKeyPassword,optional" structpb:"privateKeyPassword"`
+	AgentSocketPath    string `pulumi:"agentSocketPath,optional" structpb:"agentSocketPath"`
+	// HostKey pins the key of the host, as a public key in authorized_keys format or a SHA256 fingerprint.
+	HostKey string `pulumi:"hostKey,optional" structpb:"hostKey"`
+	// DialTimeout is how long to keep trying to connect to a host that is not reachable yet.
+	DialTimeout string `pulumi:"dialTimeout,optional" structpb:"dialTimeout"`
+	// Proxy is a bastion host through which the host is reached.
+	Proxy *connection `pulumi:"proxy,optional"`
+}
+
+// The defaults of a connection.
+const (
+	defaultSSHPort     = 22
+	defaultSSHUser     = "root"
+	defaultDialTimeout = 2 * time.Minute
+)
+
+// isRemoteCommand reports whether a request is for a RemoteCommand resource.
+func isRemoteCommand(req hasUrn) bool {
+	return resource.URN(req.GetUrn()).Type() == remoteCommandType
+}
+
+// decodeConnection decodes the connection input of a resource, which is nil when it is not set.
+func decodeConnection(props resource.PropertyMap) (*connection, error) {
+	v, ok := props["connection"]
+	if !ok || v.IsNull() {
+		return nil, nil
+	}
+	var c connection
+	if err := decodeProperty("connection", v, reflect.ValueOf(&c)); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// address returns the host and port of the connection.
+func (c connection) address() string {
+	port := c.Port
+	if port == 0 {
+		port = defaultSSHPort
+	}
+	return net.JoinHostPort(c.Host, strconv.Itoa(port))
+}
+
+func (c connection) user() string {
+	if c.User == "" {
+		return defaultSSHUser
+	}
+	return c.User
+}
+
+// target identifies the host and user that commands run as, including the hosts they are reached through.
+// Unlike the credentials, a change of the target reruns the update command.
+func (c *connection) target() string {
+	if c == nil {
+		return ""
+	}
+	t := c.user() + "@" + c.address()
+	if c.Proxy != nil {
+		t += " via " + c.Proxy.target()
+	}
+	return t
+}
+
+// clientConfig returns the SSH configuration of the connection. The returned closer releases the
+// connection to the SSH agent, if one is used. warn shows the user host keys that are accepted without
+// being pinned.
+func (c connection) clientConfig(warn func(string)) (*ssh.ClientConfig, io.Closer, error) {
+	var methods []ssh.AuthMethod
+	var closer io.Closer = ioutil.NopCloser(nil)
+	if c.PrivateKey != "" {
+		var signer ssh.Signer
+		var err error
+		if c.PrivateKeyPassword != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(c.PrivateKey), []byte(c.PrivateKeyPassword))
+		} else {
+			signer, err = ssh.ParsePrivateKey([]byte(c.PrivateKey))
+		}
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid privateKey")
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	socket := c.AgentSocketPath
+	if socket == "" && c.PrivateKey == "" && c.Password == "" {
+		socket = os.Getenv("SSH_AUTH_SOCK")
+	}
+	if socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not connect to the SSH agent")
+		}
+		closer = conn
+		methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+	}
+	if c.Password != "" {
+		methods = append(methods, ssh.Password(c.Password))
+	}
+	if len(methods) == 0 {
+		return nil, nil, errors.New("connection requires a password, a privateKey or an SSH agent")
+	}
+	hostKeyCallback, err := c.hostKeyCallback(warn)
+	if err != nil {
+		closer.Close()
+		return nil, nil, err
+	}
+	return &ssh.ClientConfig{
+		User:            c.user(),
+		Auth:            methods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	}, closer, nil
+}
+
+// hostKeyCallback verifies the key of the host against the pinned host key. Without one, any key is accepted
+// and its fingerprint is passed to warn, so that the user can check and pin it.
+func (c connection) hostKeyCallback(warn func(string)) (ssh.HostKeyCallback, error) {
+	switch {
+	case c.HostKey == "":
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			warn(fmt.Sprintf("accepted the host key of %s with fingerprint %s, which is not pinned; set hostKey to %[2]s to reject any other key",
+				hostname, ssh.FingerprintSHA256(key)))
+			return nil
+		}, nil
+	case strings.HasPrefix(c.HostKey, "SHA256:"):
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if fingerprint := ssh.FingerprintSHA256(key); fingerprint != c.HostKey {
+				return errors.Errorf("the host key of %s has fingerprint %s, not %s", hostname, fingerprint, c.HostKey)
+			}
+			return nil
+		}, nil
+	default:
+		pinned, _, _, _, err := ssh.ParseAuthorizedKey([]byte(c.HostKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid hostKey")
+		}
+		return ssh.FixedHostKey(pinned), nil
+	}
+}
+
+// retryableDialError reports a failure to reach a host, which may succeed once the host is up.
+type retryableDialError struct {
+	err error
+}
+
+func (e *retryableDialError) Error() string {
+	return e.err.Error()
+}
+
+// connect connects to the host, retrying until it is reachable or the dial timeout passes.
+func (c connection) connect(ctx context.Context, warn func(string)) (*ssh.Client, error) {
+	timeout := defaultDialTimeout
+	if c.DialTimeout != "" {
+		d, err := time.ParseDuration(c.DialTimeout)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid dialTimeout")
+		}
+		timeout = d
+	}
+	deadline := time.Now().Add(timeout)
+	delay := time.Second
+	for {
+		client, err := c.dial(ctx, warn)
+		if _, ok := err.(*retryableDialError); !ok || time.Now().Add(delay).After(deadline) {
+			return client, err
+		}
+		logging.V(5).Infof("could not connect to %s, retrying in %v: %v", c.address(), delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if delay *= 2; delay > 10*time.Second {
+			delay = 10 * time.Second
+		}
+	}
+}
+
+// dial connects to the host once, through its proxy if it has one.
+func (c connection) dial(ctx context.Context, warn func(string)) (*ssh.Client, error) {
+	config, agentConn, err := c.clientConfig(warn)
+	if err != nil {
+		return nil, err
+	}
+	defer agentConn.Close()
+	var proxy *ssh.Client
+	var conn net.Conn
+	if c.Proxy != nil {
+		if proxy, err = c.Proxy.connect(ctx, warn); err != nil {
+			return nil, errors.Wrapf(err, "could not connect to proxy %s", c.Proxy.address())
+		}
+		conn, err = proxy.Dial("tcp", c.address())
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", c.address())
+	}
+	if err != nil {
+		if proxy != nil {
+			proxy.Close()
+		}
+		return nil, &retryableDialError{err}
+	}
+	sshConn, chans, reqs, err := handshake(ctx, conn, c.address(), config)
+	if err != nil {
+		conn.Close()
+		if proxy != nil {
+			proxy.Close()
+		}
+		if errors.Cause(err) == io.EOF {
+			// The SSH server closed the connection before the handshake, as it does while starting.
+			return nil, &retryableDialError{err}
+		}
+		return nil, errors.Wrapf(err, "could not connect to %s", c.address())
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	if proxy != nil {
+		go func() {
+			_ = client.Wait()
+			proxy.Close()
+		}()
+	}
+	return client, nil
+}
+
+// handshake runs the SSH handshake on conn. The timeout of config only applies to dialing, so the handshake is
+// given the same time here: conn is closed when it takes longer or ctx is done first. Closing works for the
+// channels of a proxy as well, which do not support deadlines.
+func handshake(ctx context.Context, conn net.Conn, addr string, config *ssh.ClientConfig) (ssh.Conn, <-chan ssh.NewChannel, <-chan *ssh.Request, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+	done := make(chan struct{})
+	closed := make(chan bool, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			closed <- true
+		case <-done:
+			closed <- false
+		}
+	}()
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	close(done)
+	if <-closed {
+		if err == nil {
+			sshConn.Close()
+		}
+		return nil, nil, nil, errors.Wrapf(ctx.Err(), "the SSH handshake with %s did not complete", addr)
+	}
+	return sshConn, chans, reqs, err
+}
+
+// remoteExitError reports the exit status of a remote command.
+type remoteExitError struct {
+	*ssh.ExitError
+}
+
+func (e *remoteExitError) ExitCode() int {
+	return e.ExitStatus()
+}
+
+// runRemote runs the command line on the host of conn. If ctx is done first, the command is sent the
+// stop signal and, if it is still running after the grace period, its session is closed.
+func runRemote(ctx context.Context, conn connection, warn func(string), line string, stdin io.Reader, stdout, stderr io.Writer, stop stopPolicy) error {
+	client, err := conn.connect(ctx, warn)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+	if err := session.Start(line); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		err := session.Wait()
+		if exitError, ok := err.(*ssh.ExitError); ok {
+			err = &remoteExitError{exitError}
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+	_ = session.Signal(ssh.Signal(strings.TrimPrefix(signalName(stop.signal), "SIG")))
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(stop.grace):
+		session.Close()
+		return ctx.Err()
+	}
+}
+
+// signalName returns the name of a stop signal, such as "SIGTERM".
+func signalName(sig syscall.Signal) string {
+	for name, s := range stopSignals {
+		if s == sig {
+			return name
+		}
+	}
+	return "SIGTERM"
+}
+
+// safeShellWord matches words that a POSIX shell does not interpret.
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes s as a single word for a POSIX shell.
+func shellQuote(s string) string {
+	if safeShellWord.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// remoteCommandLine returns the shell command line that runs args on a remote host in dir with the
+// variables env, and the input that must precede the stdin of the command. The variables are read from that
+// input rather than passed on the command line, where other users of the host could read them.
+func remoteCommandLine(args []string, dir string, env []string) (string, []byte) {
+	var vars strings.Builder
+	for _, kv := range env {
+		vars.WriteString("export " + shellQuote(kv) + "\n")
+	}
+	// dd reads one byte at a time, so that the rest of stdin is left to the command.
+	script := "vars=$(dd bs=1 count=" + strconv.Itoa(vars.Len()) + " 2>/dev/null) && eval \"$vars\" && unset vars"
+	if dir != "" {
+		script += " && cd " + shellQuote(dir)
+	}
+	script += ` && exec "$@"`
+	words := []string{"sh", "-c", shellQuote(script), "sh"}
+	for _, arg := range args {
+		words = append(words, shellQuote(arg))
+	}
+	return strings.Join(words, " "), []byte(vars.String())
+}
+
+// checkConnection validates the connection of a resource, which RemoteCommand and the copy resources require and
+// Command does not accept.
+// The types of its properties are validated with the rest of the inputs.
+func (c *checker) checkConnection(path string, props resource.PropertyMap, remote bool) {
+	v, ok := props["connection"]
+	switch {
+	case remote && (!ok || v.IsNull()):
+		c.failures = append(c.failures, missingRequiredProperty("", path))
+		return
+	case !remote && ok && !v.IsNull():
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: path,
+			Reason:   "connection is only supported by RemoteCommand",
+		})
+		return
+	case !remote:
+		return
+	}
+	for v.IsObject() {
+		conn := v.ObjectValue()
+		if host := conn["host"]; host.IsString() && host.StringValue() == "" {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: propertyPath(path, "host"), Reason: "host must not be empty"})
+		}
+		if port := conn["port"]; port.IsNumber() && (port.NumberValue() < 1 || port.NumberValue() > 65535) {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: propertyPath(path, "port"), Reason: "expected a port between 1 and 65535"})
+		}
+		c.checkTimeout(propertyPath(path, "dialTimeout"), conn["dialTimeout"])
+		if key := conn["privateKey"]; key.IsString() && !conn["privateKeyPassword"].IsString() {
+			if _, err := ssh.ParsePrivateKey([]byte(key.StringValue())); err != nil {
+				c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: propertyPath(path, "privateKey"), Reason: err.Error()})
+			}
+		}
+		if hostKey := conn["hostKey"]; hostKey.IsString() && !strings.HasPrefix(hostKey.StringValue(), "SHA256:") {
+			if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey.StringValue())); err != nil {
+				c.failures = append(c.failures, &pulumirpc.CheckFailure{
+					Property: propertyPath(path, "hostKey"),
+					Reason:   "expected a public key such as \"ssh-ed25519 AAAA...\" or a fingerprint such as \"SHA256:...\"",
+				})
+			}
+		}
+		path, v = propertyPath(path, "proxy"), conn["proxy"]
+	}
+}
@@ -0,0 +1,607 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHServer is an in-process SSH server. It runs exec requests with the local shell, serves the sftp
+// subsystem on the local file system and opens direct-tcpip channels, so that it can also serve as a bastion.
+type testSSHServer struct {
+	addr    string
+	hostKey ssh.PublicKey
+	mu      sync.Mutex
+	// lines are the command lines of the exec requests, which any user of a real host could read.
+	lines []string
+}
+
+// newTestKey returns a new private key in PEM format and its signer.
+func newTestKey(t *testing.T) (string, ssh.Signer) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), signer
+}
+
+// newTestSSHServer starts a server that accepts the user "test" with the password "secret" or the
+// public key of authorized.
+func newTestSSHServer(t *testing.T, authorized ssh.PublicKey) *testSSHServer {
+	t.Helper()
+	_, hostKey := newTestKey(t)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if c.User() == "test" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("password rejected for %s", c.User())
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if authorized != nil && c.User() == "test" && string(key.Marshal()) == string(authorized.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("public key rejected for %s", c.User())
+		},
+	}
+	config.AddHostKey(hostKey)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	s := &testSSHServer{addr: l.Addr().String(), hostKey: hostKey.PublicKey()}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, config)
+		}
+	}()
+	return s
+}
+
+// connection returns the connection properties of the server, authenticating with a password.
+func (s *testSSHServer) connection() resource.PropertyMap {
+	host, port, _ := net.SplitHostPort(s.addr)
+	var p float64
+	fmt.Sscan(port, &p)
+	return resource.PropertyMap{
+		"host":     resource.NewStringProperty(host),
+		"port":     resource.NewNumberProperty(p),
+		"user":     resource.NewStringProperty("test"),
+		"password": resource.MakeSecret(resource.NewStringProperty("secret")),
+	}
+}
+
+func (s *testSSHServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		switch newChannel.ChannelType() {
+		case "session":
+			ch, requests, err := newChannel.Accept()
+			if err != nil {
+				continue
+			}
+			go s.session(ch, requests)
+		case "direct-tcpip":
+			var target struct {
+				Host       string
+				Port       uint32
+				OriginHost string
+				OriginPort uint32
+			}
+			if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+				newChannel.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+			upstream, err := net.Dial("tcp", net.JoinHostPort(target.Host, fmt.Sprint(target.Port)))
+			if err != nil {
+				newChannel.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+			ch, requests, err := newChannel.Accept()
+			if err != nil {
+				upstream.Close()
+				continue
+			}
+			go ssh.DiscardRequests(requests)
+			go func() {
+				defer ch.Close()
+				defer upstream.Close()
+				go io.Copy(upstream, ch)
+				io.Copy(ch, upstream)
+			}()
+		default:
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+// session runs the exec request of a session with sh, forwarding signals to it, or serves SFTP.
+func (s *testSSHServer) session(ch ssh.Channel, requests <-chan *ssh.Request) {
+	defer ch.Close()
+	var cmd *exec.Cmd
+	var mu sync.Mutex
+	done := make(chan struct{})
+	for req := range requests {
+		switch req.Type {
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				req.Reply(false, nil)
+				continue
+			}
+			s.mu.Lock()
+			s.lines = append(s.lines, payload.Command)
+			s.mu.Unlock()
+			mu.Lock()
+			cmd = exec.Command("sh", "-c", payload.Command)
+			cmd.Stdin, cmd.Stdout, cmd.Stderr = ch, ch, ch.Stderr()
+			err := cmd.Start()
+			mu.Unlock()
+			req.Reply(err == nil, nil)
+			if err != nil {
+				return
+			}
+			go func() {
+				status := 0
+				if err := cmd.Wait(); err != nil {
+					status = 255
+					if exitError, ok := err.(*exec.ExitError); ok {
+						status = exitError.ExitCode()
+					}
+				}
+				code := make([]byte, 4)
+				binary.BigEndian.PutUint32(code, uint32(status))
+				ch.SendRequest("exit-status", false, code)
+				ch.Close()
+				close(done)
+			}()
+		case "subsystem":
+			var payload struct{ Name string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil || payload.Name != "sftp" {
+				req.Reply(false, nil)
+				continue
+			}
+			req.Reply(true, nil)
+			server, err := sftp.NewServer(ch)
+			if err != nil {
+				return
+			}
+			go func() {
+				server.Serve()
+				server.Close()
+				close(done)
+			}()
+		case "signal":
+			var payload struct{ Signal string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err == nil {
+				mu.Lock()
+				if sig, err := parseStopSignal(payload.Signal); err == nil && cmd != nil && cmd.Process != nil {
+					cmd.Process.Signal(syscall.Signal(sig))
+				}
+				mu.Unlock()
+			}
+		default:
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+		}
+	}
+	<-done
+}
+
+func remoteCommandProps(conn resource.PropertyMap, script string) resource.PropertyMap {
+	return resource.PropertyMap{
+		"connection": resource.NewObjectProperty(conn),
+		"create": resource.NewObjectProperty(resource.PropertyMap{
+			"script":      resource.NewStringProperty(script),
+			"environment": resource.NewObjectProperty(resource.PropertyMap{"GREETING": resource.NewStringProperty("it's me")}),
+			"stdin":       resource.NewStringProperty("from stdin"),
+		}),
+	}
+}
+
+func Test_commandProvider_RemoteCommand(t *testing.T) {
+	keyPEM, clientKey := newTestKey(t)
+	server := newTestSSHServer(t, clientKey.PublicKey())
+	bastion := newTestSSHServer(t, nil)
+	withKey := server.connection()
+	delete(withKey, "password")
+	withKey["privateKey"] = resource.MakeSecret(resource.NewStringProperty(keyPEM))
+	pinned := server.connection()
+	pinned["hostKey"] = resource.NewStringProperty(string(ssh.MarshalAuthorizedKey(server.hostKey)))
+	fingerprint := server.connection()
+	fingerprint["hostKey"] = resource.NewStringProperty(ssh.FingerprintSHA256(server.hostKey))
+	wrongHostKey := server.connection()
+	wrongHostKey["hostKey"] = resource.NewStringProperty(ssh.FingerprintSHA256(bastion.hostKey))
+	wrongPassword := server.connection()
+	wrongPassword["password"] = resource.NewStringProperty("wrong")
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachable := server.connection()
+	unreachable["port"] = resource.NewNumberProperty(float64(closed.Addr().(*net.TCPAddr).Port))
+	unreachable["dialTimeout"] = resource.NewStringProperty("1s")
+	closed.Close()
+	proxied := server.connection()
+	proxied["proxy"] = resource.NewObjectProperty(bastion.connection())
+
+	tests := []struct {
+		name       string
+		conn       resource.PropertyMap
+		script     string
+		wantStdout string
+		wantErr    string
+	}{
+		{name: "Password", conn: server.connection(), script: `echo "$GREETING"; cat; echo; echo "$PULUMI_COMMAND_OP"`, wantStdout: "it's me\nfrom stdin\ncreate\n"},
+		{name: "Private key", conn: withKey, script: "echo key", wantStdout: "key\n"},
+		{name: "Pinned host key", conn: pinned, script: "echo pinned", wantStdout: "pinned\n"},
+		{name: "Pinned fingerprint", conn: fingerprint, script: "echo pinned", wantStdout: "pinned\n"},
+		{name: "Wrong host key", conn: wrongHostKey, script: "echo unreachable", wantErr: "has fingerprint"},
+		{name: "Wrong password", conn: wrongPassword, script: "echo unreachable", wantErr: "unable to authenticate"},
+		{name: "Unreachable", conn: unreachable, script: "echo unreachable", wantErr: "connection refused"},
+		{name: "Bastion", conn: proxied, script: "echo proxied", wantStdout: "proxied\n"},
+		{name: "Exit code", conn: server.connection(), script: "echo failed >&2; exit 3", wantErr: "failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pulumirpc.CreateRequest{Urn: "urn:pulumi:command-test::command-test::command:v1:RemoteCommand::demo"}
+			var err error
+			req.Properties, err = plugin.MarshalProperties(remoteCommandProps(tt.conn, tt.script), plugin.MarshalOptions{KeepSecrets: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			resp, err := p.Create(context.Background(), req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if stdout := resp.GetProperties().GetFields()["stdout"].GetStringValue(); stdout != tt.wantStdout {
+				t.Errorf("Create() stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func Test_connection_hostKeyCallback(t *testing.T) {
+	_, signer := newTestKey(t)
+	key := signer.PublicKey()
+	var warnings []string
+	warn := func(msg string) { warnings = append(warnings, msg) }
+	callback, err := connection{Host: "a.example.com"}.hostKeyCallback(warn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := callback("a.example.com:22", nil, key); err != nil {
+		t.Fatalf("hostKeyCallback() error = %v, want any key to be accepted", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], ssh.FingerprintSHA256(key)) {
+		t.Errorf("hostKeyCallback() warnings = %q, want the fingerprint of the accepted key", warnings)
+	}
+	warnings = nil
+	callback, err = connection{Host: "a.example.com", HostKey: ssh.FingerprintSHA256(key)}.hostKeyCallback(warn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := callback("a.example.com:22", nil, key); err != nil || len(warnings) != 0 {
+		t.Errorf("hostKeyCallback() error = %v, warnings = %q, want the pinned key to be accepted silently", err, warnings)
+	}
+}
+
+func Test_handshake(t *testing.T) {
+	// The listener accepts connections but never answers, like a host whose SSH server hangs.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	config := &ssh.ClientConfig{User: "test", HostKeyCallback: ssh.InsecureIgnoreHostKey()}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		timeout time.Duration
+	}{
+		{name: "Timeout", ctx: context.Background(), timeout: 100 * time.Millisecond},
+		{name: "Cancelled", ctx: cancelled, timeout: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := net.Dial("tcp", l.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			config.Timeout = tt.timeout
+			done := make(chan error, 1)
+			go func() {
+				_, _, _, err := handshake(tt.ctx, conn, l.Addr().String(), config)
+				done <- err
+			}()
+			select {
+			case err := <-done:
+				if err == nil || !strings.Contains(err.Error(), "did not complete") {
+					t.Errorf("handshake() error = %v, want the handshake to be abandoned", err)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatal("handshake() did not return")
+			}
+		})
+	}
+}
+
+func Test_remoteCommandLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		dir      string
+		env      []string
+		want     string
+		wantVars string
+	}{
+		{
+			name: "Plain",
+			args: []string{"ls", "-la", "/tmp"},
+			want: `sh -c 'vars=$(dd bs=1 count=0 2>/dev/null) && eval "$vars" && unset vars && exec "$@"' sh ls -la /tmp`,
+		},
+		{
+			name: "Quoted",
+			args: []string{"echo", "it's", "a b", ""},
+			want: `sh -c 'vars=$(dd bs=1 count=0 2>/dev/null) && eval "$vars" && unset vars && exec "$@"' sh echo 'it'"'"'s' 'a b' ''`,
+		},
+		{
+			name:     "Dir and environment",
+			args:     []string{"make"},
+			dir:      "/srv/my app",
+			env:      []string{"A=1", "B=x y"},
+			want:     `sh -c 'vars=$(dd bs=1 count=26 2>/dev/null) && eval "$vars" && unset vars && cd '"'"'/srv/my app'"'"' && exec "$@"' sh make`,
+			wantVars: "export A=1\nexport 'B=x y'\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, vars := remoteCommandLine(tt.args, tt.dir, tt.env)
+			if got != tt.want {
+				t.Errorf("remoteCommandLine() = %q, want %q", got, tt.want)
+			}
+			if string(vars) != tt.wantVars {
+				t.Errorf("remoteCommandLine() vars = %q, want %q", vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_RemoteCommandCredentials(t *testing.T) {
+	server := newTestSSHServer(t, nil)
+	inputs := resource.PropertyMap{
+		"connection": resource.NewObjectProperty(server.connection()),
+		"create":     resource.NewObjectProperty(resource.PropertyMap{"script": resource.NewStringProperty("true")}),
+		"update": resource.NewObjectProperty(resource.PropertyMap{
+			"script":    resource.NewStringProperty(`printf '%s\n' "$PULUMI_COMMAND_OLD_INPUTS"; cat`),
+			"stdinFrom": resource.NewStringProperty("previousState"),
+			"environment": resource.NewObjectProperty(resource.PropertyMap{
+				"TOKEN": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+			}),
+		}),
+	}
+	olds, err := plugin.MarshalProperties(resource.PropertyMap{
+		"stdout": resource.NewStringProperty(""),
+		"inputs": resource.NewObjectProperty(inputs),
+	}, plugin.MarshalOptions{KeepSecrets: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	news, err := plugin.MarshalProperties(inputs, plugin.MarshalOptions{KeepSecrets: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	resp, err := p.Update(context.Background(), &pulumirpc.UpdateRequest{
+		Urn:  "urn:pulumi:command-test::command-test::" + remoteCommandType + "::demo",
+		Id:   "demo",
+		Olds: olds,
+		News: news,
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	stdout := resp.GetProperties().GetFields()["stdout"].GetStringValue()
+	if !strings.Contains(stdout, `"update":{`) {
+		t.Errorf("Update() stdout = %q, want the old inputs", stdout)
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	for _, secret := range []string{"secret", "hunter2"} {
+		if strings.Contains(stdout, secret) {
+			t.Errorf("the old inputs passed to the command contain %q: %s", secret, stdout)
+		}
+		for _, line := range server.lines {
+			if strings.Contains(line, secret) {
+				t.Errorf("the command line contains %q: %s", secret, line)
+			}
+		}
+	}
+	if len(server.lines) == 0 || strings.Contains(strings.Join(server.lines, "\n"), "PULUMI_COMMAND_OP=") {
+		t.Errorf("the command lines pass variables: %q", server.lines)
+	}
+}
+
+func Test_commandProvider_CheckRemoteCommand(t *testing.T) {
+	conn := resource.NewObjectProperty(resource.PropertyMap{
+		"host":  resource.NewStringProperty("example.com"),
+		"port":  resource.NewNumberProperty(0),
+		"proxy": resource.NewObjectProperty(resource.PropertyMap{"host": resource.NewStringProperty(""), "hostKey": resource.NewStringProperty("ssh-rsa")}),
+	})
+	create := resource.NewObjectProperty(resource.PropertyMap{
+		"script":     resource.NewStringProperty("echo"),
+		"scriptFile": resource.NewBoolProperty(true),
+		"dir":        resource.NewStringProperty("/does/not/exist/locally"),
+	})
+	tests := []struct {
+		name         string
+		typ          string
+		news         resource.PropertyMap
+		wantFailures []string
+	}{
+		{name: "Remote without connection", typ: remoteCommandType, news: resource.PropertyMap{"create": create}, wantFailures: []string{"", "create.scriptFile"}},
+		{name: "Invalid connection", typ: remoteCommandType, news: resource.PropertyMap{"create": create, "connection": conn}, wantFailures: []string{"connection.port", "connection.proxy.host", "connection.proxy.hostKey", "create.scriptFile"}},
+		{name: "Local with connection", typ: commandType, news: resource.PropertyMap{"create": create, "connection": conn}, wantFailures: []string{"connection"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			news, err := plugin.MarshalProperties(tt.news, plugin.MarshalOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			resp, err := p.Check(context.Background(), &pulumirpc.CheckRequest{
+				Urn:  "urn:pulumi:command-test::command-test::" + tt.typ + "::demo",
+				News: news,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, f := range resp.GetFailures() {
+				got = append(got, f.GetProperty())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantFailures, ",") {
+				t.Errorf("Check() failures = %v, want %v", resp.GetFailures(), tt.wantFailures)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_DiffRemoteTarget(t *testing.T) {
+	inputs := func(host, password string, update bool) resource.PropertyMap {
+		props := resource.PropertyMap{
+			"create": resource.NewObjectProperty(resource.PropertyMap{"script": resource.NewStringProperty("echo")}),
+			"connection": resource.NewObjectProperty(resource.PropertyMap{
+				"host":     resource.NewStringProperty(host),
+				"password": resource.NewStringProperty(password),
+			}),
+		}
+		if update {
+			props["update"] = resource.NewObjectProperty(resource.PropertyMap{"script": resource.NewStringProperty("echo")})
+		}
+		return props
+	}
+	tests := []struct {
+		name string
+		news resource.PropertyMap
+		want pulumirpc.DiffResponse_DiffChanges
+	}{
+		{name: "Same connection", news: inputs("a.example.com", "old", false), want: pulumirpc.DiffResponse_DIFF_NONE},
+		{name: "New password", news: inputs("a.example.com", "new", false), want: pulumirpc.DiffResponse_DIFF_SOME},
+		{name: "New host", news: inputs("b.example.com", "old", false), want: pulumirpc.DiffResponse_DIFF_SOME},
+		// The host cannot be reached, so the update fails if it runs the update command.
+		{name: "New password with an update command", news: inputs("a.example.com", "new", true), want: pulumirpc.DiffResponse_DIFF_SOME},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			olds, err := plugin.MarshalProperties(resource.PropertyMap{
+				"stdout": resource.NewStringProperty("hello"),
+				"inputs": resource.NewObjectProperty(inputs("a.example.com", "old", tt.news.HasValue("update"))),
+			}, plugin.MarshalOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			news, err := plugin.MarshalProperties(tt.news, plugin.MarshalOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			resp, err := p.Diff(context.Background(), &pulumirpc.DiffRequest{
+				Urn: "urn:pulumi:command-test::command-test::" + remoteCommandType + "::demo", Olds: olds, News: news,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.GetChanges() != tt.want {
+				t.Errorf("Diff() = %v, want %v", resp, tt.want)
+			}
+			if resp.GetChanges() != pulumirpc.DiffResponse_DIFF_SOME {
+				return
+			}
+			// Without an update command, or with new credentials only, the update keeps the outputs and saves
+			// the new connection.
+			updated, err := p.Update(context.Background(), &pulumirpc.UpdateRequest{
+				Urn: "urn:pulumi:command-test::command-test::" + remoteCommandType + "::demo", Olds: olds, News: news,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			state := mustUnmarshal(t, updated.GetProperties())
+			if state["stdout"].StringValue() != "hello" || !state["inputs"].DeepEquals(resource.NewObjectProperty(tt.news)) {
+				t.Errorf("Update() = %v, want the outputs kept and the inputs %v", state, tt.news)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// lineWriter is an io.Writer that calls emit for every complete line written to it.
+type lineWriter struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	emit func(line string)
+}
+
+func newLineWriter(emit func(line string)) *lineWriter {
+	return &lineWriter{emit: emit}
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := w.buf.Next(i + 1)
+		w.emit(string(bytes.TrimRight(line, "\r\n")))
+	}
+	return len(p), nil
+}
+
+// Flush emits any trailing output that was not terminated by a newline.
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf.Len() > 0 {
+		w.emit(w.buf.String())
+		w.buf.Reset()
+	}
+}
+
+// outputStreams captures the output of a command while forwarding each line to the engine as a status message.
+type outputStreams struct {
+	stdout, stderr bytes.Buffer
+	lines          []*lineWriter
+}
+
+// newOutputStreams returns streams that forward output to host for urn after passing each line
+// through redact. Output is only captured if host is nil or the command does not belong to a resource.
+func newOutputStreams(ctx context.Context, host *provider.HostClient, urn resource.URN, redact func(string) string) *outputStreams {
+	s := &outputStreams{}
+	if host != nil && urn != "" {
+		emit := func(line string) {
+			if err := host.LogStatus(ctx, diag.Info, urn, redact(line)); err != nil {
+				logging.V(9).Infof("failed to forward output of %s: %v", urn, err)
+			}
+		}
+		s.lines = []*lineWriter{newLineWriter(emit), newLineWriter(emit)}
+	}
+	return s
+}
+
+// Stdout returns the writer for the command's stdout.
+func (s *outputStreams) Stdout() io.Writer {
+	if s.lines == nil {
+		return &s.stdout
+	}
+	return io.MultiWriter(&s.stdout, s.lines[0])
+}
+
+// Stderr returns the writer for the command's stderr.
+func (s *outputStreams) Stderr() io.Writer {
+	if s.lines == nil {
+		return &s.stderr
+	}
+	return io.MultiWriter(&s.stderr, s.lines[1])
+}
+
+// Flush forwards any partial lines left once the command has exited.
+func (s *outputStreams) Flush() {
+	for _, w := range s.lines {
+		w.Flush()
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_lineWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   []string
+	}{
+		{
+			name:   "Complete lines",
+			writes: []string{"one\ntwo\n"},
+			want:   []string{"one", "two"},
+		},
+		{
+			name:   "Split writes",
+			writes: []string{"o", "ne\r\ntw", "o\nthree"},
+			want:   []string{"one", "two", "three"},
+		},
+		{
+			name:   "Empty lines",
+			writes: []string{"\n\n"},
+			want:   []string{"", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			w := newLineWriter(func(line string) {
+				got = append(got, line)
+			})
+			for _, s := range tt.writes {
+				if _, err := w.Write([]byte(s)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			w.Flush()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lineWriter emitted %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
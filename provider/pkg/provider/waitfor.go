@@ -0,0 +1,288 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"google.golang.org/grpc/codes"
+)
+
+// waitFor runs a probe command after create and update until it reports that the resource is ready.
+type waitFor struct {
+	Probe cmd `pulumi:"probe"`
+	// Interval is the time between probes and Timeout the time after which waiting fails.
+	Interval string `pulumi:"interval,optional"`
+	Timeout  string `pulumi:"timeout,optional"`
+	// The resource is ready when the probe exits with ExitCode, prints stdout matching StdoutMatch, or
+	// prints JSON whose value at JSONPath is Equals. Without a criterion the probe must exit with 0.
+	ExitCode    *int   `pulumi:"exitCode,optional" structpb:"exitCode"`
+	StdoutMatch string `pulumi:"stdoutMatch,optional" structpb:"stdoutMatch"`
+	JSONPath    string `pulumi:"jsonPath,optional" structpb:"jsonPath"`
+	Equals      string `pulumi:"equals,optional"`
+}
+
+// The defaults of a waitFor block.
+const (
+	defaultWaitInterval = 5 * time.Second
+	defaultWaitTimeout  = 5 * time.Minute
+)
+
+// waitOp is the operation that probe commands run as.
+const waitOp = "waitFor"
+
+// decodeWaitFor decodes the waitFor input of a resource, which is nil when it is not set.
+func decodeWaitFor(props resource.PropertyMap) (*waitFor, error) {
+	v, ok := props["waitFor"]
+	if !ok || v.IsNull() {
+		return nil, nil
+	}
+	var w waitFor
+	if err := decodeProperty(waitOp, v, reflect.ValueOf(&w)); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// durations returns the interval and timeout of w.
+func (w waitFor) durations() (interval, timeout time.Duration, err error) {
+	interval, timeout = defaultWaitInterval, defaultWaitTimeout
+	if w.Interval != "" {
+		if interval, err = time.ParseDuration(w.Interval); err != nil {
+			return 0, 0, errors.Wrap(err, "invalid waitFor.interval")
+		}
+	}
+	if w.Timeout != "" {
+		if timeout, err = time.ParseDuration(w.Timeout); err != nil {
+			return 0, 0, errors.Wrap(err, "invalid waitFor.timeout")
+		}
+	}
+	return interval, timeout, nil
+}
+
+// ready reports whether a probe that exited with code and printed stdout meets the success criterion.
+// The reason describes why it does not.
+func (w waitFor) ready(code int, stdout string) (ok bool, reason string, err error) {
+	switch {
+	case w.StdoutMatch != "":
+		re, err := regexp.Compile(w.StdoutMatch)
+		if err != nil {
+			return false, "", errors.Wrap(err, "invalid waitFor.stdoutMatch")
+		}
+		if !re.MatchString(stdout) {
+			return false, fmt.Sprintf("stdout does not match %q", w.StdoutMatch), nil
+		}
+	case w.JSONPath != "":
+		path, err := parseJSONPath(w.JSONPath)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "invalid waitFor.jsonPath %q", w.JSONPath)
+		}
+		var doc interface{}
+		if err := json.Unmarshal([]byte(stdout), &doc); err != nil {
+			return false, "stdout is not JSON", nil
+		}
+		found, ok := path.Get(resource.NewPropertyValue(doc))
+		if !ok || found.IsNull() {
+			return false, fmt.Sprintf("%s is not set", w.JSONPath), nil
+		}
+		if got := jsonString(found); got != w.Equals {
+			return false, fmt.Sprintf("%s is %s, not %s", w.JSONPath, got, w.Equals), nil
+		}
+	default:
+		want := 0
+		if w.ExitCode != nil {
+			want = *w.ExitCode
+		}
+		if code != want {
+			return false, fmt.Sprintf("exit code %d, not %d", code, want), nil
+		}
+	}
+	return true, "", nil
+}
+
+// jsonString renders a value found in JSON for comparison with waitFor.equals. Strings are compared
+// without quotes and other values in their JSON form.
+func jsonString(v resource.PropertyValue) string {
+	if v.IsString() {
+		return v.StringValue()
+	}
+	b, err := json.Marshal(v.Mappable())
+	if err != nil {
+		return fmt.Sprint(v.Mappable())
+	}
+	return string(b)
+}
+
+// wait runs the probe of w until the resource is ready, the timeout passes or the provider is cancelled.
+// input holds the properties of the resource, which determine the secrets to redact. The wait also ends at
+// deadline, when the operation times out, unless it is zero, and at the deadline of ctx.
+func (p *commandProvider) wait(ctx context.Context, req hasUrn, w waitFor, input resource.PropertyMap, deadline time.Time) error {
+	interval, timeout, err := w.durations()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := p.withCancellation(ctx)
+	defer cancel()
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+	limit := fmt.Sprintf("within %v", timeout)
+	waitDeadline := time.Now().Add(timeout)
+	if !deadline.IsZero() && deadline.Before(waitDeadline) {
+		limit = "before the operation timed out"
+		waitDeadline = deadline
+	}
+	waitCtx, cancelWait := context.WithDeadline(ctx, waitDeadline)
+	defer cancelWait()
+	// The probe is run again at each interval rather than retried.
+	probe := w.Probe
+	probe.Retry = nil
+	// reason describes why the last completed probe did not find the resource ready.
+	var reason string
+	for attempt := 1; ; attempt++ {
+		out, err, code := p.runOnce(waitCtx, req, waitOp, probe, input, time.Now())
+		switch {
+		case out != nil:
+			ok, why, err := w.ready(code, stdoutOf(out))
+			if err != nil {
+				return err
+			}
+			if ok {
+				logging.V(5).Infof("%s: ready after %d probes", waitOp, attempt)
+				return nil
+			}
+			reason = why
+			p.logWait(ctx, req, fmt.Sprintf("waiting for the resource to be ready: %s", reason))
+		case waitCtx.Err() != nil:
+			// The probe was stopped because waiting timed out or was cancelled, which is reported below.
+		default:
+			if _, ok := err.(*timeoutError); !ok {
+				return errors.Wrap(err, "waitFor probe")
+			}
+			reason = err.Error()
+			p.logWait(ctx, req, fmt.Sprintf("waiting for the resource to be ready: %s", reason))
+		}
+		select {
+		case <-time.After(interval):
+		case <-waitCtx.Done():
+		}
+		if ctx.Err() == context.Canceled {
+			return errors.New("waitFor was cancelled")
+		}
+		if waitCtx.Err() != nil {
+			if reason == "" {
+				return errors.Errorf("the resource was not ready %s", limit)
+			}
+			return errors.Errorf("the resource was not ready %s: %s", limit, reason)
+		}
+	}
+}
+
+// logWait reports the progress of waiting to the engine.
+func (p *commandProvider) logWait(ctx context.Context, req hasUrn, msg string) {
+	logging.V(5).Infof("%s: %s", waitOp, msg)
+	if p.host == nil || req.GetUrn() == "" {
+		return
+	}
+	if err := p.host.LogStatus(ctx, diag.Info, resource.URN(req.GetUrn()), msg); err != nil {
+		logging.V(5).Infof("failed to log status: %v", err)
+	}
+}
+
+// waitUntilReady runs the waitFor block of a resource, if it has one, after its create or update
+// command has run. The operation started at started, which with the timeout of req limits the wait.
+func (p *commandProvider) waitUntilReady(ctx context.Context, req hasUrn, props *structpb.Struct, started time.Time) error {
+	input, err := p.prepare(req, waitOp, props, "properties")
+	if err != nil {
+		return err
+	}
+	plain, err := plainProperties(props)
+	if err != nil {
+		return err
+	}
+	w, err := decodeWaitFor(plain)
+	if err != nil || w == nil {
+		return err
+	}
+	return p.wait(ctx, req, *w, input, requestDeadline(req, started))
+}
+
+// initFailed reports that the resource with outputs out was created or updated but is not ready,
+// so that the engine records it and runs its update again on the next deployment.
+func initFailed(id string, out, inputs *structpb.Struct, err error) error {
+	return rpcerror.WithDetails(rpcerror.New(codes.Unknown, err.Error()), &pulumirpc.ErrorResourceInitFailed{
+		Id: id, Properties: out, Inputs: inputs, Reasons: []string{err.Error()},
+	})
+}
+
+// stdoutOf returns the stdout output of a command, which may be marked secret.
+func stdoutOf(out *structpb.Struct) string {
+	return stringOutput(out, "stdout")
+}
+
+// checkWaitFor validates the waitFor block of a resource.
+func (c *checker) checkWaitFor(path string, props resource.PropertyMap) {
+	v := props["waitFor"]
+	if !v.IsObject() {
+		return
+	}
+	w := v.ObjectValue()
+	if probe := w["probe"]; probe.IsObject() {
+		c.checkCmd(propertyPath(path, "probe"), probe.ObjectValue())
+	}
+	c.checkTimeout(propertyPath(path, "interval"), w["interval"])
+	c.checkTimeout(propertyPath(path, "timeout"), w["timeout"])
+	var criteria []string
+	for _, k := range []resource.PropertyKey{"exitCode", "stdoutMatch", "jsonPath"} {
+		if e, ok := w[k]; ok && !e.IsNull() {
+			criteria = append(criteria, string(k))
+		}
+	}
+	if len(criteria) > 1 {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: path,
+			Reason:   fmt.Sprintf("expected at most one of exitCode, stdoutMatch and jsonPath, received %v", criteria),
+		})
+	}
+	if match := w["stdoutMatch"]; match.IsString() {
+		if _, err := regexp.Compile(match.StringValue()); err != nil {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: propertyPath(path, "stdoutMatch"), Reason: err.Error()})
+		}
+	}
+	jsonPath, equals := w["jsonPath"], w["equals"]
+	if jsonPath.IsString() {
+		if _, err := parseJSONPath(jsonPath.StringValue()); err != nil {
+			c.failures = append(c.failures, &pulumirpc.CheckFailure{Property: propertyPath(path, "jsonPath"), Reason: err.Error()})
+		}
+	}
+	if jsonPath.IsString() != equals.IsString() {
+		c.failures = append(c.failures, &pulumirpc.CheckFailure{
+			Property: propertyPath(path, "equals"),
+			Reason:   "jsonPath and equals must be set together",
+		})
+	}
+}
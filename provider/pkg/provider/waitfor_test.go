@@ -0,0 +1,148 @@
+// Copyright 2019, Brandon Kalinowski.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+func Test_waitFor_ready(t *testing.T) {
+	three := 3
+	tests := []struct {
+		name   string
+		w      waitFor
+		code   int
+		stdout string
+		want   bool
+	}{
+		{name: "Default exit code", code: 0, want: true},
+		{name: "Default exit code failed", code: 1, want: false},
+		{name: "Exit code", w: waitFor{ExitCode: &three}, code: 3, want: true},
+		{name: "Exit code differs", w: waitFor{ExitCode: &three}, code: 0, want: false},
+		{name: "Stdout match", w: waitFor{StdoutMatch: `^HTTP/1\.1 200`}, code: 1, stdout: "HTTP/1.1 200 OK\n", want: true},
+		{name: "Stdout mismatch", w: waitFor{StdoutMatch: `^HTTP/1\.1 200`}, stdout: "HTTP/1.1 503\n", want: false},
+		{name: "JSON string", w: waitFor{JSONPath: "$.status.phase", Equals: "Running"}, stdout: `{"status":{"phase":"Running"}}`, want: true},
+		{name: "JSON string differs", w: waitFor{JSONPath: "status.phase", Equals: "Running"}, stdout: `{"status":{"phase":"Pending"}}`, want: false},
+		{name: "JSON boolean", w: waitFor{JSONPath: "items[1].ready", Equals: "true"}, stdout: `{"items":[{},{"ready":true}]}`, want: true},
+		{name: "JSON missing", w: waitFor{JSONPath: "ready", Equals: "true"}, stdout: `{}`, want: false},
+		{name: "Not JSON", w: waitFor{JSONPath: "ready", Equals: "true"}, stdout: "starting", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason, err := tt.w.ready(tt.code, tt.stdout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ready() = %v, want %v", got, tt.want)
+			}
+			if !got && reason == "" {
+				t.Errorf("ready() did not give a reason")
+			}
+		})
+	}
+}
+
+func Test_commandProvider_wait(t *testing.T) {
+	tests := []struct {
+		name    string
+		runs    int
+		timeout string
+		// deadline is when the operation times out, if it has a timeout.
+		deadline time.Duration
+		cancel   bool
+		wantErr  string
+	}{
+		{name: "Ready", runs: 0, timeout: "10s"},
+		{name: "Ready after probes", runs: 3, timeout: "10s"},
+		{name: "Timeout", runs: 1000, timeout: "200ms", wantErr: "not ready within 200ms: exit code 1, not 0"},
+		{name: "Operation timeout", runs: 1000, timeout: "10s", deadline: 200 * time.Millisecond, wantErr: "not ready before the operation timed out: exit code 1, not 0"},
+		{name: "Cancelled", runs: 1000, timeout: "10s", cancel: true, wantErr: "waitFor was cancelled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The probe fails until it has recorded tt.runs runs in the counter file.
+			counter := filepath.Join(t.TempDir(), "runs")
+			w := waitFor{
+				Probe: cmd{
+					Script: `n=$(cat "$COUNTER" 2>/dev/null || echo 0)
+echo $((n + 1)) > "$COUNTER"
+[ "$n" -ge "$RUNS" ]`,
+					Environment: map[string]string{"COUNTER": counter, "RUNS": strconv.Itoa(tt.runs)},
+				},
+				Interval: "10ms",
+				Timeout:  tt.timeout,
+			}
+			p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+			if tt.cancel {
+				time.AfterFunc(100*time.Millisecond, p.canceler.cancel)
+			}
+			req := &pulumirpc.CreateRequest{Urn: "urn:pulumi:command-test::command-test::command:v1:Command::demo"}
+			var deadline time.Time
+			if tt.deadline > 0 {
+				deadline = time.Now().Add(tt.deadline)
+			}
+			err := p.wait(context.Background(), req, w, resource.PropertyMap{}, deadline)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("wait() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("wait() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_commandProvider_CreateWaitFor(t *testing.T) {
+	props := resource.PropertyMap{
+		"create": resource.NewObjectProperty(resource.PropertyMap{
+			"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("true")}),
+		}),
+		"waitFor": resource.NewObjectProperty(resource.PropertyMap{
+			"probe": resource.NewObjectProperty(resource.PropertyMap{
+				"command": resource.NewArrayProperty([]resource.PropertyValue{resource.NewStringProperty("false")}),
+			}),
+			"interval": resource.NewStringProperty("10ms"),
+			"timeout":  resource.NewStringProperty("50ms"),
+		}),
+	}
+	req := &pulumirpc.CreateRequest{Urn: "urn:pulumi:command-test::command-test::command:v1:Command::demo"}
+	var err error
+	req.Properties, err = plugin.MarshalProperties(props, plugin.MarshalOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &commandProvider{canceler: makeCancellationContext(), name: "command", version: "dev"}
+	_, err = p.Create(context.Background(), req)
+	if err == nil {
+		t.Fatal("Create() succeeded, want the waitFor to time out")
+	}
+	// The resource was created, so it must be recorded in the state.
+	if failed := initFailure(t, err); failed.GetId() != defaultID || failed.GetProperties().GetFields()["inputs"] == nil {
+		t.Errorf("Create() initialization failure = %v", failed)
+	}
+}
@@ -41,6 +41,15 @@ func toPrimitive(src *structpb.Value) (reflect.Value, bool) {
 }
 
 func convertValue(src *structpb.Value, dest reflect.Value) error {
+	if dest.Kind() == reflect.Ptr && dest.IsNil() {
+		if _, ok := src.GetKind().(*structpb.Value_NullValue); ok {
+			return nil
+		}
+		if !dest.CanSet() {
+			return fmt.Errorf("cannot assign %T to a nil %s", src.GetKind(), dest.Type())
+		}
+		dest.Set(reflect.New(dest.Type().Elem()))
+	}
 	dst := reflect.Indirect(dest)
 	if v, ok := toPrimitive(src); ok {
 		if !v.Type().AssignableTo(dst.Type()) {
@@ -66,3 +66,22 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertPointers(t *testing.T) {
+	var req *pulumirpc.DiffRequest = &pulumirpc.DiffRequest{}
+	err := jsonpb.UnmarshalString(`{"news":{"enabled":false,"create":{"command":["ls"]}}}`, req)
+	if err != nil {
+		panic("Could not unmarshal json string")
+	}
+	var dst struct {
+		Enabled *bool
+		Create  *cmd
+		Read    *cmd
+	}
+	if err := Convert(req.GetNews(), &dst); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if dst.Enabled == nil || *dst.Enabled || dst.Create == nil || len(dst.Create.Command) != 1 || dst.Read != nil {
+		t.Errorf("Convert() = %+v", dst)
+	}
+}
@@ -35,6 +35,66 @@ namespace Pulumi.Command
         [Output("stderr")]
         public Output<string?> StdErr { get; private set; } = null!;
 
+        /// <summary>
+        /// exit code of the command
+        /// </summary>
+        [Output("exitCode")]
+        public Output<int?> ExitCode { get; private set; } = null!;
+
+        /// <summary>
+        /// The time the command was started, in RFC 3339 format
+        /// </summary>
+        [Output("startedAt")]
+        public Output<string?> StartedAt { get; private set; } = null!;
+
+        /// <summary>
+        /// The time the command exited, in RFC 3339 format
+        /// </summary>
+        [Output("finishedAt")]
+        public Output<string?> FinishedAt { get; private set; } = null!;
+
+        /// <summary>
+        /// How long the command ran, in milliseconds
+        /// </summary>
+        [Output("durationMs")]
+        public Output<int?> DurationMs { get; private set; } = null!;
+
+        /// <summary>
+        /// The number of times the last command ran, including retries
+        /// </summary>
+        [Output("attempts")]
+        public Output<int?> Attempts { get; private set; } = null!;
+
+        /// <summary>
+        /// stdout of the command decoded according to its OutputFormat
+        /// </summary>
+        [Output("parsed")]
+        public Output<object?> Parsed { get; private set; } = null!;
+
+        /// <summary>
+        /// The SHA-256 hash of the script of the last create or update, when it is a script
+        /// </summary>
+        [Output("scriptHash")]
+        public Output<string?> ScriptHash { get; private set; } = null!;
+
+        /// <summary>
+        /// The digest of the image the last command ran in, when it has an Image
+        /// </summary>
+        [Output("imageDigest")]
+        public Output<string?> ImageDigest { get; private set; } = null!;
+
+        /// <summary>
+        /// stdout of the read command during the last refresh
+        /// </summary>
+        [Output("readStdout")]
+        public Output<string?> ReadStdout { get; private set; } = null!;
+
+        /// <summary>
+        /// Whether the output of the read command differed from the expected output during the last refresh
+        /// </summary>
+        [Output("drifted")]
+        public Output<bool?> Drifted { get; private set; } = null!;
+
         /// <summary>
         /// Create a Command resource with the given unique name, arguments, and options.
         /// </summary>
@@ -106,13 +166,61 @@ namespace Pulumi.Command
         [Input("delete")]
         public Input<CommandArgs>? Delete { get; set; }
 
+        /// <summary>
+        /// The output the read command prints when the resource has not drifted. Defaults to the stdout of the last create or update (string)
+        /// </summary>
+        [Input("expected")]
+        public Input<string>? Expected { get; set; }
+
+        /// <summary>
+        /// How the ID of the resource is derived. Defaults to the static ID "id". Changing it replaces the resource
+        /// </summary>
+        [Input("idFrom")]
+        public Input<IdFromArgs>? IdFrom { get; set; }
+
+        /// <summary>
+        /// Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time
+        /// </summary>
+        [Input("waitFor")]
+        public Input<WaitForArgs>? WaitFor { get; set; }
+
+        [Input("secretOutputs")]
+        private InputList<string>? _secretOutputs;
+
+        /// <summary>
+        /// The outputs to mark as secret: any of stdout, stderr and parsed (list)
+        /// </summary>
+        public InputList<string> SecretOutputs
+        {
+            get => _secretOutputs ?? (_secretOutputs = new InputList<string>());
+            set => _secretOutputs = value;
+        }
+
+        [Input("replaceOn")]
+        private InputList<string>? _replaceOn;
+
+        /// <summary>
+        /// The inputs whose changes replace the resource instead of updating it: any of compare, create, read, update, delete and diff (list)
+        /// </summary>
+        public InputList<string> ReplaceOn
+        {
+            get => _replaceOn ?? (_replaceOn = new InputList<string>());
+            set => _replaceOn = value;
+        }
+
+        /// <summary>
+        /// Whether the resource is deleted before its replacement is created. Defaults to true (bool)
+        /// </summary>
+        [Input("deleteBeforeReplace")]
+        public Input<bool>? DeleteBeforeReplace { get; set; }
+
         public sealed class CommandArgs : Pulumi.ResourceArgs
         {
           [Input("command")]
           private InputList<string>? _command;
 
           /// <summary>
-          /// Specify the command to run as an array of arguments (list)
+          /// Specify the command to run as an array of arguments. Set either Command or Script (list)
           /// </summary>
           public InputList<string> Command
           {
@@ -120,6 +228,24 @@ namespace Pulumi.Command
               set => _command = value;
           }
 
+          /// <summary>
+          /// A script to run with Interpreter instead of Command. POSIX shell scripts run with set -euo pipefail (string)
+          /// </summary>
+          [Input("script")]
+          public Input<string>? Script { get; set; }
+
+          /// <summary>
+          /// The interpreter of Script: sh (the default), bash, python3 or pwsh (string)
+          /// </summary>
+          [Input("interpreter")]
+          public Input<string>? Interpreter { get; set; }
+
+          /// <summary>
+          /// Run Script from a private temporary file instead of passing it as an argument. A script starting with #! runs with the interpreter its shebang names (bool)
+          /// </summary>
+          [Input("scriptFile")]
+          public Input<bool>? ScriptFile { get; set; }
+
           /// <summary>
           /// Pass the stdin to a command (string)
           /// </summary>
@@ -137,6 +263,300 @@ namespace Pulumi.Command
               get => _environment ?? (_environment = new InputMap<object>());
               set => _environment = value;
           }
+
+          /// <summary>
+          /// The working directory to run the command in. Relative paths are resolved against the Pulumi project root (string)
+          /// </summary>
+          [Input("dir")]
+          public Input<string>? Dir { get; set; }
+
+          /// <summary>
+          /// Controls which variables of the provider's environment are passed to the command: all (the default), allowlist or none (string)
+          /// </summary>
+          [Input("inheritEnv")]
+          public Input<string>? InheritEnv { get; set; }
+
+          [Input("envAllowlist")]
+          private InputList<string>? _envAllowlist;
+
+          /// <summary>
+          /// The names of the variables to inherit when InheritEnv is allowlist (list)
+          /// </summary>
+          public InputList<string> EnvAllowlist
+          {
+              get => _envAllowlist ?? (_envAllowlist = new InputList<string>());
+              set => _envAllowlist = value;
+          }
+
+          [Input("unsetEnv")]
+          private InputList<string>? _unsetEnv;
+
+          /// <summary>
+          /// The names of inherited variables to remove from the command's environment (list)
+          /// </summary>
+          public InputList<string> UnsetEnv
+          {
+              get => _unsetEnv ?? (_unsetEnv = new InputList<string>());
+              set => _unsetEnv = value;
+          }
+
+          [Input("allowedExitCodes")]
+          private InputList<int>? _allowedExitCodes;
+
+          /// <summary>
+          /// Non-zero exit codes that are treated as success (list)
+          /// </summary>
+          public InputList<int> AllowedExitCodes
+          {
+              get => _allowedExitCodes ?? (_allowedExitCodes = new InputList<int>());
+              set => _allowedExitCodes = value;
+          }
+
+          /// <summary>
+          /// Decode stdout into the Parsed output: text, json, yaml, dotenv or lines (string)
+          /// </summary>
+          [Input("outputFormat")]
+          public Input<string>? OutputFormat { get; set; }
+
+          /// <summary>
+          /// Set to previousState to pass a JSON document of the previous stdout, stderr, parsed output and inputs of the resource to stdin instead of Stdin. Not available to the create command (string)
+          /// </summary>
+          [Input("stdinFrom")]
+          public Input<string>? StdinFrom { get; set; }
+
+          /// <summary>
+          /// The maximum time the command may run, as a duration such as 30s or 5m (string)
+          /// </summary>
+          [Input("timeout")]
+          public Input<string>? Timeout { get; set; }
+
+          /// <summary>
+          /// The signal sent to the command's process group when it times out or the deployment is cancelled. Defaults to SIGTERM (string)
+          /// </summary>
+          [Input("stopSignal")]
+          public Input<string>? StopSignal { get; set; }
+
+          /// <summary>
+          /// How long the command is given to exit after StopSignal before it is killed, as a duration. Defaults to 10s (string)
+          /// </summary>
+          [Input("stopGracePeriod")]
+          public Input<string>? StopGracePeriod { get; set; }
+
+          /// <summary>
+          /// Retry the command when it fails. Timeouts and cancellation are not retried
+          /// </summary>
+          [Input("retry")]
+          public Input<RetryArgs>? Retry { get; set; }
+
+          /// <summary>
+          /// Run the command in a new container of this image through the Docker Engine API at DOCKER_HOST. Dir is a path in the container
+          /// </summary>
+          [Input("image")]
+          public Input<ImageArgs>? Image { get; set; }
+
+          /// <summary>
+          /// Run the command in a sandbox on Linux, with a read-only file system except for a scratch directory in TMPDIR, no network, no new privileges and resource limits
+          /// </summary>
+          [Input("sandbox")]
+          public Input<SandboxArgs>? Sandbox { get; set; }
+        }
+
+        public sealed class RetryArgs : Pulumi.ResourceArgs
+        {
+          /// <summary>
+          /// The maximum number of times the command runs, including the first. Defaults to 3 (int)
+          /// </summary>
+          [Input("attempts")]
+          public Input<int>? Attempts { get; set; }
+
+          /// <summary>
+          /// How long to wait before the first retry, as a duration. Defaults to 1s (string)
+          /// </summary>
+          [Input("initialDelay")]
+          public Input<string>? InitialDelay { get; set; }
+
+          /// <summary>
+          /// The longest wait between attempts, as a duration. Defaults to 30s (string)
+          /// </summary>
+          [Input("maxDelay")]
+          public Input<string>? MaxDelay { get; set; }
+
+          /// <summary>
+          /// The factor each delay is multiplied by. Defaults to 2 (double)
+          /// </summary>
+          [Input("backoffFactor")]
+          public Input<double>? BackoffFactor { get; set; }
+
+          [Input("retryOnExitCodes")]
+          private InputList<int>? _retryOnExitCodes;
+
+          /// <summary>
+          /// Only retry when the command exits with one of these codes. Defaults to any failing exit code (list)
+          /// </summary>
+          public InputList<int> RetryOnExitCodes
+          {
+              get => _retryOnExitCodes ?? (_retryOnExitCodes = new InputList<int>());
+              set => _retryOnExitCodes = value;
+          }
+
+          /// <summary>
+          /// Only retry when stderr matches this regular expression (string)
+          /// </summary>
+          [Input("retryOnStderrMatch")]
+          public Input<string>? RetryOnStderrMatch { get; set; }
+        }
+
+        public sealed class ImageArgs : Pulumi.ResourceArgs
+        {
+          /// <summary>
+          /// The image reference, such as alpine:3.14 or alpine@sha256:... A new digest of the image in its registry updates the resource (string)
+          /// </summary>
+          [Input("name", required: true)]
+          public Input<string> Name { get; set; } = null!;
+
+          [Input("mounts")]
+          private InputList<MountArgs>? _mounts;
+
+          /// <summary>
+          /// Paths of the machine running the provider to bind into the container (list)
+          /// </summary>
+          public InputList<MountArgs> Mounts
+          {
+              get => _mounts ?? (_mounts = new InputList<MountArgs>());
+              set => _mounts = value;
+          }
+
+          /// <summary>
+          /// The network of the container, such as none, host or the name of a network (string)
+          /// </summary>
+          [Input("networkMode")]
+          public Input<string>? NetworkMode { get; set; }
+
+          /// <summary>
+          /// The user the command runs as, such as nobody or 1000:1000. Defaults to the user of the image (string)
+          /// </summary>
+          [Input("user")]
+          public Input<string>? User { get; set; }
+        }
+
+        public sealed class MountArgs : Pulumi.ResourceArgs
+        {
+          /// <summary>
+          /// The path on the machine running the provider. Relative paths are resolved against the Pulumi project root (string)
+          /// </summary>
+          [Input("source", required: true)]
+          public Input<string> Source { get; set; } = null!;
+
+          /// <summary>
+          /// The absolute path in the container (string)
+          /// </summary>
+          [Input("target", required: true)]
+          public Input<string> Target { get; set; } = null!;
+
+          /// <summary>
+          /// Whether the container may only read the mount (bool)
+          /// </summary>
+          [Input("readOnly")]
+          public Input<bool>? ReadOnly { get; set; }
+        }
+
+        public sealed class SandboxArgs : Pulumi.ResourceArgs
+        {
+          /// <summary>
+          /// The CPU time each process may use, in seconds, after which it is killed (int)
+          /// </summary>
+          [Input("cpuSeconds")]
+          public Input<int>? CpuSeconds { get; set; }
+
+          /// <summary>
+          /// The size of the address space of each process, in mebibytes (int)
+          /// </summary>
+          [Input("memoryMB")]
+          public Input<int>? MemoryMB { get; set; }
+
+          /// <summary>
+          /// The number of files each process may have open (int)
+          /// </summary>
+          [Input("openFiles")]
+          public Input<int>? OpenFiles { get; set; }
+
+          /// <summary>
+          /// The number of processes the user of the command may run, including those outside of the sandbox (int)
+          /// </summary>
+          [Input("maxProcesses")]
+          public Input<int>? MaxProcesses { get; set; }
+
+          /// <summary>
+          /// Keep the network of the provider instead of running the command in an empty network namespace (bool)
+          /// </summary>
+          [Input("network")]
+          public Input<bool>? Network { get; set; }
+        }
+
+        public sealed class IdFromArgs : Pulumi.ResourceArgs
+        {
+          /// <summary>
+          /// A static ID (string)
+          /// </summary>
+          [Input("value")]
+          public Input<string>? Value { get; set; }
+
+          /// <summary>
+          /// Use the stdout of the create command, without surrounding whitespace (bool)
+          /// </summary>
+          [Input("stdout")]
+          public Input<bool>? Stdout { get; set; }
+
+          /// <summary>
+          /// The path of a value in the parsed output of the create command, such as items[0].id. Requires OutputFormat (string)
+          /// </summary>
+          [Input("path")]
+          public Input<string>? Path { get; set; }
+        }
+
+        public sealed class WaitForArgs : Pulumi.ResourceArgs
+        {
+          /// <summary>
+          /// The command that checks whether the resource is ready
+          /// </summary>
+          [Input("probe", required: true)]
+          public Input<CommandArgs> Probe { get; set; } = null!;
+
+          /// <summary>
+          /// The time between probes, as a duration. Defaults to 5s (string)
+          /// </summary>
+          [Input("interval")]
+          public Input<string>? Interval { get; set; }
+
+          /// <summary>
+          /// How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to 5m (string)
+          /// </summary>
+          [Input("timeout")]
+          public Input<string>? Timeout { get; set; }
+
+          /// <summary>
+          /// The exit code of the probe when the resource is ready. Defaults to 0 when no other criterion is set (int)
+          /// </summary>
+          [Input("exitCode")]
+          public Input<int>? ExitCode { get; set; }
+
+          /// <summary>
+          /// A regular expression that the stdout of the probe matches when the resource is ready (string)
+          /// </summary>
+          [Input("stdoutMatch")]
+          public Input<string>? StdoutMatch { get; set; }
+
+          /// <summary>
+          /// The path of a value in the JSON stdout of the probe, such as status.phase. Requires Equals (string)
+          /// </summary>
+          [Input("jsonPath")]
+          public Input<string>? JsonPath { get; set; }
+
+          /// <summary>
+          /// The value at JsonPath when the resource is ready. Values other than strings are compared in their JSON form (string)
+          /// </summary>
+          [Input("equals")]
+          public Input<string>? Equals { get; set; }
         }
   }
 }
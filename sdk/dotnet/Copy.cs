@@ -0,0 +1,203 @@
+// Pulumi Command Provider .NET SDK
+// Copyright 2020, Mitchell Maler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Command
+{
+  /// <summary>
+  /// Upload a file to a remote host over SFTP.
+  ///
+  /// The file is written atomically with its mode and compared by the SHA-256 hash of its content, so it is only uploaded again when its content changes.
+  /// A refresh detects changes made on the host and the next update uploads the file again. Deleting the resource removes the remote file.
+  /// </summary>
+  public partial class CopyFile : Pulumi.CustomResource
+  {
+        /// <summary>
+        /// The local file that is uploaded
+        /// </summary>
+        [Output("source")]
+        public Output<string?> Source { get; private set; } = null!;
+
+        /// <summary>
+        /// The path on the host
+        /// </summary>
+        [Output("remotePath")]
+        public Output<string> RemotePath { get; private set; } = null!;
+
+        /// <summary>
+        /// The permission bits of the remote file in octal
+        /// </summary>
+        [Output("mode")]
+        public Output<string> Mode { get; private set; } = null!;
+
+        /// <summary>
+        /// The SHA-256 hash of the content of the file
+        /// </summary>
+        [Output("sha256")]
+        public Output<string> Sha256 { get; private set; } = null!;
+
+        /// <summary>
+        /// The size of the file in bytes
+        /// </summary>
+        [Output("size")]
+        public Output<int> Size { get; private set; } = null!;
+
+        /// <summary>
+        /// Create a CopyFile resource with the given unique name, arguments, and options.
+        /// </summary>
+        ///
+        /// <param name="name">The unique name of the resource</param>
+        /// <param name="args">The arguments used to populate this resource's properties</param>
+        /// <param name="options">A bag of options that control this resource's behavior</param>
+        public CopyFile(string name, CopyFileArgs args, CustomResourceOptions? options = null)
+            : base("command:v1:CopyFile", name, args ?? throw new ArgumentNullException(nameof(args)), MakeResourceOptions(options, ""))
+        {
+        }
+
+        private static CustomResourceOptions MakeResourceOptions(CustomResourceOptions? options, Input<string>? id)
+        {
+            var defaultOptions = new CustomResourceOptions
+            {
+                Version = Utilities.Version,
+            };
+            var merged = CustomResourceOptions.Merge(defaultOptions, options);
+            // Override the ID if one was specified for consistency with other language SDKs.
+            merged.Id = id ?? merged.Id;
+            return merged;
+        }
+  }
+
+  public sealed class CopyFileArgs : Pulumi.ResourceArgs
+  {
+        /// <summary>
+        /// The host to copy to
+        /// </summary>
+        [Input("connection", required: true)]
+        public Input<RemoteCommandSet.ConnectionArgs> Connection { get; set; } = null!;
+
+        /// <summary>
+        /// The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of Source, Content and ContentBase64 (string)
+        /// </summary>
+        [Input("source")]
+        public Input<string>? Source { get; set; }
+
+        /// <summary>
+        /// The content of the file (string)
+        /// </summary>
+        [Input("content")]
+        public Input<string>? Content { get; set; }
+
+        /// <summary>
+        /// The base64-encoded content of a binary file (string)
+        /// </summary>
+        [Input("contentBase64")]
+        public Input<string>? ContentBase64 { get; set; }
+
+        /// <summary>
+        /// The path on the host. Missing parent directories are created. Changing it replaces the copy (string)
+        /// </summary>
+        [Input("remotePath", required: true)]
+        public Input<string> RemotePath { get; set; } = null!;
+
+        /// <summary>
+        /// The permission bits of the remote file in octal, such as 0600. Defaults to the mode of Source, or 0644 (string)
+        /// </summary>
+        [Input("mode")]
+        public Input<string>? Mode { get; set; }
+  }
+
+  /// <summary>
+  /// Upload a directory tree to a remote host over SFTP.
+  ///
+  /// Files keep their mode bits. Each file is compared by the SHA-256 hash of its content, so an update only uploads
+  /// the files that changed and removes those that were removed locally. Deleting the resource removes the remote directory.
+  /// </summary>
+  public partial class CopyDirectory : Pulumi.CustomResource
+  {
+        /// <summary>
+        /// The local directory that is uploaded
+        /// </summary>
+        [Output("source")]
+        public Output<string> Source { get; private set; } = null!;
+
+        /// <summary>
+        /// The path of the directory on the host
+        /// </summary>
+        [Output("remotePath")]
+        public Output<string> RemotePath { get; private set; } = null!;
+
+        /// <summary>
+        /// The SHA-256 hash of the tree, covering the paths, modes and content of its files and directories
+        /// </summary>
+        [Output("sha256")]
+        public Output<string> Sha256 { get; private set; } = null!;
+
+        /// <summary>
+        /// The mode and SHA-256 hash of each file and directory of the tree, by its path relative to the directory
+        /// </summary>
+        [Output("files")]
+        public Output<ImmutableDictionary<string, object>> Files { get; private set; } = null!;
+
+        /// <summary>
+        /// Create a CopyDirectory resource with the given unique name, arguments, and options.
+        /// </summary>
+        ///
+        /// <param name="name">The unique name of the resource</param>
+        /// <param name="args">The arguments used to populate this resource's properties</param>
+        /// <param name="options">A bag of options that control this resource's behavior</param>
+        public CopyDirectory(string name, CopyDirectoryArgs args, CustomResourceOptions? options = null)
+            : base("command:v1:CopyDirectory", name, args ?? throw new ArgumentNullException(nameof(args)), MakeResourceOptions(options, ""))
+        {
+        }
+
+        private static CustomResourceOptions MakeResourceOptions(CustomResourceOptions? options, Input<string>? id)
+        {
+            var defaultOptions = new CustomResourceOptions
+            {
+                Version = Utilities.Version,
+            };
+            var merged = CustomResourceOptions.Merge(defaultOptions, options);
+            // Override the ID if one was specified for consistency with other language SDKs.
+            merged.Id = id ?? merged.Id;
+            return merged;
+        }
+  }
+
+  public sealed class CopyDirectoryArgs : Pulumi.ResourceArgs
+  {
+        /// <summary>
+        /// The host to copy to
+        /// </summary>
+        [Input("connection", required: true)]
+        public Input<RemoteCommandSet.ConnectionArgs> Connection { get; set; } = null!;
+
+        /// <summary>
+        /// The local directory to upload. Relative paths are resolved against the Pulumi project root (string)
+        /// </summary>
+        [Input("source", required: true)]
+        public Input<string> Source { get; set; } = null!;
+
+        /// <summary>
+        /// The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy (string)
+        /// </summary>
+        [Input("remotePath", required: true)]
+        public Input<string> RemotePath { get; set; } = null!;
+  }
+}
@@ -0,0 +1,132 @@
+// Pulumi Command Provider .NET SDK
+// Copyright 2020, Mitchell Maler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Command
+{
+  /// <summary>
+  /// A file on the machine running Pulumi.
+  ///
+  /// The file is written atomically and compared by the SHA-256 hash of its content, so it is only rewritten when its content, mode or owner change.
+  /// A refresh detects changes made outside of Pulumi and the next update restores the file.
+  /// The ID of the resource is its path, which can be used to import an existing file.
+  /// </summary>
+  public partial class LocalFile : Pulumi.CustomResource
+  {
+        /// <summary>
+        /// The path of the file
+        /// </summary>
+        [Output("path")]
+        public Output<string> Path { get; private set; } = null!;
+
+        /// <summary>
+        /// The content of the file
+        /// </summary>
+        [Output("content")]
+        public Output<string?> Content { get; private set; } = null!;
+
+        /// <summary>
+        /// The base64-encoded content of a binary file
+        /// </summary>
+        [Output("contentBase64")]
+        public Output<string?> ContentBase64 { get; private set; } = null!;
+
+        /// <summary>
+        /// The permission bits of the file in octal
+        /// </summary>
+        [Output("mode")]
+        public Output<string> Mode { get; private set; } = null!;
+
+        /// <summary>
+        /// The user that owns the file, optionally followed by a colon and its group
+        /// </summary>
+        [Output("owner")]
+        public Output<string?> Owner { get; private set; } = null!;
+
+        /// <summary>
+        /// The SHA-256 hash of the content of the file
+        /// </summary>
+        [Output("sha256")]
+        public Output<string> Sha256 { get; private set; } = null!;
+
+        /// <summary>
+        /// The size of the file in bytes
+        /// </summary>
+        [Output("size")]
+        public Output<int> Size { get; private set; } = null!;
+
+        /// <summary>
+        /// Create a LocalFile resource with the given unique name, arguments, and options.
+        /// </summary>
+        ///
+        /// <param name="name">The unique name of the resource</param>
+        /// <param name="args">The arguments used to populate this resource's properties</param>
+        /// <param name="options">A bag of options that control this resource's behavior</param>
+        public LocalFile(string name, LocalFileArgs args, CustomResourceOptions? options = null)
+            : base("command:v1:LocalFile", name, args ?? throw new ArgumentNullException(nameof(args)), MakeResourceOptions(options, ""))
+        {
+        }
+
+        private static CustomResourceOptions MakeResourceOptions(CustomResourceOptions? options, Input<string>? id)
+        {
+            var defaultOptions = new CustomResourceOptions
+            {
+                Version = Utilities.Version,
+            };
+            var merged = CustomResourceOptions.Merge(defaultOptions, options);
+            // Override the ID if one was specified for consistency with other language SDKs.
+            merged.Id = id ?? merged.Id;
+            return merged;
+        }
+  }
+
+  public sealed class LocalFileArgs : Pulumi.ResourceArgs
+  {
+        /// <summary>
+        /// The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file (string)
+        /// </summary>
+        [Input("path", required: true)]
+        public Input<string> Path { get; set; } = null!;
+
+        /// <summary>
+        /// The content of the file. Set either Content or ContentBase64 (string)
+        /// </summary>
+        [Input("content")]
+        public Input<string>? Content { get; set; }
+
+        /// <summary>
+        /// The base64-encoded content of a binary file (string)
+        /// </summary>
+        [Input("contentBase64")]
+        public Input<string>? ContentBase64 { get; set; }
+
+        /// <summary>
+        /// The permission bits of the file in octal, such as 0600. Defaults to 0644 (string)
+        /// </summary>
+        [Input("mode")]
+        public Input<string>? Mode { get; set; }
+
+        /// <summary>
+        /// The user that owns the file, optionally followed by a colon and its group, such as www-data:adm (string)
+        /// </summary>
+        [Input("owner")]
+        public Input<string>? Owner { get; set; }
+  }
+}
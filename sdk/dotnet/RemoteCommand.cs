@@ -0,0 +1,284 @@
+// Pulumi Command Provider .NET SDK
+// Copyright 2020, Mitchell Maler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Command
+{
+  public partial class RemoteCommand : Pulumi.CustomResource
+  {
+        /// <summary>
+        /// stdout of the command
+        /// </summary>
+        [Output("stdout")]
+        public Output<string?> StdOut { get; private set; } = null!;
+
+        /// <summary>
+        /// stderr of the command
+        /// </summary>
+        [Output("stderr")]
+        public Output<string?> StdErr { get; private set; } = null!;
+
+        /// <summary>
+        /// exit code of the command
+        /// </summary>
+        [Output("exitCode")]
+        public Output<int?> ExitCode { get; private set; } = null!;
+
+        /// <summary>
+        /// The time the command was started, in RFC 3339 format
+        /// </summary>
+        [Output("startedAt")]
+        public Output<string?> StartedAt { get; private set; } = null!;
+
+        /// <summary>
+        /// The time the command exited, in RFC 3339 format
+        /// </summary>
+        [Output("finishedAt")]
+        public Output<string?> FinishedAt { get; private set; } = null!;
+
+        /// <summary>
+        /// How long the command ran, in milliseconds
+        /// </summary>
+        [Output("durationMs")]
+        public Output<int?> DurationMs { get; private set; } = null!;
+
+        /// <summary>
+        /// The number of times the last command ran, including retries
+        /// </summary>
+        [Output("attempts")]
+        public Output<int?> Attempts { get; private set; } = null!;
+
+        /// <summary>
+        /// stdout of the command decoded according to its OutputFormat
+        /// </summary>
+        [Output("parsed")]
+        public Output<object?> Parsed { get; private set; } = null!;
+
+        /// <summary>
+        /// The SHA-256 hash of the script of the last create or update, when it is a script
+        /// </summary>
+        [Output("scriptHash")]
+        public Output<string?> ScriptHash { get; private set; } = null!;
+
+        /// <summary>
+        /// stdout of the read command during the last refresh
+        /// </summary>
+        [Output("readStdout")]
+        public Output<string?> ReadStdout { get; private set; } = null!;
+
+        /// <summary>
+        /// Whether the output of the read command differed from the expected output during the last refresh
+        /// </summary>
+        [Output("drifted")]
+        public Output<bool?> Drifted { get; private set; } = null!;
+
+        /// <summary>
+        /// Create a RemoteCommand resource with the given unique name, arguments, and options.
+        /// </summary>
+        ///
+        /// <param name="name">The unique name of the resource</param>
+        /// <param name="args">The arguments used to populate this resource's properties</param>
+        /// <param name="options">A bag of options that control this resource's behavior</param>
+        public RemoteCommand(string name, RemoteCommandSet args, CustomResourceOptions? options = null)
+            : base("command:v1:RemoteCommand", name, args ?? ResourceArgs.Empty, MakeResourceOptions(options, ""))
+        {
+
+          if (args == null){
+            throw new ArgumentNullException(nameof(args));
+          }
+
+          if (args.Connection == null){
+            throw new ArgumentNullException(nameof(args.Connection));
+          }
+
+          if (args.Create == null){
+            throw new ArgumentNullException(nameof(args.Create));
+          }
+        }
+
+        private static CustomResourceOptions MakeResourceOptions(CustomResourceOptions? options, Input<string>? id)
+        {
+            var defaultOptions = new CustomResourceOptions
+            {
+                Version = Utilities.Version,
+            };
+            var merged = CustomResourceOptions.Merge(defaultOptions, options);
+            // Override the ID if one was specified for consistency with other language SDKs.
+            merged.Id = id ?? merged.Id;
+            return merged;
+        }
+  }
+
+  public sealed class RemoteCommandSet : Pulumi.ResourceArgs
+  {
+        /// <summary>
+        /// The host the commands run on
+        /// </summary>
+        [Input("connection", required: true)]
+        public Input<ConnectionArgs> Connection { get; set; } = null!;
+
+        /// <summary>
+        /// diff
+        /// </summary>
+        [Input("diff")]
+        public Input<CommandSet.CommandArgs>? Diff { get; set; }
+
+        /// <summary>
+        /// compare
+        /// </summary>
+        [Input("compare")]
+        public Input<CommandSet.CommandArgs>? Compare { get; set; }
+
+        /// <summary>
+        /// create
+        /// </summary>
+        [Input("create")]
+        public Input<CommandSet.CommandArgs>? Create { get; set; }
+
+        /// <summary>
+        /// read
+        /// </summary>
+        [Input("read")]
+        public Input<CommandSet.CommandArgs>? Read { get; set; }
+
+        /// <summary>
+        /// update
+        /// </summary>
+        [Input("update")]
+        public Input<CommandSet.CommandArgs>? Update { get; set; }
+
+        /// <summary>
+        /// delete
+        /// </summary>
+        [Input("delete")]
+        public Input<CommandSet.CommandArgs>? Delete { get; set; }
+
+        /// <summary>
+        /// The output the read command prints when the resource has not drifted. Defaults to the stdout of the last create or update (string)
+        /// </summary>
+        [Input("expected")]
+        public Input<string>? Expected { get; set; }
+
+        /// <summary>
+        /// How the ID of the resource is derived. Defaults to the static ID "id". Changing it replaces the resource
+        /// </summary>
+        [Input("idFrom")]
+        public Input<CommandSet.IdFromArgs>? IdFrom { get; set; }
+
+        /// <summary>
+        /// Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time
+        /// </summary>
+        [Input("waitFor")]
+        public Input<CommandSet.WaitForArgs>? WaitFor { get; set; }
+
+        [Input("secretOutputs")]
+        private InputList<string>? _secretOutputs;
+
+        /// <summary>
+        /// The outputs to mark as secret: any of stdout, stderr and parsed (list)
+        /// </summary>
+        public InputList<string> SecretOutputs
+        {
+            get => _secretOutputs ?? (_secretOutputs = new InputList<string>());
+            set => _secretOutputs = value;
+        }
+
+        [Input("replaceOn")]
+        private InputList<string>? _replaceOn;
+
+        /// <summary>
+        /// The inputs whose changes replace the resource instead of updating it: any of compare, create, read, update, delete, diff and connection (list)
+        /// </summary>
+        public InputList<string> ReplaceOn
+        {
+            get => _replaceOn ?? (_replaceOn = new InputList<string>());
+            set => _replaceOn = value;
+        }
+
+        /// <summary>
+        /// Whether the resource is deleted before its replacement is created. Defaults to true (bool)
+        /// </summary>
+        [Input("deleteBeforeReplace")]
+        public Input<bool>? DeleteBeforeReplace { get; set; }
+
+        public sealed class ConnectionArgs : Pulumi.ResourceArgs
+        {
+          /// <summary>
+          /// The address of the host (string)
+          /// </summary>
+          [Input("host", required: true)]
+          public Input<string> Host { get; set; } = null!;
+
+          /// <summary>
+          /// The port of the SSH server. Defaults to 22 (int)
+          /// </summary>
+          [Input("port")]
+          public Input<int>? Port { get; set; }
+
+          /// <summary>
+          /// The user to log in as. Defaults to root (string)
+          /// </summary>
+          [Input("user")]
+          public Input<string>? User { get; set; }
+
+          /// <summary>
+          /// The password of the user (string)
+          /// </summary>
+          [Input("password")]
+          public Input<string>? Password { get; set; }
+
+          /// <summary>
+          /// The private key of the user in PEM format (string)
+          /// </summary>
+          [Input("privateKey")]
+          public Input<string>? PrivateKey { get; set; }
+
+          /// <summary>
+          /// The password of an encrypted PrivateKey (string)
+          /// </summary>
+          [Input("privateKeyPassword")]
+          public Input<string>? PrivateKeyPassword { get; set; }
+
+          /// <summary>
+          /// The path of the socket of an SSH agent that holds the keys of the user (string)
+          /// </summary>
+          [Input("agentSocketPath")]
+          public Input<string>? AgentSocketPath { get; set; }
+
+          /// <summary>
+          /// Pins the key of the host, as a public key in authorized_keys format or as a SHA256: fingerprint. Without it, any host key is accepted (string)
+          /// </summary>
+          [Input("hostKey")]
+          public Input<string>? HostKey { get; set; }
+
+          /// <summary>
+          /// How long to keep trying to connect to a host that is not reachable yet, as a duration. Defaults to 2m (string)
+          /// </summary>
+          [Input("dialTimeout")]
+          public Input<string>? DialTimeout { get; set; }
+
+          /// <summary>
+          /// A bastion host through which the host is reached
+          /// </summary>
+          [Input("proxy")]
+          public Input<ConnectionArgs>? Proxy { get; set; }
+        }
+  }
+}
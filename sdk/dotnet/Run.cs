@@ -0,0 +1,365 @@
+// Pulumi Command Provider .NET SDK
+// Copyright 2020, Mitchell Maler.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Command
+{
+  public static class Run
+  {
+        /// <summary>
+        /// Run a command and return its output. Use this to look up data without creating a resource.
+        /// The command runs whenever the program runs, including during previews, so it should not have side effects.
+        /// </summary>
+        public static Task<RunResult> InvokeAsync(RunArgs args, InvokeOptions? options = null)
+            => Pulumi.Deployment.Instance.InvokeAsync<RunResult>("command:v1:run", args ?? new RunArgs(), options.WithVersion());
+  }
+
+  public sealed class RunArgs : Pulumi.InvokeArgs
+  {
+        [Input("command")]
+        private List<string>? _command;
+
+        /// <summary>
+        /// Specify the command to run as an array of arguments. Set either Command or Script (list)
+        /// </summary>
+        public List<string> Command
+        {
+            get => _command ?? (_command = new List<string>());
+            set => _command = value;
+        }
+
+        /// <summary>
+        /// A script to run with Interpreter instead of Command. POSIX shell scripts run with set -euo pipefail (string)
+        /// </summary>
+        [Input("script")]
+        public string? Script { get; set; }
+
+        /// <summary>
+        /// The interpreter of Script: sh (the default), bash, python3 or pwsh (string)
+        /// </summary>
+        [Input("interpreter")]
+        public string? Interpreter { get; set; }
+
+        /// <summary>
+        /// Run Script from a private temporary file instead of passing it as an argument. A script starting with #! runs with the interpreter its shebang names (bool)
+        /// </summary>
+        [Input("scriptFile")]
+        public bool? ScriptFile { get; set; }
+
+        /// <summary>
+        /// Pass the stdin to a command (string)
+        /// </summary>
+        [Input("stdin")]
+        public string? Stdin { get; set; }
+
+        [Input("environment")]
+        private Dictionary<string, string>? _environment;
+
+        /// <summary>
+        /// Set environment variables for the running command (map)
+        /// </summary>
+        public Dictionary<string, string> Environment
+        {
+            get => _environment ?? (_environment = new Dictionary<string, string>());
+            set => _environment = value;
+        }
+
+        /// <summary>
+        /// The working directory to run the command in. Relative paths are resolved against the Pulumi project root (string)
+        /// </summary>
+        [Input("dir")]
+        public string? Dir { get; set; }
+
+        /// <summary>
+        /// Controls which variables of the provider's environment are passed to the command: all (the default), allowlist or none (string)
+        /// </summary>
+        [Input("inheritEnv")]
+        public string? InheritEnv { get; set; }
+
+        [Input("envAllowlist")]
+        private List<string>? _envAllowlist;
+
+        /// <summary>
+        /// The names of the variables to inherit when InheritEnv is allowlist (list)
+        /// </summary>
+        public List<string> EnvAllowlist
+        {
+            get => _envAllowlist ?? (_envAllowlist = new List<string>());
+            set => _envAllowlist = value;
+        }
+
+        [Input("unsetEnv")]
+        private List<string>? _unsetEnv;
+
+        /// <summary>
+        /// The names of inherited variables to remove from the command's environment (list)
+        /// </summary>
+        public List<string> UnsetEnv
+        {
+            get => _unsetEnv ?? (_unsetEnv = new List<string>());
+            set => _unsetEnv = value;
+        }
+
+        [Input("allowedExitCodes")]
+        private List<int>? _allowedExitCodes;
+
+        /// <summary>
+        /// Non-zero exit codes that are treated as success (list)
+        /// </summary>
+        public List<int> AllowedExitCodes
+        {
+            get => _allowedExitCodes ?? (_allowedExitCodes = new List<int>());
+            set => _allowedExitCodes = value;
+        }
+
+        /// <summary>
+        /// The maximum time the command may run, as a duration such as 30s or 5m (string)
+        /// </summary>
+        [Input("timeout")]
+        public string? Timeout { get; set; }
+
+        /// <summary>
+        /// The signal sent to the command's process group when it times out or the deployment is cancelled. Defaults to SIGTERM (string)
+        /// </summary>
+        [Input("stopSignal")]
+        public string? StopSignal { get; set; }
+
+        /// <summary>
+        /// How long the command is given to exit after StopSignal before it is killed, as a duration. Defaults to 10s (string)
+        /// </summary>
+        [Input("stopGracePeriod")]
+        public string? StopGracePeriod { get; set; }
+
+        /// <summary>
+        /// Decode stdout into the Parsed output: text, json, yaml, dotenv or lines (string)
+        /// </summary>
+        [Input("outputFormat")]
+        public string? OutputFormat { get; set; }
+
+        /// <summary>
+        /// Retry the command when it fails. Timeouts and cancellation are not retried
+        /// </summary>
+        [Input("retry")]
+        public RetryArgs? Retry { get; set; }
+
+        /// <summary>
+        /// Run the command in a new container of this image through the Docker Engine API at DOCKER_HOST. Dir is a path in the container
+        /// </summary>
+        [Input("image")]
+        public ImageArgs? Image { get; set; }
+
+        /// <summary>
+        /// Run the command in a sandbox on Linux, with a read-only file system except for a scratch directory in TMPDIR, no network, no new privileges and resource limits
+        /// </summary>
+        [Input("sandbox")]
+        public SandboxArgs? Sandbox { get; set; }
+
+        public RunArgs()
+        {
+        }
+
+        public sealed class RetryArgs : Pulumi.InvokeArgs
+        {
+          /// <summary>
+          /// The maximum number of times the command runs, including the first. Defaults to 3 (int)
+          /// </summary>
+          [Input("attempts")]
+          public int? Attempts { get; set; }
+
+          /// <summary>
+          /// How long to wait before the first retry, as a duration. Defaults to 1s (string)
+          /// </summary>
+          [Input("initialDelay")]
+          public string? InitialDelay { get; set; }
+
+          /// <summary>
+          /// The longest wait between attempts, as a duration. Defaults to 30s (string)
+          /// </summary>
+          [Input("maxDelay")]
+          public string? MaxDelay { get; set; }
+
+          /// <summary>
+          /// The factor each delay is multiplied by. Defaults to 2 (double)
+          /// </summary>
+          [Input("backoffFactor")]
+          public double? BackoffFactor { get; set; }
+
+          [Input("retryOnExitCodes")]
+          private List<int>? _retryOnExitCodes;
+
+          /// <summary>
+          /// Only retry when the command exits with one of these codes. Defaults to any failing exit code (list)
+          /// </summary>
+          public List<int> RetryOnExitCodes
+          {
+              get => _retryOnExitCodes ?? (_retryOnExitCodes = new List<int>());
+              set => _retryOnExitCodes = value;
+          }
+
+          /// <summary>
+          /// Only retry when stderr matches this regular expression (string)
+          /// </summary>
+          [Input("retryOnStderrMatch")]
+          public string? RetryOnStderrMatch { get; set; }
+        }
+
+        public sealed class ImageArgs : Pulumi.InvokeArgs
+        {
+          /// <summary>
+          /// The image reference, such as alpine:3.14 or alpine@sha256:... A new digest of the image in its registry updates the resource (string)
+          /// </summary>
+          [Input("name", required: true)]
+          public string Name { get; set; } = null!;
+
+          [Input("mounts")]
+          private List<MountArgs>? _mounts;
+
+          /// <summary>
+          /// Paths of the machine running the provider to bind into the container (list)
+          /// </summary>
+          public List<MountArgs> Mounts
+          {
+              get => _mounts ?? (_mounts = new List<MountArgs>());
+              set => _mounts = value;
+          }
+
+          /// <summary>
+          /// The network of the container, such as none, host or the name of a network (string)
+          /// </summary>
+          [Input("networkMode")]
+          public string? NetworkMode { get; set; }
+
+          /// <summary>
+          /// The user the command runs as, such as nobody or 1000:1000. Defaults to the user of the image (string)
+          /// </summary>
+          [Input("user")]
+          public string? User { get; set; }
+        }
+
+        public sealed class MountArgs : Pulumi.InvokeArgs
+        {
+          /// <summary>
+          /// The path on the machine running the provider. Relative paths are resolved against the Pulumi project root (string)
+          /// </summary>
+          [Input("source", required: true)]
+          public string Source { get; set; } = null!;
+
+          /// <summary>
+          /// The absolute path in the container (string)
+          /// </summary>
+          [Input("target", required: true)]
+          public string Target { get; set; } = null!;
+
+          /// <summary>
+          /// Whether the container may only read the mount (bool)
+          /// </summary>
+          [Input("readOnly")]
+          public bool? ReadOnly { get; set; }
+        }
+
+        public sealed class SandboxArgs : Pulumi.InvokeArgs
+        {
+          /// <summary>
+          /// The CPU time each process may use, in seconds, after which it is killed (int)
+          /// </summary>
+          [Input("cpuSeconds")]
+          public int? CpuSeconds { get; set; }
+
+          /// <summary>
+          /// The size of the address space of each process, in mebibytes (int)
+          /// </summary>
+          [Input("memoryMB")]
+          public int? MemoryMB { get; set; }
+
+          /// <summary>
+          /// The number of files each process may have open (int)
+          /// </summary>
+          [Input("openFiles")]
+          public int? OpenFiles { get; set; }
+
+          /// <summary>
+          /// The number of processes the user of the command may run, including those outside of the sandbox (int)
+          /// </summary>
+          [Input("maxProcesses")]
+          public int? MaxProcesses { get; set; }
+
+          /// <summary>
+          /// Keep the network of the provider instead of running the command in an empty network namespace (bool)
+          /// </summary>
+          [Input("network")]
+          public bool? Network { get; set; }
+        }
+  }
+
+  [OutputType]
+  public sealed class RunResult
+  {
+        /// <summary>
+        /// stdout of the command
+        /// </summary>
+        public readonly string Stdout;
+
+        /// <summary>
+        /// stderr of the command
+        /// </summary>
+        public readonly string Stderr;
+
+        /// <summary>
+        /// exit code of the command
+        /// </summary>
+        public readonly int ExitCode;
+
+        /// <summary>
+        /// stdout of the command decoded according to its OutputFormat
+        /// </summary>
+        public readonly object? Parsed;
+
+        /// <summary>
+        /// The number of times the command ran, including retries
+        /// </summary>
+        public readonly int? Attempts;
+
+        /// <summary>
+        /// The digest of the image the command ran in, when it has an Image
+        /// </summary>
+        public readonly string? ImageDigest;
+
+        [OutputConstructor]
+        private RunResult(
+            string stdout,
+
+            string stderr,
+
+            int exitCode,
+
+            object? parsed,
+
+            int? attempts,
+
+            string? imageDigest)
+        {
+            Stdout = stdout;
+            Stderr = stderr;
+            ExitCode = exitCode;
+            Parsed = parsed;
+            Attempts = attempts;
+            ImageDigest = imageDigest;
+        }
+  }
+}
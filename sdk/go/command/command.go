@@ -21,24 +21,56 @@ import (
 type Command struct {
 	pulumi.CustomResourceState
 
-	Compare pulumi.StringPtrOutput `pulumi:"compare"`
+	// The number of times the last command ran, including retries
+	Attempts pulumi.IntPtrOutput    `pulumi:"attempts"`
+	Compare  pulumi.StringPtrOutput `pulumi:"compare"`
 	// Define a command to create a resource.
 	Create CmdPtrOutput `pulumi:"create"`
 	Delete CmdPtrOutput `pulumi:"delete"`
+	// Whether the resource is deleted before its replacement is created. Defaults to true. Set to false to create the replacement first.
+	DeleteBeforeReplace pulumi.BoolPtrOutput `pulumi:"deleteBeforeReplace"`
 	// Specify a command to run to diff the resource.
 	//
 	// Exit 0 to run update.
 	// Exit with a non-zero value or omit to disable update.
 	// Hint: an easy method to always run update is to set diff to `['true']`
 	Diff CmdPtrOutput `pulumi:"diff"`
+	// Whether the output of the `read` command differed from the expected output during the last refresh
+	Drifted pulumi.BoolPtrOutput `pulumi:"drifted"`
+	// How long the command ran, in milliseconds
+	DurationMs pulumi.IntPtrOutput `pulumi:"durationMs"`
+	// exit code of the command
+	ExitCode pulumi.IntPtrOutput `pulumi:"exitCode"`
+	// The output the `read` command prints when the resource has not drifted. Defaults to the stdout of the last create or update.
+	Expected pulumi.StringPtrOutput `pulumi:"expected"`
+	// The time the command exited, in RFC 3339 format
+	FinishedAt pulumi.StringPtrOutput `pulumi:"finishedAt"`
+	// How the ID of the resource is derived. Defaults to the static ID `id`. Changing it replaces the resource.
+	IdFrom IdFromPtrOutput `pulumi:"idFrom"`
+	// The digest of the image the last command ran in, when it has an `image`
+	ImageDigest pulumi.StringPtrOutput `pulumi:"imageDigest"`
+	// stdout of the command decoded according to its `outputFormat`
+	Parsed pulumi.AnyOutput `pulumi:"parsed"`
 	// Define a command to create read the resource.
 	Read CmdPtrOutput `pulumi:"read"`
+	// stdout of the `read` command during the last refresh
+	ReadStdout pulumi.StringPtrOutput `pulumi:"readStdout"`
+	// The inputs whose changes replace the resource instead of updating it: any of `compare`, `create`, `read`, `update`, `delete` and `diff`.
+	ReplaceOn pulumi.StringArrayOutput `pulumi:"replaceOn"`
+	// The SHA-256 hash of the script of the last create or update, when it is a `script`
+	ScriptHash pulumi.StringPtrOutput `pulumi:"scriptHash"`
+	// The outputs to mark as secret: any of `stdout`, `stderr` and `parsed`. Secret inputs are always stored as secrets and redacted from logged output.
+	SecretOutputs pulumi.StringArrayOutput `pulumi:"secretOutputs"`
+	// The time the command was started, in RFC 3339 format
+	StartedAt pulumi.StringPtrOutput `pulumi:"startedAt"`
 	// stderr of the command
 	Stderr pulumi.StringPtrOutput `pulumi:"stderr"`
 	// stdout of the command
 	Stdout pulumi.StringPtrOutput `pulumi:"stdout"`
 	// If unspecified, create definition will be used. Define to provide an alternate update command.
 	Update CmdPtrOutput `pulumi:"update"`
+	// Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time.
+	WaitFor WaitForPtrOutput `pulumi:"waitFor"`
 }
 
 // NewCommand registers a new resource with the given unique name, arguments, and options.
@@ -86,16 +118,28 @@ type commandArgs struct {
 	// Define a command to create a resource.
 	Create Cmd  `pulumi:"create"`
 	Delete *Cmd `pulumi:"delete"`
+	// Whether the resource is deleted before its replacement is created. Defaults to true. Set to false to create the replacement first.
+	DeleteBeforeReplace *bool `pulumi:"deleteBeforeReplace"`
 	// Specify a command to run to diff the resource.
 	//
 	// Exit 0 to run update.
 	// Exit with a non-zero value or omit to disable update.
 	// Hint: an easy method to always run update is to set diff to `['true']`
 	Diff *Cmd `pulumi:"diff"`
+	// The output the `read` command prints when the resource has not drifted. Defaults to the stdout of the last create or update.
+	Expected *string `pulumi:"expected"`
+	// How the ID of the resource is derived. Defaults to the static ID `id`. Changing it replaces the resource.
+	IdFrom *IdFrom `pulumi:"idFrom"`
 	// Define a command to create read the resource.
 	Read *Cmd `pulumi:"read"`
+	// The inputs whose changes replace the resource instead of updating it: any of `compare`, `create`, `read`, `update`, `delete` and `diff`.
+	ReplaceOn []string `pulumi:"replaceOn"`
+	// The outputs to mark as secret: any of `stdout`, `stderr` and `parsed`. Secret inputs are always stored as secrets and redacted from logged output.
+	SecretOutputs []string `pulumi:"secretOutputs"`
 	// If unspecified, create definition will be used. Define to provide an alternate update command.
 	Update *Cmd `pulumi:"update"`
+	// Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time.
+	WaitFor *WaitFor `pulumi:"waitFor"`
 }
 
 // The set of arguments for constructing a Command resource.
@@ -103,16 +147,28 @@ type CommandArgs struct {
 	// Define a command to create a resource.
 	Create CmdInput
 	Delete CmdPtrInput
+	// Whether the resource is deleted before its replacement is created. Defaults to true. Set to false to create the replacement first.
+	DeleteBeforeReplace pulumi.BoolPtrInput
 	// Specify a command to run to diff the resource.
 	//
 	// Exit 0 to run update.
 	// Exit with a non-zero value or omit to disable update.
 	// Hint: an easy method to always run update is to set diff to `['true']`
 	Diff CmdPtrInput
+	// The output the `read` command prints when the resource has not drifted. Defaults to the stdout of the last create or update.
+	Expected pulumi.StringPtrInput
+	// How the ID of the resource is derived. Defaults to the static ID `id`. Changing it replaces the resource.
+	IdFrom IdFromPtrInput
 	// Define a command to create read the resource.
 	Read CmdPtrInput
+	// The inputs whose changes replace the resource instead of updating it: any of `compare`, `create`, `read`, `update`, `delete` and `diff`.
+	ReplaceOn pulumi.StringArrayInput
+	// The outputs to mark as secret: any of `stdout`, `stderr` and `parsed`. Secret inputs are always stored as secrets and redacted from logged output.
+	SecretOutputs pulumi.StringArrayInput
 	// If unspecified, create definition will be used. Define to provide an alternate update command.
 	Update CmdPtrInput
+	// Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time.
+	WaitFor WaitForPtrInput
 }
 
 func (CommandArgs) ElementType() reflect.Type {
@@ -0,0 +1,137 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Upload a directory tree to a remote host over SFTP.
+//
+// Files keep their mode bits. Each file is compared by the SHA-256 hash of its content, so an update only uploads the files that changed and removes those that were removed locally. A refresh detects changes made on the host. Deleting the resource removes the remote directory. The ID of the resource is the remote path.
+type CopyDirectory struct {
+	pulumi.CustomResourceState
+
+	// The host to copy to
+	Connection ConnectionOutput `pulumi:"connection"`
+	// The mode and SHA-256 hash of each file and directory of the tree, by its path relative to the directory
+	Files pulumi.MapOutput `pulumi:"files"`
+	// The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy.
+	RemotePath pulumi.StringOutput `pulumi:"remotePath"`
+	// The SHA-256 hash of the tree, covering the paths, modes and content of its files and directories
+	Sha256 pulumi.StringOutput `pulumi:"sha256"`
+	// The local directory to upload. Relative paths are resolved against the Pulumi project root.
+	Source pulumi.StringOutput `pulumi:"source"`
+}
+
+// NewCopyDirectory registers a new resource with the given unique name, arguments, and options.
+func NewCopyDirectory(ctx *pulumi.Context,
+	name string, args *CopyDirectoryArgs, opts ...pulumi.ResourceOption) (*CopyDirectory, error) {
+	if args == nil {
+		return nil, errors.New("missing one or more required arguments")
+	}
+
+	if args.Connection == nil {
+		return nil, errors.New("invalid value for required argument 'Connection'")
+	}
+	if args.RemotePath == nil {
+		return nil, errors.New("invalid value for required argument 'RemotePath'")
+	}
+	if args.Source == nil {
+		return nil, errors.New("invalid value for required argument 'Source'")
+	}
+	var resource CopyDirectory
+	err := ctx.RegisterResource("command:v1:CopyDirectory", name, args, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// GetCopyDirectory gets an existing CopyDirectory resource's state with the given name, ID, and optional
+// state properties that are used to uniquely qualify the lookup (nil if not required).
+func GetCopyDirectory(ctx *pulumi.Context,
+	name string, id pulumi.IDInput, state *CopyDirectoryState, opts ...pulumi.ResourceOption) (*CopyDirectory, error) {
+	var resource CopyDirectory
+	err := ctx.ReadResource("command:v1:CopyDirectory", name, id, state, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// Input properties used for looking up and filtering CopyDirectory resources.
+type copyDirectoryState struct {
+}
+
+type CopyDirectoryState struct {
+}
+
+func (CopyDirectoryState) ElementType() reflect.Type {
+	return reflect.TypeOf((*copyDirectoryState)(nil)).Elem()
+}
+
+type copyDirectoryArgs struct {
+	// The host to copy to
+	Connection Connection `pulumi:"connection"`
+	// The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy.
+	RemotePath string `pulumi:"remotePath"`
+	// The local directory to upload. Relative paths are resolved against the Pulumi project root.
+	Source string `pulumi:"source"`
+}
+
+// The set of arguments for constructing a CopyDirectory resource.
+type CopyDirectoryArgs struct {
+	// The host to copy to
+	Connection ConnectionInput
+	// The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy.
+	RemotePath pulumi.StringInput
+	// The local directory to upload. Relative paths are resolved against the Pulumi project root.
+	Source pulumi.StringInput
+}
+
+func (CopyDirectoryArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*copyDirectoryArgs)(nil)).Elem()
+}
+
+type CopyDirectoryInput interface {
+	pulumi.Input
+
+	ToCopyDirectoryOutput() CopyDirectoryOutput
+	ToCopyDirectoryOutputWithContext(ctx context.Context) CopyDirectoryOutput
+}
+
+func (*CopyDirectory) ElementType() reflect.Type {
+	return reflect.TypeOf((*CopyDirectory)(nil))
+}
+
+func (i *CopyDirectory) ToCopyDirectoryOutput() CopyDirectoryOutput {
+	return i.ToCopyDirectoryOutputWithContext(context.Background())
+}
+
+func (i *CopyDirectory) ToCopyDirectoryOutputWithContext(ctx context.Context) CopyDirectoryOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(CopyDirectoryOutput)
+}
+
+type CopyDirectoryOutput struct{ *pulumi.OutputState }
+
+func (CopyDirectoryOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*CopyDirectory)(nil))
+}
+
+func (o CopyDirectoryOutput) ToCopyDirectoryOutput() CopyDirectoryOutput {
+	return o
+}
+
+func (o CopyDirectoryOutput) ToCopyDirectoryOutputWithContext(ctx context.Context) CopyDirectoryOutput {
+	return o
+}
+
+func init() {
+	pulumi.RegisterOutputType(CopyDirectoryOutput{})
+}
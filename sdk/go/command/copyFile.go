@@ -0,0 +1,152 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Upload a file to a remote host over SFTP.
+//
+// The file is written atomically with its mode and compared by the SHA-256 hash of its content, so it is only uploaded again when its content changes. A refresh detects changes made on the host and the next update uploads the file again. Deleting the resource removes the remote file. The ID of the resource is the remote path.
+type CopyFile struct {
+	pulumi.CustomResourceState
+
+	// The host to copy to
+	Connection ConnectionOutput `pulumi:"connection"`
+	// The content of the file
+	Content pulumi.StringPtrOutput `pulumi:"content"`
+	// The base64-encoded content of a binary file
+	ContentBase64 pulumi.StringPtrOutput `pulumi:"contentBase64"`
+	// The permission bits of the remote file in octal, such as `0600`. Defaults to the mode of `source`, or `0644`.
+	Mode pulumi.StringOutput `pulumi:"mode"`
+	// The path on the host. Missing parent directories are created. Changing it replaces the copy.
+	RemotePath pulumi.StringOutput `pulumi:"remotePath"`
+	// The SHA-256 hash of the content of the file
+	Sha256 pulumi.StringOutput `pulumi:"sha256"`
+	// The size of the file in bytes
+	Size pulumi.IntOutput `pulumi:"size"`
+	// The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of `source`, `content` and `contentBase64`.
+	Source pulumi.StringPtrOutput `pulumi:"source"`
+}
+
+// NewCopyFile registers a new resource with the given unique name, arguments, and options.
+func NewCopyFile(ctx *pulumi.Context,
+	name string, args *CopyFileArgs, opts ...pulumi.ResourceOption) (*CopyFile, error) {
+	if args == nil {
+		return nil, errors.New("missing one or more required arguments")
+	}
+
+	if args.Connection == nil {
+		return nil, errors.New("invalid value for required argument 'Connection'")
+	}
+	if args.RemotePath == nil {
+		return nil, errors.New("invalid value for required argument 'RemotePath'")
+	}
+	var resource CopyFile
+	err := ctx.RegisterResource("command:v1:CopyFile", name, args, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// GetCopyFile gets an existing CopyFile resource's state with the given name, ID, and optional
+// state properties that are used to uniquely qualify the lookup (nil if not required).
+func GetCopyFile(ctx *pulumi.Context,
+	name string, id pulumi.IDInput, state *CopyFileState, opts ...pulumi.ResourceOption) (*CopyFile, error) {
+	var resource CopyFile
+	err := ctx.ReadResource("command:v1:CopyFile", name, id, state, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// Input properties used for looking up and filtering CopyFile resources.
+type copyFileState struct {
+}
+
+type CopyFileState struct {
+}
+
+func (CopyFileState) ElementType() reflect.Type {
+	return reflect.TypeOf((*copyFileState)(nil)).Elem()
+}
+
+type copyFileArgs struct {
+	// The host to copy to
+	Connection Connection `pulumi:"connection"`
+	// The content of the file
+	Content *string `pulumi:"content"`
+	// The base64-encoded content of a binary file
+	ContentBase64 *string `pulumi:"contentBase64"`
+	// The permission bits of the remote file in octal, such as `0600`. Defaults to the mode of `source`, or `0644`.
+	Mode *string `pulumi:"mode"`
+	// The path on the host. Missing parent directories are created. Changing it replaces the copy.
+	RemotePath string `pulumi:"remotePath"`
+	// The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of `source`, `content` and `contentBase64`.
+	Source *string `pulumi:"source"`
+}
+
+// The set of arguments for constructing a CopyFile resource.
+type CopyFileArgs struct {
+	// The host to copy to
+	Connection ConnectionInput
+	// The content of the file
+	Content pulumi.StringPtrInput
+	// The base64-encoded content of a binary file
+	ContentBase64 pulumi.StringPtrInput
+	// The permission bits of the remote file in octal, such as `0600`. Defaults to the mode of `source`, or `0644`.
+	Mode pulumi.StringPtrInput
+	// The path on the host. Missing parent directories are created. Changing it replaces the copy.
+	RemotePath pulumi.StringInput
+	// The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of `source`, `content` and `contentBase64`.
+	Source pulumi.StringPtrInput
+}
+
+func (CopyFileArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*copyFileArgs)(nil)).Elem()
+}
+
+type CopyFileInput interface {
+	pulumi.Input
+
+	ToCopyFileOutput() CopyFileOutput
+	ToCopyFileOutputWithContext(ctx context.Context) CopyFileOutput
+}
+
+func (*CopyFile) ElementType() reflect.Type {
+	return reflect.TypeOf((*CopyFile)(nil))
+}
+
+func (i *CopyFile) ToCopyFileOutput() CopyFileOutput {
+	return i.ToCopyFileOutputWithContext(context.Background())
+}
+
+func (i *CopyFile) ToCopyFileOutputWithContext(ctx context.Context) CopyFileOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(CopyFileOutput)
+}
+
+type CopyFileOutput struct{ *pulumi.OutputState }
+
+func (CopyFileOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*CopyFile)(nil))
+}
+
+func (o CopyFileOutput) ToCopyFileOutput() CopyFileOutput {
+	return o
+}
+
+func (o CopyFileOutput) ToCopyFileOutputWithContext(ctx context.Context) CopyFileOutput {
+	return o
+}
+
+func init() {
+	pulumi.RegisterOutputType(CopyFileOutput{})
+}
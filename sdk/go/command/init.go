@@ -22,6 +22,14 @@ func (m *module) Construct(ctx *pulumi.Context, name, typ, urn string) (r pulumi
 	switch typ {
 	case "command:v1:Command":
 		r = &Command{}
+	case "command:v1:CopyDirectory":
+		r = &CopyDirectory{}
+	case "command:v1:CopyFile":
+		r = &CopyFile{}
+	case "command:v1:LocalFile":
+		r = &LocalFile{}
+	case "command:v1:RemoteCommand":
+		r = &RemoteCommand{}
 	default:
 		return nil, fmt.Errorf("unknown resource type: %s", typ)
 	}
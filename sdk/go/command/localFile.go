@@ -0,0 +1,143 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// A file on the machine running Pulumi.
+//
+// The file is written atomically and compared by the SHA-256 hash of its content, so it is only rewritten when its content, mode or owner change. A refresh detects changes made outside of Pulumi and the next update restores the file. The ID of the resource is its path, which can be used to import an existing file.
+type LocalFile struct {
+	pulumi.CustomResourceState
+
+	// The content of the file. Set either `content` or `contentBase64`.
+	Content pulumi.StringPtrOutput `pulumi:"content"`
+	// The base64-encoded content of a binary file
+	ContentBase64 pulumi.StringPtrOutput `pulumi:"contentBase64"`
+	// The permission bits of the file in octal, such as `0600`. Defaults to `0644`.
+	Mode pulumi.StringOutput `pulumi:"mode"`
+	// The user that owns the file, optionally followed by a colon and its group, such as `www-data:adm`. Names and numeric IDs are accepted.
+	Owner pulumi.StringPtrOutput `pulumi:"owner"`
+	// The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file.
+	Path pulumi.StringOutput `pulumi:"path"`
+	// The SHA-256 hash of the content of the file
+	Sha256 pulumi.StringOutput `pulumi:"sha256"`
+	// The size of the file in bytes
+	Size pulumi.IntOutput `pulumi:"size"`
+}
+
+// NewLocalFile registers a new resource with the given unique name, arguments, and options.
+func NewLocalFile(ctx *pulumi.Context,
+	name string, args *LocalFileArgs, opts ...pulumi.ResourceOption) (*LocalFile, error) {
+	if args == nil {
+		return nil, errors.New("missing one or more required arguments")
+	}
+
+	if args.Path == nil {
+		return nil, errors.New("invalid value for required argument 'Path'")
+	}
+	var resource LocalFile
+	err := ctx.RegisterResource("command:v1:LocalFile", name, args, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// GetLocalFile gets an existing LocalFile resource's state with the given name, ID, and optional
+// state properties that are used to uniquely qualify the lookup (nil if not required).
+func GetLocalFile(ctx *pulumi.Context,
+	name string, id pulumi.IDInput, state *LocalFileState, opts ...pulumi.ResourceOption) (*LocalFile, error) {
+	var resource LocalFile
+	err := ctx.ReadResource("command:v1:LocalFile", name, id, state, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// Input properties used for looking up and filtering LocalFile resources.
+type localFileState struct {
+}
+
+type LocalFileState struct {
+}
+
+func (LocalFileState) ElementType() reflect.Type {
+	return reflect.TypeOf((*localFileState)(nil)).Elem()
+}
+
+type localFileArgs struct {
+	// The content of the file. Set either `content` or `contentBase64`.
+	Content *string `pulumi:"content"`
+	// The base64-encoded content of a binary file
+	ContentBase64 *string `pulumi:"contentBase64"`
+	// The permission bits of the file in octal, such as `0600`. Defaults to `0644`.
+	Mode *string `pulumi:"mode"`
+	// The user that owns the file, optionally followed by a colon and its group, such as `www-data:adm`. Names and numeric IDs are accepted.
+	Owner *string `pulumi:"owner"`
+	// The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file.
+	Path string `pulumi:"path"`
+}
+
+// The set of arguments for constructing a LocalFile resource.
+type LocalFileArgs struct {
+	// The content of the file. Set either `content` or `contentBase64`.
+	Content pulumi.StringPtrInput
+	// The base64-encoded content of a binary file
+	ContentBase64 pulumi.StringPtrInput
+	// The permission bits of the file in octal, such as `0600`. Defaults to `0644`.
+	Mode pulumi.StringPtrInput
+	// The user that owns the file, optionally followed by a colon and its group, such as `www-data:adm`. Names and numeric IDs are accepted.
+	Owner pulumi.StringPtrInput
+	// The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file.
+	Path pulumi.StringInput
+}
+
+func (LocalFileArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*localFileArgs)(nil)).Elem()
+}
+
+type LocalFileInput interface {
+	pulumi.Input
+
+	ToLocalFileOutput() LocalFileOutput
+	ToLocalFileOutputWithContext(ctx context.Context) LocalFileOutput
+}
+
+func (*LocalFile) ElementType() reflect.Type {
+	return reflect.TypeOf((*LocalFile)(nil))
+}
+
+func (i *LocalFile) ToLocalFileOutput() LocalFileOutput {
+	return i.ToLocalFileOutputWithContext(context.Background())
+}
+
+func (i *LocalFile) ToLocalFileOutputWithContext(ctx context.Context) LocalFileOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(LocalFileOutput)
+}
+
+type LocalFileOutput struct{ *pulumi.OutputState }
+
+func (LocalFileOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*LocalFile)(nil))
+}
+
+func (o LocalFileOutput) ToLocalFileOutput() LocalFileOutput {
+	return o
+}
+
+func (o LocalFileOutput) ToLocalFileOutputWithContext(ctx context.Context) LocalFileOutput {
+	return o
+}
+
+func init() {
+	pulumi.RegisterOutputType(LocalFileOutput{})
+}
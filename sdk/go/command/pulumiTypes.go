@@ -12,11 +12,45 @@ import (
 
 // Command specification
 type Cmd struct {
-	// Specifiy the command to run as an array of arguments
-	Command     []string          `pulumi:"command"`
-	Environment map[string]string `pulumi:"environment"`
+	// Non-zero exit codes that are treated as success.
+	AllowedExitCodes []int `pulumi:"allowedExitCodes"`
+	// Specifiy the command to run as an array of arguments. Set either `command` or `script`.
+	Command []string `pulumi:"command"`
+	// The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+	Dir *string `pulumi:"dir"`
+	// The names of the variables to inherit when `inheritEnv` is `allowlist`.
+	EnvAllowlist []string          `pulumi:"envAllowlist"`
+	Environment  map[string]string `pulumi:"environment"`
+	// Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+	Image *Image `pulumi:"image"`
+	// Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+	//
+	// The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+	InheritEnv *string `pulumi:"inheritEnv"`
+	// The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+	Interpreter *string `pulumi:"interpreter"`
+	// Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+	OutputFormat *string `pulumi:"outputFormat"`
+	// Retry the command when it fails. Timeouts and cancellation are not retried.
+	Retry *Retry `pulumi:"retry"`
+	// Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+	Sandbox *Sandbox `pulumi:"sandbox"`
+	// A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+	Script *string `pulumi:"script"`
+	// Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+	ScriptFile *bool `pulumi:"scriptFile"`
 	// Pass the stdin to a command
 	Stdin *string `pulumi:"stdin"`
+	// Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`. Not available to the create command.
+	StdinFrom *string `pulumi:"stdinFrom"`
+	// How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+	StopGracePeriod *string `pulumi:"stopGracePeriod"`
+	// The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+	StopSignal *string `pulumi:"stopSignal"`
+	// The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+	Timeout *string `pulumi:"timeout"`
+	// The names of inherited variables to remove from the command's environment.
+	UnsetEnv []string `pulumi:"unsetEnv"`
 }
 
 // CmdInput is an input type that accepts CmdArgs and CmdOutput values.
@@ -32,11 +66,45 @@ type CmdInput interface {
 
 // Command specification
 type CmdArgs struct {
-	// Specifiy the command to run as an array of arguments
-	Command     pulumi.StringArrayInput `pulumi:"command"`
-	Environment pulumi.StringMapInput   `pulumi:"environment"`
+	// Non-zero exit codes that are treated as success.
+	AllowedExitCodes pulumi.IntArrayInput `pulumi:"allowedExitCodes"`
+	// Specifiy the command to run as an array of arguments. Set either `command` or `script`.
+	Command pulumi.StringArrayInput `pulumi:"command"`
+	// The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+	Dir pulumi.StringPtrInput `pulumi:"dir"`
+	// The names of the variables to inherit when `inheritEnv` is `allowlist`.
+	EnvAllowlist pulumi.StringArrayInput `pulumi:"envAllowlist"`
+	Environment  pulumi.StringMapInput   `pulumi:"environment"`
+	// Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+	Image ImagePtrInput `pulumi:"image"`
+	// Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+	//
+	// The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+	InheritEnv pulumi.StringPtrInput `pulumi:"inheritEnv"`
+	// The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+	Interpreter pulumi.StringPtrInput `pulumi:"interpreter"`
+	// Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+	OutputFormat pulumi.StringPtrInput `pulumi:"outputFormat"`
+	// Retry the command when it fails. Timeouts and cancellation are not retried.
+	Retry RetryPtrInput `pulumi:"retry"`
+	// Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+	Sandbox SandboxPtrInput `pulumi:"sandbox"`
+	// A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+	Script pulumi.StringPtrInput `pulumi:"script"`
+	// Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+	ScriptFile pulumi.BoolPtrInput `pulumi:"scriptFile"`
 	// Pass the stdin to a command
 	Stdin pulumi.StringPtrInput `pulumi:"stdin"`
+	// Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`. Not available to the create command.
+	StdinFrom pulumi.StringPtrInput `pulumi:"stdinFrom"`
+	// How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+	StopGracePeriod pulumi.StringPtrInput `pulumi:"stopGracePeriod"`
+	// The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+	StopSignal pulumi.StringPtrInput `pulumi:"stopSignal"`
+	// The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+	Timeout pulumi.StringPtrInput `pulumi:"timeout"`
+	// The names of inherited variables to remove from the command's environment.
+	UnsetEnv pulumi.StringArrayInput `pulumi:"unsetEnv"`
 }
 
 func (CmdArgs) ElementType() reflect.Type {
@@ -117,20 +185,102 @@ func (o CmdOutput) ToCmdPtrOutputWithContext(ctx context.Context) CmdPtrOutput {
 	}).(CmdPtrOutput)
 }
 
-// Specifiy the command to run as an array of arguments
+// Non-zero exit codes that are treated as success.
+func (o CmdOutput) AllowedExitCodes() pulumi.IntArrayOutput {
+	return o.ApplyT(func(v Cmd) []int { return v.AllowedExitCodes }).(pulumi.IntArrayOutput)
+}
+
+// Specifiy the command to run as an array of arguments. Set either `command` or `script`.
 func (o CmdOutput) Command() pulumi.StringArrayOutput {
 	return o.ApplyT(func(v Cmd) []string { return v.Command }).(pulumi.StringArrayOutput)
 }
 
+// The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+func (o CmdOutput) Dir() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.Dir }).(pulumi.StringPtrOutput)
+}
+
+// The names of the variables to inherit when `inheritEnv` is `allowlist`.
+func (o CmdOutput) EnvAllowlist() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v Cmd) []string { return v.EnvAllowlist }).(pulumi.StringArrayOutput)
+}
+
 func (o CmdOutput) Environment() pulumi.StringMapOutput {
 	return o.ApplyT(func(v Cmd) map[string]string { return v.Environment }).(pulumi.StringMapOutput)
 }
 
+// Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+func (o CmdOutput) Image() ImagePtrOutput {
+	return o.ApplyT(func(v Cmd) *Image { return v.Image }).(ImagePtrOutput)
+}
+
+// Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+//
+// The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+func (o CmdOutput) InheritEnv() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.InheritEnv }).(pulumi.StringPtrOutput)
+}
+
+// The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+func (o CmdOutput) Interpreter() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.Interpreter }).(pulumi.StringPtrOutput)
+}
+
+// Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+func (o CmdOutput) OutputFormat() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.OutputFormat }).(pulumi.StringPtrOutput)
+}
+
+// Retry the command when it fails. Timeouts and cancellation are not retried.
+func (o CmdOutput) Retry() RetryPtrOutput {
+	return o.ApplyT(func(v Cmd) *Retry { return v.Retry }).(RetryPtrOutput)
+}
+
+// Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+func (o CmdOutput) Sandbox() SandboxPtrOutput {
+	return o.ApplyT(func(v Cmd) *Sandbox { return v.Sandbox }).(SandboxPtrOutput)
+}
+
+// A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+func (o CmdOutput) Script() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.Script }).(pulumi.StringPtrOutput)
+}
+
+// Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+func (o CmdOutput) ScriptFile() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v Cmd) *bool { return v.ScriptFile }).(pulumi.BoolPtrOutput)
+}
+
 // Pass the stdin to a command
 func (o CmdOutput) Stdin() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v Cmd) *string { return v.Stdin }).(pulumi.StringPtrOutput)
 }
 
+// Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`. Not available to the create command.
+func (o CmdOutput) StdinFrom() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.StdinFrom }).(pulumi.StringPtrOutput)
+}
+
+// How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+func (o CmdOutput) StopGracePeriod() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.StopGracePeriod }).(pulumi.StringPtrOutput)
+}
+
+// The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+func (o CmdOutput) StopSignal() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.StopSignal }).(pulumi.StringPtrOutput)
+}
+
+// The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+func (o CmdOutput) Timeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Cmd) *string { return v.Timeout }).(pulumi.StringPtrOutput)
+}
+
+// The names of inherited variables to remove from the command's environment.
+func (o CmdOutput) UnsetEnv() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v Cmd) []string { return v.UnsetEnv }).(pulumi.StringArrayOutput)
+}
+
 type CmdPtrOutput struct{ *pulumi.OutputState }
 
 func (CmdPtrOutput) ElementType() reflect.Type {
@@ -155,7 +305,17 @@ func (o CmdPtrOutput) Elem() CmdOutput {
 	}).(CmdOutput)
 }
 
-// Specifiy the command to run as an array of arguments
+// Non-zero exit codes that are treated as success.
+func (o CmdPtrOutput) AllowedExitCodes() pulumi.IntArrayOutput {
+	return o.ApplyT(func(v *Cmd) []int {
+		if v == nil {
+			return nil
+		}
+		return v.AllowedExitCodes
+	}).(pulumi.IntArrayOutput)
+}
+
+// Specifiy the command to run as an array of arguments. Set either `command` or `script`.
 func (o CmdPtrOutput) Command() pulumi.StringArrayOutput {
 	return o.ApplyT(func(v *Cmd) []string {
 		if v == nil {
@@ -165,6 +325,26 @@ func (o CmdPtrOutput) Command() pulumi.StringArrayOutput {
 	}).(pulumi.StringArrayOutput)
 }
 
+// The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+func (o CmdPtrOutput) Dir() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Dir
+	}).(pulumi.StringPtrOutput)
+}
+
+// The names of the variables to inherit when `inheritEnv` is `allowlist`.
+func (o CmdPtrOutput) EnvAllowlist() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v *Cmd) []string {
+		if v == nil {
+			return nil
+		}
+		return v.EnvAllowlist
+	}).(pulumi.StringArrayOutput)
+}
+
 func (o CmdPtrOutput) Environment() pulumi.StringMapOutput {
 	return o.ApplyT(func(v *Cmd) map[string]string {
 		if v == nil {
@@ -174,6 +354,88 @@ func (o CmdPtrOutput) Environment() pulumi.StringMapOutput {
 	}).(pulumi.StringMapOutput)
 }
 
+// Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+func (o CmdPtrOutput) Image() ImagePtrOutput {
+	return o.ApplyT(func(v *Cmd) *Image {
+		if v == nil {
+			return nil
+		}
+		return v.Image
+	}).(ImagePtrOutput)
+}
+
+// Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+//
+// The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+func (o CmdPtrOutput) InheritEnv() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.InheritEnv
+	}).(pulumi.StringPtrOutput)
+}
+
+// The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+func (o CmdPtrOutput) Interpreter() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Interpreter
+	}).(pulumi.StringPtrOutput)
+}
+
+// Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+func (o CmdPtrOutput) OutputFormat() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.OutputFormat
+	}).(pulumi.StringPtrOutput)
+}
+
+// Retry the command when it fails. Timeouts and cancellation are not retried.
+func (o CmdPtrOutput) Retry() RetryPtrOutput {
+	return o.ApplyT(func(v *Cmd) *Retry {
+		if v == nil {
+			return nil
+		}
+		return v.Retry
+	}).(RetryPtrOutput)
+}
+
+// Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+func (o CmdPtrOutput) Sandbox() SandboxPtrOutput {
+	return o.ApplyT(func(v *Cmd) *Sandbox {
+		if v == nil {
+			return nil
+		}
+		return v.Sandbox
+	}).(SandboxPtrOutput)
+}
+
+// A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+func (o CmdPtrOutput) Script() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Script
+	}).(pulumi.StringPtrOutput)
+}
+
+// Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+func (o CmdPtrOutput) ScriptFile() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *Cmd) *bool {
+		if v == nil {
+			return nil
+		}
+		return v.ScriptFile
+	}).(pulumi.BoolPtrOutput)
+}
+
 // Pass the stdin to a command
 func (o CmdPtrOutput) Stdin() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v *Cmd) *string {
@@ -184,7 +446,1580 @@ func (o CmdPtrOutput) Stdin() pulumi.StringPtrOutput {
 	}).(pulumi.StringPtrOutput)
 }
 
+// Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`. Not available to the create command.
+func (o CmdPtrOutput) StdinFrom() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.StdinFrom
+	}).(pulumi.StringPtrOutput)
+}
+
+// How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+func (o CmdPtrOutput) StopGracePeriod() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.StopGracePeriod
+	}).(pulumi.StringPtrOutput)
+}
+
+// The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+func (o CmdPtrOutput) StopSignal() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.StopSignal
+	}).(pulumi.StringPtrOutput)
+}
+
+// The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+func (o CmdPtrOutput) Timeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Cmd) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Timeout
+	}).(pulumi.StringPtrOutput)
+}
+
+// The names of inherited variables to remove from the command's environment.
+func (o CmdPtrOutput) UnsetEnv() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v *Cmd) []string {
+		if v == nil {
+			return nil
+		}
+		return v.UnsetEnv
+	}).(pulumi.StringArrayOutput)
+}
+
+// How to reach a host over SSH. The user authenticates with `privateKey`, `password` or an SSH agent, which is used by default when `SSH_AUTH_SOCK` is set and neither of the others is.
+type Connection struct {
+	// The path of the socket of an SSH agent that holds the keys of the user
+	AgentSocketPath *string `pulumi:"agentSocketPath"`
+	// How long to keep trying to connect to a host that is not reachable yet, such as a machine that is still booting, as a duration. Defaults to `2m`.
+	DialTimeout *string `pulumi:"dialTimeout"`
+	// The address of the host
+	Host string `pulumi:"host"`
+	// Pins the key of the host, as a public key in `authorized_keys` format such as `ssh-ed25519 AAAA...` or as a fingerprint such as `SHA256:...`. Without it, any host key is accepted.
+	HostKey *string `pulumi:"hostKey"`
+	// The password of the user
+	Password *string `pulumi:"password"`
+	// The port of the SSH server. Defaults to 22.
+	Port *int `pulumi:"port"`
+	// The private key of the user in PEM format
+	PrivateKey *string `pulumi:"privateKey"`
+	// The password of an encrypted `privateKey`
+	PrivateKeyPassword *string `pulumi:"privateKeyPassword"`
+	// A bastion host through which the host is reached
+	Proxy *Connection `pulumi:"proxy"`
+	// The user to log in as. Defaults to `root`.
+	User *string `pulumi:"user"`
+}
+
+// ConnectionInput is an input type that accepts ConnectionArgs and ConnectionOutput values.
+// You can construct a concrete instance of `ConnectionInput` via:
+//
+//          ConnectionArgs{...}
+type ConnectionInput interface {
+	pulumi.Input
+
+	ToConnectionOutput() ConnectionOutput
+	ToConnectionOutputWithContext(context.Context) ConnectionOutput
+}
+
+// How to reach a host over SSH. The user authenticates with `privateKey`, `password` or an SSH agent, which is used by default when `SSH_AUTH_SOCK` is set and neither of the others is.
+type ConnectionArgs struct {
+	// The path of the socket of an SSH agent that holds the keys of the user
+	AgentSocketPath pulumi.StringPtrInput `pulumi:"agentSocketPath"`
+	// How long to keep trying to connect to a host that is not reachable yet, such as a machine that is still booting, as a duration. Defaults to `2m`.
+	DialTimeout pulumi.StringPtrInput `pulumi:"dialTimeout"`
+	// The address of the host
+	Host pulumi.StringInput `pulumi:"host"`
+	// Pins the key of the host, as a public key in `authorized_keys` format such as `ssh-ed25519 AAAA...` or as a fingerprint such as `SHA256:...`. Without it, any host key is accepted.
+	HostKey pulumi.StringPtrInput `pulumi:"hostKey"`
+	// The password of the user
+	Password pulumi.StringPtrInput `pulumi:"password"`
+	// The port of the SSH server. Defaults to 22.
+	Port pulumi.IntPtrInput `pulumi:"port"`
+	// The private key of the user in PEM format
+	PrivateKey pulumi.StringPtrInput `pulumi:"privateKey"`
+	// The password of an encrypted `privateKey`
+	PrivateKeyPassword pulumi.StringPtrInput `pulumi:"privateKeyPassword"`
+	// A bastion host through which the host is reached
+	Proxy ConnectionPtrInput `pulumi:"proxy"`
+	// The user to log in as. Defaults to `root`.
+	User pulumi.StringPtrInput `pulumi:"user"`
+}
+
+func (ConnectionArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Connection)(nil)).Elem()
+}
+
+func (i ConnectionArgs) ToConnectionOutput() ConnectionOutput {
+	return i.ToConnectionOutputWithContext(context.Background())
+}
+
+func (i ConnectionArgs) ToConnectionOutputWithContext(ctx context.Context) ConnectionOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ConnectionOutput)
+}
+
+func (i ConnectionArgs) ToConnectionPtrOutput() ConnectionPtrOutput {
+	return i.ToConnectionPtrOutputWithContext(context.Background())
+}
+
+func (i ConnectionArgs) ToConnectionPtrOutputWithContext(ctx context.Context) ConnectionPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ConnectionOutput).ToConnectionPtrOutputWithContext(ctx)
+}
+
+// ConnectionPtrInput is an input type that accepts ConnectionArgs, ConnectionPtr and ConnectionPtrOutput values.
+// You can construct a concrete instance of `ConnectionPtrInput` via:
+//
+//          ConnectionArgs{...}
+//
+//  or:
+//
+//          nil
+type ConnectionPtrInput interface {
+	pulumi.Input
+
+	ToConnectionPtrOutput() ConnectionPtrOutput
+	ToConnectionPtrOutputWithContext(context.Context) ConnectionPtrOutput
+}
+
+type connectionPtrType ConnectionArgs
+
+func ConnectionPtr(v *ConnectionArgs) ConnectionPtrInput {
+	return (*connectionPtrType)(v)
+}
+
+func (*connectionPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**Connection)(nil)).Elem()
+}
+
+func (i *connectionPtrType) ToConnectionPtrOutput() ConnectionPtrOutput {
+	return i.ToConnectionPtrOutputWithContext(context.Background())
+}
+
+func (i *connectionPtrType) ToConnectionPtrOutputWithContext(ctx context.Context) ConnectionPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ConnectionPtrOutput)
+}
+
+// How to reach a host over SSH. The user authenticates with `privateKey`, `password` or an SSH agent, which is used by default when `SSH_AUTH_SOCK` is set and neither of the others is.
+type ConnectionOutput struct{ *pulumi.OutputState }
+
+func (ConnectionOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Connection)(nil)).Elem()
+}
+
+func (o ConnectionOutput) ToConnectionOutput() ConnectionOutput {
+	return o
+}
+
+func (o ConnectionOutput) ToConnectionOutputWithContext(ctx context.Context) ConnectionOutput {
+	return o
+}
+
+func (o ConnectionOutput) ToConnectionPtrOutput() ConnectionPtrOutput {
+	return o.ToConnectionPtrOutputWithContext(context.Background())
+}
+
+func (o ConnectionOutput) ToConnectionPtrOutputWithContext(ctx context.Context) ConnectionPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v Connection) *Connection {
+		return &v
+	}).(ConnectionPtrOutput)
+}
+
+// The path of the socket of an SSH agent that holds the keys of the user
+func (o ConnectionOutput) AgentSocketPath() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Connection) *string { return v.AgentSocketPath }).(pulumi.StringPtrOutput)
+}
+
+// How long to keep trying to connect to a host that is not reachable yet, such as a machine that is still booting, as a duration. Defaults to `2m`.
+func (o ConnectionOutput) DialTimeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Connection) *string { return v.DialTimeout }).(pulumi.StringPtrOutput)
+}
+
+// The address of the host
+func (o ConnectionOutput) Host() pulumi.StringOutput {
+	return o.ApplyT(func(v Connection) string { return v.Host }).(pulumi.StringOutput)
+}
+
+// Pins the key of the host, as a public key in `authorized_keys` format such as `ssh-ed25519 AAAA...` or as a fingerprint such as `SHA256:...`. Without it, any host key is accepted.
+func (o ConnectionOutput) HostKey() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Connection) *string { return v.HostKey }).(pulumi.StringPtrOutput)
+}
+
+// The password of the user
+func (o ConnectionOutput) Password() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Connection) *string { return v.Password }).(pulumi.StringPtrOutput)
+}
+
+// The port of the SSH server. Defaults to 22.
+func (o ConnectionOutput) Port() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v Connection) *int { return v.Port }).(pulumi.IntPtrOutput)
+}
+
+// The private key of the user in PEM format
+func (o ConnectionOutput) PrivateKey() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Connection) *string { return v.PrivateKey }).(pulumi.StringPtrOutput)
+}
+
+// The password of an encrypted `privateKey`
+func (o ConnectionOutput) PrivateKeyPassword() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Connection) *string { return v.PrivateKeyPassword }).(pulumi.StringPtrOutput)
+}
+
+// A bastion host through which the host is reached
+func (o ConnectionOutput) Proxy() ConnectionPtrOutput {
+	return o.ApplyT(func(v Connection) *Connection { return v.Proxy }).(ConnectionPtrOutput)
+}
+
+// The user to log in as. Defaults to `root`.
+func (o ConnectionOutput) User() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Connection) *string { return v.User }).(pulumi.StringPtrOutput)
+}
+
+type ConnectionPtrOutput struct{ *pulumi.OutputState }
+
+func (ConnectionPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**Connection)(nil)).Elem()
+}
+
+func (o ConnectionPtrOutput) ToConnectionPtrOutput() ConnectionPtrOutput {
+	return o
+}
+
+func (o ConnectionPtrOutput) ToConnectionPtrOutputWithContext(ctx context.Context) ConnectionPtrOutput {
+	return o
+}
+
+func (o ConnectionPtrOutput) Elem() ConnectionOutput {
+	return o.ApplyT(func(v *Connection) Connection {
+		if v != nil {
+			return *v
+		}
+		var ret Connection
+		return ret
+	}).(ConnectionOutput)
+}
+
+// The path of the socket of an SSH agent that holds the keys of the user
+func (o ConnectionPtrOutput) AgentSocketPath() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Connection) *string {
+		if v == nil {
+			return nil
+		}
+		return v.AgentSocketPath
+	}).(pulumi.StringPtrOutput)
+}
+
+// How long to keep trying to connect to a host that is not reachable yet, such as a machine that is still booting, as a duration. Defaults to `2m`.
+func (o ConnectionPtrOutput) DialTimeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Connection) *string {
+		if v == nil {
+			return nil
+		}
+		return v.DialTimeout
+	}).(pulumi.StringPtrOutput)
+}
+
+// The address of the host
+func (o ConnectionPtrOutput) Host() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Connection) *string {
+		if v == nil {
+			return nil
+		}
+		return &v.Host
+	}).(pulumi.StringPtrOutput)
+}
+
+// Pins the key of the host, as a public key in `authorized_keys` format such as `ssh-ed25519 AAAA...` or as a fingerprint such as `SHA256:...`. Without it, any host key is accepted.
+func (o ConnectionPtrOutput) HostKey() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Connection) *string {
+		if v == nil {
+			return nil
+		}
+		return v.HostKey
+	}).(pulumi.StringPtrOutput)
+}
+
+// The password of the user
+func (o ConnectionPtrOutput) Password() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Connection) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Password
+	}).(pulumi.StringPtrOutput)
+}
+
+// The port of the SSH server. Defaults to 22.
+func (o ConnectionPtrOutput) Port() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v *Connection) *int {
+		if v == nil {
+			return nil
+		}
+		return v.Port
+	}).(pulumi.IntPtrOutput)
+}
+
+// The private key of the user in PEM format
+func (o ConnectionPtrOutput) PrivateKey() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Connection) *string {
+		if v == nil {
+			return nil
+		}
+		return v.PrivateKey
+	}).(pulumi.StringPtrOutput)
+}
+
+// The password of an encrypted `privateKey`
+func (o ConnectionPtrOutput) PrivateKeyPassword() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Connection) *string {
+		if v == nil {
+			return nil
+		}
+		return v.PrivateKeyPassword
+	}).(pulumi.StringPtrOutput)
+}
+
+// A bastion host through which the host is reached
+func (o ConnectionPtrOutput) Proxy() ConnectionPtrOutput {
+	return o.ApplyT(func(v *Connection) *Connection {
+		if v == nil {
+			return nil
+		}
+		return v.Proxy
+	}).(ConnectionPtrOutput)
+}
+
+// The user to log in as. Defaults to `root`.
+func (o ConnectionPtrOutput) User() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Connection) *string {
+		if v == nil {
+			return nil
+		}
+		return v.User
+	}).(pulumi.StringPtrOutput)
+}
+
+// How the ID of a resource is derived. Set exactly one field.
+type IdFrom struct {
+	// The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`.
+	Path *string `pulumi:"path"`
+	// Use the stdout of the create command, without surrounding whitespace
+	Stdout *bool `pulumi:"stdout"`
+	// A static ID
+	Value *string `pulumi:"value"`
+}
+
+// IdFromInput is an input type that accepts IdFromArgs and IdFromOutput values.
+// You can construct a concrete instance of `IdFromInput` via:
+//
+//          IdFromArgs{...}
+type IdFromInput interface {
+	pulumi.Input
+
+	ToIdFromOutput() IdFromOutput
+	ToIdFromOutputWithContext(context.Context) IdFromOutput
+}
+
+// How the ID of a resource is derived. Set exactly one field.
+type IdFromArgs struct {
+	// The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`.
+	Path pulumi.StringPtrInput `pulumi:"path"`
+	// Use the stdout of the create command, without surrounding whitespace
+	Stdout pulumi.BoolPtrInput `pulumi:"stdout"`
+	// A static ID
+	Value pulumi.StringPtrInput `pulumi:"value"`
+}
+
+func (IdFromArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*IdFrom)(nil)).Elem()
+}
+
+func (i IdFromArgs) ToIdFromOutput() IdFromOutput {
+	return i.ToIdFromOutputWithContext(context.Background())
+}
+
+func (i IdFromArgs) ToIdFromOutputWithContext(ctx context.Context) IdFromOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(IdFromOutput)
+}
+
+func (i IdFromArgs) ToIdFromPtrOutput() IdFromPtrOutput {
+	return i.ToIdFromPtrOutputWithContext(context.Background())
+}
+
+func (i IdFromArgs) ToIdFromPtrOutputWithContext(ctx context.Context) IdFromPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(IdFromOutput).ToIdFromPtrOutputWithContext(ctx)
+}
+
+// IdFromPtrInput is an input type that accepts IdFromArgs, IdFromPtr and IdFromPtrOutput values.
+// You can construct a concrete instance of `IdFromPtrInput` via:
+//
+//          IdFromArgs{...}
+//
+//  or:
+//
+//          nil
+type IdFromPtrInput interface {
+	pulumi.Input
+
+	ToIdFromPtrOutput() IdFromPtrOutput
+	ToIdFromPtrOutputWithContext(context.Context) IdFromPtrOutput
+}
+
+type idFromPtrType IdFromArgs
+
+func IdFromPtr(v *IdFromArgs) IdFromPtrInput {
+	return (*idFromPtrType)(v)
+}
+
+func (*idFromPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**IdFrom)(nil)).Elem()
+}
+
+func (i *idFromPtrType) ToIdFromPtrOutput() IdFromPtrOutput {
+	return i.ToIdFromPtrOutputWithContext(context.Background())
+}
+
+func (i *idFromPtrType) ToIdFromPtrOutputWithContext(ctx context.Context) IdFromPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(IdFromPtrOutput)
+}
+
+// How the ID of a resource is derived. Set exactly one field.
+type IdFromOutput struct{ *pulumi.OutputState }
+
+func (IdFromOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*IdFrom)(nil)).Elem()
+}
+
+func (o IdFromOutput) ToIdFromOutput() IdFromOutput {
+	return o
+}
+
+func (o IdFromOutput) ToIdFromOutputWithContext(ctx context.Context) IdFromOutput {
+	return o
+}
+
+func (o IdFromOutput) ToIdFromPtrOutput() IdFromPtrOutput {
+	return o.ToIdFromPtrOutputWithContext(context.Background())
+}
+
+func (o IdFromOutput) ToIdFromPtrOutputWithContext(ctx context.Context) IdFromPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v IdFrom) *IdFrom {
+		return &v
+	}).(IdFromPtrOutput)
+}
+
+// The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`.
+func (o IdFromOutput) Path() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v IdFrom) *string { return v.Path }).(pulumi.StringPtrOutput)
+}
+
+// Use the stdout of the create command, without surrounding whitespace
+func (o IdFromOutput) Stdout() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v IdFrom) *bool { return v.Stdout }).(pulumi.BoolPtrOutput)
+}
+
+// A static ID
+func (o IdFromOutput) Value() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v IdFrom) *string { return v.Value }).(pulumi.StringPtrOutput)
+}
+
+type IdFromPtrOutput struct{ *pulumi.OutputState }
+
+func (IdFromPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**IdFrom)(nil)).Elem()
+}
+
+func (o IdFromPtrOutput) ToIdFromPtrOutput() IdFromPtrOutput {
+	return o
+}
+
+func (o IdFromPtrOutput) ToIdFromPtrOutputWithContext(ctx context.Context) IdFromPtrOutput {
+	return o
+}
+
+func (o IdFromPtrOutput) Elem() IdFromOutput {
+	return o.ApplyT(func(v *IdFrom) IdFrom {
+		if v != nil {
+			return *v
+		}
+		var ret IdFrom
+		return ret
+	}).(IdFromOutput)
+}
+
+// The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`.
+func (o IdFromPtrOutput) Path() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *IdFrom) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Path
+	}).(pulumi.StringPtrOutput)
+}
+
+// Use the stdout of the create command, without surrounding whitespace
+func (o IdFromPtrOutput) Stdout() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *IdFrom) *bool {
+		if v == nil {
+			return nil
+		}
+		return v.Stdout
+	}).(pulumi.BoolPtrOutput)
+}
+
+// A static ID
+func (o IdFromPtrOutput) Value() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *IdFrom) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Value
+	}).(pulumi.StringPtrOutput)
+}
+
+// A container image to run a command in.
+type Image struct {
+	// Paths of the machine running the provider to bind into the container
+	Mounts []Mount `pulumi:"mounts"`
+	// The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+	Name string `pulumi:"name"`
+	// The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+	NetworkMode *string `pulumi:"networkMode"`
+	// The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+	User *string `pulumi:"user"`
+}
+
+// ImageInput is an input type that accepts ImageArgs and ImageOutput values.
+// You can construct a concrete instance of `ImageInput` via:
+//
+//          ImageArgs{...}
+type ImageInput interface {
+	pulumi.Input
+
+	ToImageOutput() ImageOutput
+	ToImageOutputWithContext(context.Context) ImageOutput
+}
+
+// A container image to run a command in.
+type ImageArgs struct {
+	// Paths of the machine running the provider to bind into the container
+	Mounts MountArrayInput `pulumi:"mounts"`
+	// The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+	Name pulumi.StringInput `pulumi:"name"`
+	// The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+	NetworkMode pulumi.StringPtrInput `pulumi:"networkMode"`
+	// The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+	User pulumi.StringPtrInput `pulumi:"user"`
+}
+
+func (ImageArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Image)(nil)).Elem()
+}
+
+func (i ImageArgs) ToImageOutput() ImageOutput {
+	return i.ToImageOutputWithContext(context.Background())
+}
+
+func (i ImageArgs) ToImageOutputWithContext(ctx context.Context) ImageOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ImageOutput)
+}
+
+func (i ImageArgs) ToImagePtrOutput() ImagePtrOutput {
+	return i.ToImagePtrOutputWithContext(context.Background())
+}
+
+func (i ImageArgs) ToImagePtrOutputWithContext(ctx context.Context) ImagePtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ImageOutput).ToImagePtrOutputWithContext(ctx)
+}
+
+// ImagePtrInput is an input type that accepts ImageArgs, ImagePtr and ImagePtrOutput values.
+// You can construct a concrete instance of `ImagePtrInput` via:
+//
+//          ImageArgs{...}
+//
+//  or:
+//
+//          nil
+type ImagePtrInput interface {
+	pulumi.Input
+
+	ToImagePtrOutput() ImagePtrOutput
+	ToImagePtrOutputWithContext(context.Context) ImagePtrOutput
+}
+
+type imagePtrType ImageArgs
+
+func ImagePtr(v *ImageArgs) ImagePtrInput {
+	return (*imagePtrType)(v)
+}
+
+func (*imagePtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**Image)(nil)).Elem()
+}
+
+func (i *imagePtrType) ToImagePtrOutput() ImagePtrOutput {
+	return i.ToImagePtrOutputWithContext(context.Background())
+}
+
+func (i *imagePtrType) ToImagePtrOutputWithContext(ctx context.Context) ImagePtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ImagePtrOutput)
+}
+
+// A container image to run a command in.
+type ImageOutput struct{ *pulumi.OutputState }
+
+func (ImageOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Image)(nil)).Elem()
+}
+
+func (o ImageOutput) ToImageOutput() ImageOutput {
+	return o
+}
+
+func (o ImageOutput) ToImageOutputWithContext(ctx context.Context) ImageOutput {
+	return o
+}
+
+func (o ImageOutput) ToImagePtrOutput() ImagePtrOutput {
+	return o.ToImagePtrOutputWithContext(context.Background())
+}
+
+func (o ImageOutput) ToImagePtrOutputWithContext(ctx context.Context) ImagePtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v Image) *Image {
+		return &v
+	}).(ImagePtrOutput)
+}
+
+// Paths of the machine running the provider to bind into the container
+func (o ImageOutput) Mounts() MountArrayOutput {
+	return o.ApplyT(func(v Image) []Mount { return v.Mounts }).(MountArrayOutput)
+}
+
+// The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+func (o ImageOutput) Name() pulumi.StringOutput {
+	return o.ApplyT(func(v Image) string { return v.Name }).(pulumi.StringOutput)
+}
+
+// The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+func (o ImageOutput) NetworkMode() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Image) *string { return v.NetworkMode }).(pulumi.StringPtrOutput)
+}
+
+// The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+func (o ImageOutput) User() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Image) *string { return v.User }).(pulumi.StringPtrOutput)
+}
+
+type ImagePtrOutput struct{ *pulumi.OutputState }
+
+func (ImagePtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**Image)(nil)).Elem()
+}
+
+func (o ImagePtrOutput) ToImagePtrOutput() ImagePtrOutput {
+	return o
+}
+
+func (o ImagePtrOutput) ToImagePtrOutputWithContext(ctx context.Context) ImagePtrOutput {
+	return o
+}
+
+func (o ImagePtrOutput) Elem() ImageOutput {
+	return o.ApplyT(func(v *Image) Image {
+		if v != nil {
+			return *v
+		}
+		var ret Image
+		return ret
+	}).(ImageOutput)
+}
+
+// Paths of the machine running the provider to bind into the container
+func (o ImagePtrOutput) Mounts() MountArrayOutput {
+	return o.ApplyT(func(v *Image) []Mount {
+		if v == nil {
+			return nil
+		}
+		return v.Mounts
+	}).(MountArrayOutput)
+}
+
+// The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+func (o ImagePtrOutput) Name() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Image) *string {
+		if v == nil {
+			return nil
+		}
+		return &v.Name
+	}).(pulumi.StringPtrOutput)
+}
+
+// The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+func (o ImagePtrOutput) NetworkMode() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Image) *string {
+		if v == nil {
+			return nil
+		}
+		return v.NetworkMode
+	}).(pulumi.StringPtrOutput)
+}
+
+// The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+func (o ImagePtrOutput) User() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Image) *string {
+		if v == nil {
+			return nil
+		}
+		return v.User
+	}).(pulumi.StringPtrOutput)
+}
+
+// A path of the machine running the provider bound into a container.
+type Mount struct {
+	// Whether the container may only read the mount
+	ReadOnly *bool `pulumi:"readOnly"`
+	// The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+	Source string `pulumi:"source"`
+	// The absolute path in the container
+	Target string `pulumi:"target"`
+}
+
+// MountInput is an input type that accepts MountArgs and MountOutput values.
+// You can construct a concrete instance of `MountInput` via:
+//
+//          MountArgs{...}
+type MountInput interface {
+	pulumi.Input
+
+	ToMountOutput() MountOutput
+	ToMountOutputWithContext(context.Context) MountOutput
+}
+
+// A path of the machine running the provider bound into a container.
+type MountArgs struct {
+	// Whether the container may only read the mount
+	ReadOnly pulumi.BoolPtrInput `pulumi:"readOnly"`
+	// The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+	Source pulumi.StringInput `pulumi:"source"`
+	// The absolute path in the container
+	Target pulumi.StringInput `pulumi:"target"`
+}
+
+func (MountArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Mount)(nil)).Elem()
+}
+
+func (i MountArgs) ToMountOutput() MountOutput {
+	return i.ToMountOutputWithContext(context.Background())
+}
+
+func (i MountArgs) ToMountOutputWithContext(ctx context.Context) MountOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(MountOutput)
+}
+
+// MountArrayInput is an input type that accepts MountArray and MountArrayOutput values.
+// You can construct a concrete instance of `MountArrayInput` via:
+//
+//	MountArray{ MountArgs{...} }
+type MountArrayInput interface {
+	pulumi.Input
+
+	ToMountArrayOutput() MountArrayOutput
+	ToMountArrayOutputWithContext(context.Context) MountArrayOutput
+}
+
+type MountArray []MountInput
+
+func (MountArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Mount)(nil)).Elem()
+}
+
+func (i MountArray) ToMountArrayOutput() MountArrayOutput {
+	return i.ToMountArrayOutputWithContext(context.Background())
+}
+
+func (i MountArray) ToMountArrayOutputWithContext(ctx context.Context) MountArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(MountArrayOutput)
+}
+
+// A path of the machine running the provider bound into a container.
+type MountOutput struct{ *pulumi.OutputState }
+
+func (MountOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Mount)(nil)).Elem()
+}
+
+func (o MountOutput) ToMountOutput() MountOutput {
+	return o
+}
+
+func (o MountOutput) ToMountOutputWithContext(ctx context.Context) MountOutput {
+	return o
+}
+
+// Whether the container may only read the mount
+func (o MountOutput) ReadOnly() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v Mount) *bool { return v.ReadOnly }).(pulumi.BoolPtrOutput)
+}
+
+// The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+func (o MountOutput) Source() pulumi.StringOutput {
+	return o.ApplyT(func(v Mount) string { return v.Source }).(pulumi.StringOutput)
+}
+
+// The absolute path in the container
+func (o MountOutput) Target() pulumi.StringOutput {
+	return o.ApplyT(func(v Mount) string { return v.Target }).(pulumi.StringOutput)
+}
+
+type MountArrayOutput struct{ *pulumi.OutputState }
+
+func (MountArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Mount)(nil)).Elem()
+}
+
+func (o MountArrayOutput) ToMountArrayOutput() MountArrayOutput {
+	return o
+}
+
+func (o MountArrayOutput) ToMountArrayOutputWithContext(ctx context.Context) MountArrayOutput {
+	return o
+}
+
+func (o MountArrayOutput) Index(i pulumi.IntInput) MountOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) Mount {
+		return vs[0].([]Mount)[vs[1].(int)]
+	}).(MountOutput)
+}
+
+// How a failing command is retried. Each retry waits `backoffFactor` times longer than the previous one, up to `maxDelay`.
+type Retry struct {
+	// The maximum number of times the command runs, including the first. Defaults to 3.
+	Attempts *int `pulumi:"attempts"`
+	// The factor each delay is multiplied by. Defaults to 2.
+	BackoffFactor *float64 `pulumi:"backoffFactor"`
+	// How long to wait before the first retry, as a duration. Defaults to `1s`.
+	InitialDelay *string `pulumi:"initialDelay"`
+	// The longest wait between attempts, as a duration. Defaults to `30s`.
+	MaxDelay *string `pulumi:"maxDelay"`
+	// Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+	RetryOnExitCodes []int `pulumi:"retryOnExitCodes"`
+	// Only retry when stderr matches this regular expression.
+	RetryOnStderrMatch *string `pulumi:"retryOnStderrMatch"`
+}
+
+// RetryInput is an input type that accepts RetryArgs and RetryOutput values.
+// You can construct a concrete instance of `RetryInput` via:
+//
+//          RetryArgs{...}
+type RetryInput interface {
+	pulumi.Input
+
+	ToRetryOutput() RetryOutput
+	ToRetryOutputWithContext(context.Context) RetryOutput
+}
+
+// How a failing command is retried. Each retry waits `backoffFactor` times longer than the previous one, up to `maxDelay`.
+type RetryArgs struct {
+	// The maximum number of times the command runs, including the first. Defaults to 3.
+	Attempts pulumi.IntPtrInput `pulumi:"attempts"`
+	// The factor each delay is multiplied by. Defaults to 2.
+	BackoffFactor pulumi.Float64PtrInput `pulumi:"backoffFactor"`
+	// How long to wait before the first retry, as a duration. Defaults to `1s`.
+	InitialDelay pulumi.StringPtrInput `pulumi:"initialDelay"`
+	// The longest wait between attempts, as a duration. Defaults to `30s`.
+	MaxDelay pulumi.StringPtrInput `pulumi:"maxDelay"`
+	// Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+	RetryOnExitCodes pulumi.IntArrayInput `pulumi:"retryOnExitCodes"`
+	// Only retry when stderr matches this regular expression.
+	RetryOnStderrMatch pulumi.StringPtrInput `pulumi:"retryOnStderrMatch"`
+}
+
+func (RetryArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Retry)(nil)).Elem()
+}
+
+func (i RetryArgs) ToRetryOutput() RetryOutput {
+	return i.ToRetryOutputWithContext(context.Background())
+}
+
+func (i RetryArgs) ToRetryOutputWithContext(ctx context.Context) RetryOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(RetryOutput)
+}
+
+func (i RetryArgs) ToRetryPtrOutput() RetryPtrOutput {
+	return i.ToRetryPtrOutputWithContext(context.Background())
+}
+
+func (i RetryArgs) ToRetryPtrOutputWithContext(ctx context.Context) RetryPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(RetryOutput).ToRetryPtrOutputWithContext(ctx)
+}
+
+// RetryPtrInput is an input type that accepts RetryArgs, RetryPtr and RetryPtrOutput values.
+// You can construct a concrete instance of `RetryPtrInput` via:
+//
+//          RetryArgs{...}
+//
+//  or:
+//
+//          nil
+type RetryPtrInput interface {
+	pulumi.Input
+
+	ToRetryPtrOutput() RetryPtrOutput
+	ToRetryPtrOutputWithContext(context.Context) RetryPtrOutput
+}
+
+type retryPtrType RetryArgs
+
+func RetryPtr(v *RetryArgs) RetryPtrInput {
+	return (*retryPtrType)(v)
+}
+
+func (*retryPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**Retry)(nil)).Elem()
+}
+
+func (i *retryPtrType) ToRetryPtrOutput() RetryPtrOutput {
+	return i.ToRetryPtrOutputWithContext(context.Background())
+}
+
+func (i *retryPtrType) ToRetryPtrOutputWithContext(ctx context.Context) RetryPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(RetryPtrOutput)
+}
+
+// How a failing command is retried. Each retry waits `backoffFactor` times longer than the previous one, up to `maxDelay`.
+type RetryOutput struct{ *pulumi.OutputState }
+
+func (RetryOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Retry)(nil)).Elem()
+}
+
+func (o RetryOutput) ToRetryOutput() RetryOutput {
+	return o
+}
+
+func (o RetryOutput) ToRetryOutputWithContext(ctx context.Context) RetryOutput {
+	return o
+}
+
+func (o RetryOutput) ToRetryPtrOutput() RetryPtrOutput {
+	return o.ToRetryPtrOutputWithContext(context.Background())
+}
+
+func (o RetryOutput) ToRetryPtrOutputWithContext(ctx context.Context) RetryPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v Retry) *Retry {
+		return &v
+	}).(RetryPtrOutput)
+}
+
+// The maximum number of times the command runs, including the first. Defaults to 3.
+func (o RetryOutput) Attempts() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v Retry) *int { return v.Attempts }).(pulumi.IntPtrOutput)
+}
+
+// The factor each delay is multiplied by. Defaults to 2.
+func (o RetryOutput) BackoffFactor() pulumi.Float64PtrOutput {
+	return o.ApplyT(func(v Retry) *float64 { return v.BackoffFactor }).(pulumi.Float64PtrOutput)
+}
+
+// How long to wait before the first retry, as a duration. Defaults to `1s`.
+func (o RetryOutput) InitialDelay() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Retry) *string { return v.InitialDelay }).(pulumi.StringPtrOutput)
+}
+
+// The longest wait between attempts, as a duration. Defaults to `30s`.
+func (o RetryOutput) MaxDelay() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Retry) *string { return v.MaxDelay }).(pulumi.StringPtrOutput)
+}
+
+// Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+func (o RetryOutput) RetryOnExitCodes() pulumi.IntArrayOutput {
+	return o.ApplyT(func(v Retry) []int { return v.RetryOnExitCodes }).(pulumi.IntArrayOutput)
+}
+
+// Only retry when stderr matches this regular expression.
+func (o RetryOutput) RetryOnStderrMatch() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Retry) *string { return v.RetryOnStderrMatch }).(pulumi.StringPtrOutput)
+}
+
+type RetryPtrOutput struct{ *pulumi.OutputState }
+
+func (RetryPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**Retry)(nil)).Elem()
+}
+
+func (o RetryPtrOutput) ToRetryPtrOutput() RetryPtrOutput {
+	return o
+}
+
+func (o RetryPtrOutput) ToRetryPtrOutputWithContext(ctx context.Context) RetryPtrOutput {
+	return o
+}
+
+func (o RetryPtrOutput) Elem() RetryOutput {
+	return o.ApplyT(func(v *Retry) Retry {
+		if v != nil {
+			return *v
+		}
+		var ret Retry
+		return ret
+	}).(RetryOutput)
+}
+
+// The maximum number of times the command runs, including the first. Defaults to 3.
+func (o RetryPtrOutput) Attempts() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v *Retry) *int {
+		if v == nil {
+			return nil
+		}
+		return v.Attempts
+	}).(pulumi.IntPtrOutput)
+}
+
+// The factor each delay is multiplied by. Defaults to 2.
+func (o RetryPtrOutput) BackoffFactor() pulumi.Float64PtrOutput {
+	return o.ApplyT(func(v *Retry) *float64 {
+		if v == nil {
+			return nil
+		}
+		return v.BackoffFactor
+	}).(pulumi.Float64PtrOutput)
+}
+
+// How long to wait before the first retry, as a duration. Defaults to `1s`.
+func (o RetryPtrOutput) InitialDelay() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Retry) *string {
+		if v == nil {
+			return nil
+		}
+		return v.InitialDelay
+	}).(pulumi.StringPtrOutput)
+}
+
+// The longest wait between attempts, as a duration. Defaults to `30s`.
+func (o RetryPtrOutput) MaxDelay() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Retry) *string {
+		if v == nil {
+			return nil
+		}
+		return v.MaxDelay
+	}).(pulumi.StringPtrOutput)
+}
+
+// Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+func (o RetryPtrOutput) RetryOnExitCodes() pulumi.IntArrayOutput {
+	return o.ApplyT(func(v *Retry) []int {
+		if v == nil {
+			return nil
+		}
+		return v.RetryOnExitCodes
+	}).(pulumi.IntArrayOutput)
+}
+
+// Only retry when stderr matches this regular expression.
+func (o RetryPtrOutput) RetryOnStderrMatch() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Retry) *string {
+		if v == nil {
+			return nil
+		}
+		return v.RetryOnStderrMatch
+	}).(pulumi.StringPtrOutput)
+}
+
+// Restrictions on a command that runs on the machine running the provider. Each process of the command is subject to the limits that are set.
+type Sandbox struct {
+	// The CPU time each process may use, in seconds, after which it is killed
+	CpuSeconds *int `pulumi:"cpuSeconds"`
+	// The number of processes the user of the command may run, including those outside of the sandbox
+	MaxProcesses *int `pulumi:"maxProcesses"`
+	// The size of the address space of each process, in mebibytes
+	MemoryMB *int `pulumi:"memoryMB"`
+	// Keep the network of the provider instead of running the command in an empty network namespace
+	Network *bool `pulumi:"network"`
+	// The number of files each process may have open
+	OpenFiles *int `pulumi:"openFiles"`
+}
+
+// SandboxInput is an input type that accepts SandboxArgs and SandboxOutput values.
+// You can construct a concrete instance of `SandboxInput` via:
+//
+//          SandboxArgs{...}
+type SandboxInput interface {
+	pulumi.Input
+
+	ToSandboxOutput() SandboxOutput
+	ToSandboxOutputWithContext(context.Context) SandboxOutput
+}
+
+// Restrictions on a command that runs on the machine running the provider. Each process of the command is subject to the limits that are set.
+type SandboxArgs struct {
+	// The CPU time each process may use, in seconds, after which it is killed
+	CpuSeconds pulumi.IntPtrInput `pulumi:"cpuSeconds"`
+	// The number of processes the user of the command may run, including those outside of the sandbox
+	MaxProcesses pulumi.IntPtrInput `pulumi:"maxProcesses"`
+	// The size of the address space of each process, in mebibytes
+	MemoryMB pulumi.IntPtrInput `pulumi:"memoryMB"`
+	// Keep the network of the provider instead of running the command in an empty network namespace
+	Network pulumi.BoolPtrInput `pulumi:"network"`
+	// The number of files each process may have open
+	OpenFiles pulumi.IntPtrInput `pulumi:"openFiles"`
+}
+
+func (SandboxArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Sandbox)(nil)).Elem()
+}
+
+func (i SandboxArgs) ToSandboxOutput() SandboxOutput {
+	return i.ToSandboxOutputWithContext(context.Background())
+}
+
+func (i SandboxArgs) ToSandboxOutputWithContext(ctx context.Context) SandboxOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(SandboxOutput)
+}
+
+func (i SandboxArgs) ToSandboxPtrOutput() SandboxPtrOutput {
+	return i.ToSandboxPtrOutputWithContext(context.Background())
+}
+
+func (i SandboxArgs) ToSandboxPtrOutputWithContext(ctx context.Context) SandboxPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(SandboxOutput).ToSandboxPtrOutputWithContext(ctx)
+}
+
+// SandboxPtrInput is an input type that accepts SandboxArgs, SandboxPtr and SandboxPtrOutput values.
+// You can construct a concrete instance of `SandboxPtrInput` via:
+//
+//          SandboxArgs{...}
+//
+//  or:
+//
+//          nil
+type SandboxPtrInput interface {
+	pulumi.Input
+
+	ToSandboxPtrOutput() SandboxPtrOutput
+	ToSandboxPtrOutputWithContext(context.Context) SandboxPtrOutput
+}
+
+type sandboxPtrType SandboxArgs
+
+func SandboxPtr(v *SandboxArgs) SandboxPtrInput {
+	return (*sandboxPtrType)(v)
+}
+
+func (*sandboxPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**Sandbox)(nil)).Elem()
+}
+
+func (i *sandboxPtrType) ToSandboxPtrOutput() SandboxPtrOutput {
+	return i.ToSandboxPtrOutputWithContext(context.Background())
+}
+
+func (i *sandboxPtrType) ToSandboxPtrOutputWithContext(ctx context.Context) SandboxPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(SandboxPtrOutput)
+}
+
+// Restrictions on a command that runs on the machine running the provider. Each process of the command is subject to the limits that are set.
+type SandboxOutput struct{ *pulumi.OutputState }
+
+func (SandboxOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Sandbox)(nil)).Elem()
+}
+
+func (o SandboxOutput) ToSandboxOutput() SandboxOutput {
+	return o
+}
+
+func (o SandboxOutput) ToSandboxOutputWithContext(ctx context.Context) SandboxOutput {
+	return o
+}
+
+func (o SandboxOutput) ToSandboxPtrOutput() SandboxPtrOutput {
+	return o.ToSandboxPtrOutputWithContext(context.Background())
+}
+
+func (o SandboxOutput) ToSandboxPtrOutputWithContext(ctx context.Context) SandboxPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v Sandbox) *Sandbox {
+		return &v
+	}).(SandboxPtrOutput)
+}
+
+// The CPU time each process may use, in seconds, after which it is killed
+func (o SandboxOutput) CpuSeconds() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v Sandbox) *int { return v.CpuSeconds }).(pulumi.IntPtrOutput)
+}
+
+// The number of processes the user of the command may run, including those outside of the sandbox
+func (o SandboxOutput) MaxProcesses() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v Sandbox) *int { return v.MaxProcesses }).(pulumi.IntPtrOutput)
+}
+
+// The size of the address space of each process, in mebibytes
+func (o SandboxOutput) MemoryMB() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v Sandbox) *int { return v.MemoryMB }).(pulumi.IntPtrOutput)
+}
+
+// Keep the network of the provider instead of running the command in an empty network namespace
+func (o SandboxOutput) Network() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v Sandbox) *bool { return v.Network }).(pulumi.BoolPtrOutput)
+}
+
+// The number of files each process may have open
+func (o SandboxOutput) OpenFiles() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v Sandbox) *int { return v.OpenFiles }).(pulumi.IntPtrOutput)
+}
+
+type SandboxPtrOutput struct{ *pulumi.OutputState }
+
+func (SandboxPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**Sandbox)(nil)).Elem()
+}
+
+func (o SandboxPtrOutput) ToSandboxPtrOutput() SandboxPtrOutput {
+	return o
+}
+
+func (o SandboxPtrOutput) ToSandboxPtrOutputWithContext(ctx context.Context) SandboxPtrOutput {
+	return o
+}
+
+func (o SandboxPtrOutput) Elem() SandboxOutput {
+	return o.ApplyT(func(v *Sandbox) Sandbox {
+		if v != nil {
+			return *v
+		}
+		var ret Sandbox
+		return ret
+	}).(SandboxOutput)
+}
+
+// The CPU time each process may use, in seconds, after which it is killed
+func (o SandboxPtrOutput) CpuSeconds() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v *Sandbox) *int {
+		if v == nil {
+			return nil
+		}
+		return v.CpuSeconds
+	}).(pulumi.IntPtrOutput)
+}
+
+// The number of processes the user of the command may run, including those outside of the sandbox
+func (o SandboxPtrOutput) MaxProcesses() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v *Sandbox) *int {
+		if v == nil {
+			return nil
+		}
+		return v.MaxProcesses
+	}).(pulumi.IntPtrOutput)
+}
+
+// The size of the address space of each process, in mebibytes
+func (o SandboxPtrOutput) MemoryMB() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v *Sandbox) *int {
+		if v == nil {
+			return nil
+		}
+		return v.MemoryMB
+	}).(pulumi.IntPtrOutput)
+}
+
+// Keep the network of the provider instead of running the command in an empty network namespace
+func (o SandboxPtrOutput) Network() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *Sandbox) *bool {
+		if v == nil {
+			return nil
+		}
+		return v.Network
+	}).(pulumi.BoolPtrOutput)
+}
+
+// The number of files each process may have open
+func (o SandboxPtrOutput) OpenFiles() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v *Sandbox) *int {
+		if v == nil {
+			return nil
+		}
+		return v.OpenFiles
+	}).(pulumi.IntPtrOutput)
+}
+
+// A probe that runs after create and update until the resource is ready. The resource is ready when the probe exits with `exitCode`, prints stdout matching `stdoutMatch`, or prints JSON whose value at `jsonPath` equals `equals`. Without a criterion the probe must exit with 0.
+type WaitFor struct {
+	// The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`.
+	Equals *string `pulumi:"equals"`
+	// The exit code of the probe when the resource is ready
+	ExitCode *int `pulumi:"exitCode"`
+	// The time between probes, as a duration. Defaults to `5s`.
+	Interval *string `pulumi:"interval"`
+	// The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`.
+	JsonPath *string `pulumi:"jsonPath"`
+	// The command that checks whether the resource is ready
+	Probe Cmd `pulumi:"probe"`
+	// A regular expression that the stdout of the probe matches when the resource is ready
+	StdoutMatch *string `pulumi:"stdoutMatch"`
+	// How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`.
+	Timeout *string `pulumi:"timeout"`
+}
+
+// WaitForInput is an input type that accepts WaitForArgs and WaitForOutput values.
+// You can construct a concrete instance of `WaitForInput` via:
+//
+//          WaitForArgs{...}
+type WaitForInput interface {
+	pulumi.Input
+
+	ToWaitForOutput() WaitForOutput
+	ToWaitForOutputWithContext(context.Context) WaitForOutput
+}
+
+// A probe that runs after create and update until the resource is ready. The resource is ready when the probe exits with `exitCode`, prints stdout matching `stdoutMatch`, or prints JSON whose value at `jsonPath` equals `equals`. Without a criterion the probe must exit with 0.
+type WaitForArgs struct {
+	// The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`.
+	Equals pulumi.StringPtrInput `pulumi:"equals"`
+	// The exit code of the probe when the resource is ready
+	ExitCode pulumi.IntPtrInput `pulumi:"exitCode"`
+	// The time between probes, as a duration. Defaults to `5s`.
+	Interval pulumi.StringPtrInput `pulumi:"interval"`
+	// The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`.
+	JsonPath pulumi.StringPtrInput `pulumi:"jsonPath"`
+	// The command that checks whether the resource is ready
+	Probe CmdInput `pulumi:"probe"`
+	// A regular expression that the stdout of the probe matches when the resource is ready
+	StdoutMatch pulumi.StringPtrInput `pulumi:"stdoutMatch"`
+	// How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`.
+	Timeout pulumi.StringPtrInput `pulumi:"timeout"`
+}
+
+func (WaitForArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*WaitFor)(nil)).Elem()
+}
+
+func (i WaitForArgs) ToWaitForOutput() WaitForOutput {
+	return i.ToWaitForOutputWithContext(context.Background())
+}
+
+func (i WaitForArgs) ToWaitForOutputWithContext(ctx context.Context) WaitForOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(WaitForOutput)
+}
+
+func (i WaitForArgs) ToWaitForPtrOutput() WaitForPtrOutput {
+	return i.ToWaitForPtrOutputWithContext(context.Background())
+}
+
+func (i WaitForArgs) ToWaitForPtrOutputWithContext(ctx context.Context) WaitForPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(WaitForOutput).ToWaitForPtrOutputWithContext(ctx)
+}
+
+// WaitForPtrInput is an input type that accepts WaitForArgs, WaitForPtr and WaitForPtrOutput values.
+// You can construct a concrete instance of `WaitForPtrInput` via:
+//
+//          WaitForArgs{...}
+//
+//  or:
+//
+//          nil
+type WaitForPtrInput interface {
+	pulumi.Input
+
+	ToWaitForPtrOutput() WaitForPtrOutput
+	ToWaitForPtrOutputWithContext(context.Context) WaitForPtrOutput
+}
+
+type waitForPtrType WaitForArgs
+
+func WaitForPtr(v *WaitForArgs) WaitForPtrInput {
+	return (*waitForPtrType)(v)
+}
+
+func (*waitForPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**WaitFor)(nil)).Elem()
+}
+
+func (i *waitForPtrType) ToWaitForPtrOutput() WaitForPtrOutput {
+	return i.ToWaitForPtrOutputWithContext(context.Background())
+}
+
+func (i *waitForPtrType) ToWaitForPtrOutputWithContext(ctx context.Context) WaitForPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(WaitForPtrOutput)
+}
+
+// A probe that runs after create and update until the resource is ready. The resource is ready when the probe exits with `exitCode`, prints stdout matching `stdoutMatch`, or prints JSON whose value at `jsonPath` equals `equals`. Without a criterion the probe must exit with 0.
+type WaitForOutput struct{ *pulumi.OutputState }
+
+func (WaitForOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*WaitFor)(nil)).Elem()
+}
+
+func (o WaitForOutput) ToWaitForOutput() WaitForOutput {
+	return o
+}
+
+func (o WaitForOutput) ToWaitForOutputWithContext(ctx context.Context) WaitForOutput {
+	return o
+}
+
+func (o WaitForOutput) ToWaitForPtrOutput() WaitForPtrOutput {
+	return o.ToWaitForPtrOutputWithContext(context.Background())
+}
+
+func (o WaitForOutput) ToWaitForPtrOutputWithContext(ctx context.Context) WaitForPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v WaitFor) *WaitFor {
+		return &v
+	}).(WaitForPtrOutput)
+}
+
+// The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`.
+func (o WaitForOutput) Equals() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v WaitFor) *string { return v.Equals }).(pulumi.StringPtrOutput)
+}
+
+// The exit code of the probe when the resource is ready
+func (o WaitForOutput) ExitCode() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v WaitFor) *int { return v.ExitCode }).(pulumi.IntPtrOutput)
+}
+
+// The time between probes, as a duration. Defaults to `5s`.
+func (o WaitForOutput) Interval() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v WaitFor) *string { return v.Interval }).(pulumi.StringPtrOutput)
+}
+
+// The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`.
+func (o WaitForOutput) JsonPath() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v WaitFor) *string { return v.JsonPath }).(pulumi.StringPtrOutput)
+}
+
+// The command that checks whether the resource is ready
+func (o WaitForOutput) Probe() CmdOutput {
+	return o.ApplyT(func(v WaitFor) Cmd { return v.Probe }).(CmdOutput)
+}
+
+// A regular expression that the stdout of the probe matches when the resource is ready
+func (o WaitForOutput) StdoutMatch() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v WaitFor) *string { return v.StdoutMatch }).(pulumi.StringPtrOutput)
+}
+
+// How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`.
+func (o WaitForOutput) Timeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v WaitFor) *string { return v.Timeout }).(pulumi.StringPtrOutput)
+}
+
+type WaitForPtrOutput struct{ *pulumi.OutputState }
+
+func (WaitForPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**WaitFor)(nil)).Elem()
+}
+
+func (o WaitForPtrOutput) ToWaitForPtrOutput() WaitForPtrOutput {
+	return o
+}
+
+func (o WaitForPtrOutput) ToWaitForPtrOutputWithContext(ctx context.Context) WaitForPtrOutput {
+	return o
+}
+
+func (o WaitForPtrOutput) Elem() WaitForOutput {
+	return o.ApplyT(func(v *WaitFor) WaitFor {
+		if v != nil {
+			return *v
+		}
+		var ret WaitFor
+		return ret
+	}).(WaitForOutput)
+}
+
+// The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`.
+func (o WaitForPtrOutput) Equals() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *WaitFor) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Equals
+	}).(pulumi.StringPtrOutput)
+}
+
+// The exit code of the probe when the resource is ready
+func (o WaitForPtrOutput) ExitCode() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v *WaitFor) *int {
+		if v == nil {
+			return nil
+		}
+		return v.ExitCode
+	}).(pulumi.IntPtrOutput)
+}
+
+// The time between probes, as a duration. Defaults to `5s`.
+func (o WaitForPtrOutput) Interval() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *WaitFor) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Interval
+	}).(pulumi.StringPtrOutput)
+}
+
+// The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`.
+func (o WaitForPtrOutput) JsonPath() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *WaitFor) *string {
+		if v == nil {
+			return nil
+		}
+		return v.JsonPath
+	}).(pulumi.StringPtrOutput)
+}
+
+// The command that checks whether the resource is ready
+func (o WaitForPtrOutput) Probe() CmdPtrOutput {
+	return o.ApplyT(func(v *WaitFor) *Cmd {
+		if v == nil {
+			return nil
+		}
+		return &v.Probe
+	}).(CmdPtrOutput)
+}
+
+// A regular expression that the stdout of the probe matches when the resource is ready
+func (o WaitForPtrOutput) StdoutMatch() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *WaitFor) *string {
+		if v == nil {
+			return nil
+		}
+		return v.StdoutMatch
+	}).(pulumi.StringPtrOutput)
+}
+
+// How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`.
+func (o WaitForPtrOutput) Timeout() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *WaitFor) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Timeout
+	}).(pulumi.StringPtrOutput)
+}
+
 func init() {
 	pulumi.RegisterOutputType(CmdOutput{})
 	pulumi.RegisterOutputType(CmdPtrOutput{})
+	pulumi.RegisterOutputType(ConnectionOutput{})
+	pulumi.RegisterOutputType(ConnectionPtrOutput{})
+	pulumi.RegisterOutputType(IdFromOutput{})
+	pulumi.RegisterOutputType(IdFromPtrOutput{})
+	pulumi.RegisterOutputType(ImageOutput{})
+	pulumi.RegisterOutputType(ImagePtrOutput{})
+	pulumi.RegisterOutputType(MountOutput{})
+	pulumi.RegisterOutputType(MountArrayOutput{})
+	pulumi.RegisterOutputType(RetryOutput{})
+	pulumi.RegisterOutputType(RetryPtrOutput{})
+	pulumi.RegisterOutputType(SandboxOutput{})
+	pulumi.RegisterOutputType(SandboxPtrOutput{})
+	pulumi.RegisterOutputType(WaitForOutput{})
+	pulumi.RegisterOutputType(WaitForPtrOutput{})
 }
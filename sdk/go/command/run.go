@@ -0,0 +1,75 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+package command
+
+import (
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Run a command and return its output. Use this to look up data without creating a resource. The command runs whenever the program runs, including during previews, so it should not have side effects.
+func Run(ctx *pulumi.Context, args *RunArgs, opts ...pulumi.InvokeOption) (*RunResult, error) {
+	var rv RunResult
+	err := ctx.Invoke("command:v1:run", args, &rv, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+// The command to run
+type RunArgs struct {
+	// Non-zero exit codes that are treated as success.
+	AllowedExitCodes []int `pulumi:"allowedExitCodes"`
+	// Specifiy the command to run as an array of arguments. Set either `command` or `script`.
+	Command []string `pulumi:"command"`
+	// The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+	Dir *string `pulumi:"dir"`
+	// The names of the variables to inherit when `inheritEnv` is `allowlist`.
+	EnvAllowlist []string          `pulumi:"envAllowlist"`
+	Environment  map[string]string `pulumi:"environment"`
+	// Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+	Image *Image `pulumi:"image"`
+	// Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+	//
+	// The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+	InheritEnv *string `pulumi:"inheritEnv"`
+	// The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+	Interpreter *string `pulumi:"interpreter"`
+	// Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+	OutputFormat *string `pulumi:"outputFormat"`
+	// Retry the command when it fails. Timeouts and cancellation are not retried.
+	Retry *Retry `pulumi:"retry"`
+	// Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+	Sandbox *Sandbox `pulumi:"sandbox"`
+	// A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+	Script *string `pulumi:"script"`
+	// Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+	ScriptFile *bool `pulumi:"scriptFile"`
+	// Pass the stdin to a command
+	Stdin *string `pulumi:"stdin"`
+	// How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+	StopGracePeriod *string `pulumi:"stopGracePeriod"`
+	// The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+	StopSignal *string `pulumi:"stopSignal"`
+	// The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+	Timeout *string `pulumi:"timeout"`
+	// The names of inherited variables to remove from the command's environment.
+	UnsetEnv []string `pulumi:"unsetEnv"`
+}
+
+// The result of running a command
+type RunResult struct {
+	// The number of times the command ran, including retries
+	Attempts *int `pulumi:"attempts"`
+	// exit code of the command
+	ExitCode int `pulumi:"exitCode"`
+	// The digest of the image the command ran in, when it has an `image`
+	ImageDigest *string `pulumi:"imageDigest"`
+	// stdout of the command decoded according to its `outputFormat`
+	Parsed interface{} `pulumi:"parsed"`
+	// stderr of the command
+	Stderr string `pulumi:"stderr"`
+	// stdout of the command
+	Stdout string `pulumi:"stdout"`
+}
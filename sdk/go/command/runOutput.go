@@ -0,0 +1,117 @@
+// This file is not generated. pulumi-gen-command copies it into the package alongside the generated code.
+
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// RunOutput is the output form of Run. It accepts inputs and runs the command once they are known.
+func RunOutput(ctx *pulumi.Context, args RunOutputArgs, opts ...pulumi.InvokeOption) RunResultOutput {
+	return pulumi.ToOutputWithContext(context.Background(), args).
+		ApplyT(func(v interface{}) (RunResult, error) {
+			args := v.(RunArgs)
+			r, err := Run(ctx, &args, opts...)
+			if err != nil {
+				return RunResult{}, err
+			}
+			return *r, nil
+		}).(RunResultOutput)
+}
+
+// The command to run
+type RunOutputArgs struct {
+	// Non-zero exit codes that are treated as success.
+	AllowedExitCodes pulumi.IntArrayInput `pulumi:"allowedExitCodes"`
+	// Specifiy the command to run as an array of arguments. Set either `command` or `script`.
+	Command pulumi.StringArrayInput `pulumi:"command"`
+	// The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+	Dir pulumi.StringPtrInput `pulumi:"dir"`
+	// The names of the variables to inherit when `inheritEnv` is `allowlist`.
+	EnvAllowlist pulumi.StringArrayInput `pulumi:"envAllowlist"`
+	Environment  pulumi.StringMapInput   `pulumi:"environment"`
+	// Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+	Image ImagePtrInput `pulumi:"image"`
+	// Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+	//
+	// The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+	InheritEnv pulumi.StringPtrInput `pulumi:"inheritEnv"`
+	// The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+	Interpreter pulumi.StringPtrInput `pulumi:"interpreter"`
+	// Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+	OutputFormat pulumi.StringPtrInput `pulumi:"outputFormat"`
+	// Retry the command when it fails. Timeouts and cancellation are not retried.
+	Retry RetryPtrInput `pulumi:"retry"`
+	// Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+	Sandbox SandboxPtrInput `pulumi:"sandbox"`
+	// A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+	Script pulumi.StringPtrInput `pulumi:"script"`
+	// Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+	ScriptFile pulumi.BoolPtrInput `pulumi:"scriptFile"`
+	// Pass the stdin to a command
+	Stdin pulumi.StringPtrInput `pulumi:"stdin"`
+	// How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+	StopGracePeriod pulumi.StringPtrInput `pulumi:"stopGracePeriod"`
+	// The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+	StopSignal pulumi.StringPtrInput `pulumi:"stopSignal"`
+	// The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+	Timeout pulumi.StringPtrInput `pulumi:"timeout"`
+	// The names of inherited variables to remove from the command's environment.
+	UnsetEnv pulumi.StringArrayInput `pulumi:"unsetEnv"`
+}
+
+func (RunOutputArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*RunArgs)(nil)).Elem()
+}
+
+// The result of running a command
+type RunResultOutput struct{ *pulumi.OutputState }
+
+func (RunResultOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*RunResult)(nil)).Elem()
+}
+
+func (o RunResultOutput) ToRunResultOutput() RunResultOutput {
+	return o
+}
+
+func (o RunResultOutput) ToRunResultOutputWithContext(ctx context.Context) RunResultOutput {
+	return o
+}
+
+// The number of times the command ran, including retries
+func (o RunResultOutput) Attempts() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v RunResult) *int { return v.Attempts }).(pulumi.IntPtrOutput)
+}
+
+// exit code of the command
+func (o RunResultOutput) ExitCode() pulumi.IntOutput {
+	return o.ApplyT(func(v RunResult) int { return v.ExitCode }).(pulumi.IntOutput)
+}
+
+// The digest of the image the command ran in, when it has an `image`
+func (o RunResultOutput) ImageDigest() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v RunResult) *string { return v.ImageDigest }).(pulumi.StringPtrOutput)
+}
+
+// stdout of the command decoded according to its `outputFormat`
+func (o RunResultOutput) Parsed() pulumi.AnyOutput {
+	return o.ApplyT(func(v RunResult) interface{} { return v.Parsed }).(pulumi.AnyOutput)
+}
+
+// stderr of the command
+func (o RunResultOutput) Stderr() pulumi.StringOutput {
+	return o.ApplyT(func(v RunResult) string { return v.Stderr }).(pulumi.StringOutput)
+}
+
+// stdout of the command
+func (o RunResultOutput) Stdout() pulumi.StringOutput {
+	return o.ApplyT(func(v RunResult) string { return v.Stdout }).(pulumi.StringOutput)
+}
+
+func init() {
+	pulumi.RegisterOutputType(RunResultOutput{})
+}
@@ -24,12 +24,143 @@ function hash(input: any) {
 }
 
 export interface Cmd {
-  /** Specifiy the command to run as an array of arguments */
-  command: pulumi.Input<string[]>
+  /** Specifiy the command to run as an array of arguments. Set either `command` or `script`. */
+  command?: pulumi.Input<string[]>
+  /** A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`. */
+  script?: pulumi.Input<string>
+  /** The interpreter of `script`. Defaults to `sh`. */
+  interpreter?: pulumi.Input<'sh' | 'bash' | 'python3' | 'pwsh'>
+  /** Run `script` from a private temporary file instead of passing it as an argument.
+   * A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers. */
+  scriptFile?: pulumi.Input<boolean>
   /** Pass the stdin to a command */
   stdin?: pulumi.Input<string>
   /** Set environment variables for the running command */
   environment?: pulumi.Input<Record<string, string>>
+  /** The working directory to run the command in. Relative paths are resolved against the Pulumi project root. */
+  dir?: pulumi.Input<string>
+  /** Controls which variables of the provider's environment are passed to the command.
+   * One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+   *
+   * The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence. */
+  inheritEnv?: pulumi.Input<'all' | 'allowlist' | 'none'>
+  /** The names of the variables to inherit when `inheritEnv` is `allowlist`. */
+  envAllowlist?: pulumi.Input<string[]>
+  /** The names of inherited variables to remove from the command's environment. */
+  unsetEnv?: pulumi.Input<string[]>
+  /** The maximum time the command may run, as a duration such as `30s` or `5m`.
+   * When the resource's `customTimeouts` are shorter, they apply instead.
+   * On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`. */
+  timeout?: pulumi.Input<string>
+  /** The signal sent to the command's process group when it times out or the deployment is cancelled.
+   * One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed. */
+  stopSignal?: pulumi.Input<string>
+  /** How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`. */
+  stopGracePeriod?: pulumi.Input<string>
+  /** Non-zero exit codes that are treated as success. */
+  allowedExitCodes?: pulumi.Input<number[]>
+  /** Decode stdout into the `parsed` output.
+   * `dotenv` produces an object of `KEY=VALUE` lines and `lines` an array of lines.
+   * The command fails if stdout cannot be parsed. */
+  outputFormat?: pulumi.Input<'text' | 'json' | 'yaml' | 'dotenv' | 'lines'>
+  /** Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`.
+   * Not available to the create command. */
+  stdinFrom?: pulumi.Input<'previousState'>
+  /** Retry the command when it fails. Timeouts and cancellation are not retried. */
+  retry?: pulumi.Input<Retry>
+  /** Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`,
+   * which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container.
+   * The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect.
+   * Not supported with `scriptFile` or by RemoteCommand. */
+  image?: pulumi.Input<Image>
+  /** Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`,
+   * has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set.
+   * Not supported with `image` or by RemoteCommand. */
+  sandbox?: pulumi.Input<Sandbox>
+}
+
+/** Restrictions on a command that runs on the machine running the provider. Each process of the command is subject to the limits that are set. */
+export interface Sandbox {
+  /** The CPU time each process may use, in seconds, after which it is killed */
+  cpuSeconds?: pulumi.Input<number>
+  /** The size of the address space of each process, in mebibytes */
+  memoryMB?: pulumi.Input<number>
+  /** The number of files each process may have open */
+  openFiles?: pulumi.Input<number>
+  /** The number of processes the user of the command may run, including those outside of the sandbox */
+  maxProcesses?: pulumi.Input<number>
+  /** Keep the network of the provider instead of running the command in an empty network namespace */
+  network?: pulumi.Input<boolean>
+}
+
+/** A container image to run a command in. */
+export interface Image {
+  /** The image reference, such as `alpine:3.14` or `alpine@sha256:...`.
+   * The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource. */
+  name: pulumi.Input<string>
+  /** Paths of the machine running the provider to bind into the container */
+  mounts?: pulumi.Input<pulumi.Input<Mount>[]>
+  /** The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker. */
+  networkMode?: pulumi.Input<string>
+  /** The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image. */
+  user?: pulumi.Input<string>
+}
+
+/** A path of the machine running the provider bound into a container. */
+export interface Mount {
+  /** The path on the machine running the provider. Relative paths are resolved against the Pulumi project root. */
+  source: pulumi.Input<string>
+  /** The absolute path in the container */
+  target: pulumi.Input<string>
+  /** Whether the container may only read the mount */
+  readOnly?: pulumi.Input<boolean>
+}
+
+/** How a failing command is retried. Each retry waits `backoffFactor` times longer than the previous one, up to `maxDelay`. */
+export interface Retry {
+  /** The maximum number of times the command runs, including the first. Defaults to 3. */
+  attempts?: pulumi.Input<number>
+  /** How long to wait before the first retry, as a duration. Defaults to `1s`. */
+  initialDelay?: pulumi.Input<string>
+  /** The longest wait between attempts, as a duration. Defaults to `30s`. */
+  maxDelay?: pulumi.Input<string>
+  /** The factor each delay is multiplied by. Defaults to 2. */
+  backoffFactor?: pulumi.Input<number>
+  /** Only retry when the command exits with one of these codes. Defaults to any failing exit code. */
+  retryOnExitCodes?: pulumi.Input<number[]>
+  /** Only retry when stderr matches this regular expression. */
+  retryOnStderrMatch?: pulumi.Input<string>
+}
+
+/** How the ID of a resource is derived. Set exactly one field. */
+export interface IdFrom {
+  /** A static ID */
+  value?: pulumi.Input<string>
+  /** Use the stdout of the create command, without surrounding whitespace */
+  stdout?: pulumi.Input<boolean>
+  /** The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`. */
+  path?: pulumi.Input<string>
+}
+
+/** A probe that runs after create and update until the resource is ready.
+ *
+ * The resource is ready when the probe exits with `exitCode`, prints stdout matching `stdoutMatch`,
+ * or prints JSON whose value at `jsonPath` equals `equals`. Without a criterion the probe must exit with 0. */
+export interface WaitFor {
+  /** The command that checks whether the resource is ready */
+  probe: pulumi.Input<Cmd> | string[]
+  /** The time between probes, as a duration. Defaults to `5s`. */
+  interval?: pulumi.Input<string>
+  /** How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`. */
+  timeout?: pulumi.Input<string>
+  /** The exit code of the probe when the resource is ready */
+  exitCode?: pulumi.Input<number>
+  /** A regular expression that the stdout of the probe matches when the resource is ready */
+  stdoutMatch?: pulumi.Input<string>
+  /** The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`. */
+  jsonPath?: pulumi.Input<string>
+  /** The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`. */
+  equals?: pulumi.Input<string>
 }
 
 export interface CommandSet {
@@ -48,6 +179,20 @@ export interface CommandSet {
   update?: pulumi.Input<Cmd> | string[]
   /** Define a command to delete the resource. If unspecified, a delete operation is a no-op. */
   delete?: pulumi.Input<Cmd> | string[]
+  /** The output the `read` command prints when the resource has not drifted. Defaults to the stdout of the last create or update. */
+  expected?: pulumi.Input<string>
+  /** How the ID of the resource is derived. Defaults to the static ID `id`. Changing it replaces the resource. */
+  idFrom?: pulumi.Input<IdFrom>
+  /** Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time. */
+  waitFor?: pulumi.Input<WaitFor>
+  /** The outputs to mark as secret. Secret inputs are always stored as secrets and redacted from logged output. */
+  secretOutputs?: pulumi.Input<('stdout' | 'stderr' | 'parsed')[]>
+  /** The inputs whose changes replace the resource instead of updating it. */
+  replaceOn?: pulumi.Input<
+    ('compare' | 'create' | 'read' | 'update' | 'delete' | 'diff')[]
+  >
+  /** Whether the resource is deleted before its replacement is created. Defaults to true. */
+  deleteBeforeReplace?: pulumi.Input<boolean>
 }
 
 // fix unifies schema passed to the provider allowing for convenience array support
@@ -55,6 +200,48 @@ function fix(item: any) {
   return item ? (Array.isArray(item) ? { command: item } : item) : undefined
 }
 
+// fixWaitFor applies the convenience array support of fix to the probe of a waitFor block
+function fixWaitFor(waitFor: pulumi.Input<WaitFor> | undefined) {
+  return waitFor
+    ? pulumi.output(waitFor).apply((w) => ({ ...w, probe: fix(w.probe) }))
+    : undefined
+}
+
+/** The result of running a command */
+export interface RunResult {
+  /** stdout of the command */
+  readonly stdout: string
+  /** stderr of the command */
+  readonly stderr: string
+  /** exit code of the command */
+  readonly exitCode: number
+  /** stdout of the command decoded according to its `outputFormat` */
+  readonly parsed?: any
+  /** The number of times the command ran, including retries */
+  readonly attempts?: number
+  /** The digest of the image the command ran in, when it has an `image` */
+  readonly imageDigest?: string
+}
+
+/** Run a command and return its output. Use this to look up data without creating a resource.
+ *
+ * The command runs whenever the program runs, including during previews, so it should not have side effects.
+ */
+export function run(
+  args: Cmd | string[],
+  opts?: pulumi.InvokeOptions
+): Promise<RunResult> {
+  return pulumi.runtime.invoke('command:v1:run', fix(args), opts)
+}
+
+/** The output form of `run`. The command runs once its inputs are known. */
+export function runOutput(
+  args: pulumi.Input<Cmd> | string[],
+  opts?: pulumi.InvokeOptions
+): pulumi.Output<RunResult> {
+  return pulumi.output(args).apply((a) => run(a as Cmd | string[], opts))
+}
+
 /** Execute a Command and save it as a resource.
  *
  * Each command can be specified as an object or a convenience array.
@@ -64,10 +251,32 @@ function fix(item: any) {
  * An update will occur in these cases:
  * 1. The `compare` hash or the `update` arguments change.
  * 2. The specified `diff` command exits with an error.
+ *
+ * A change to an input listed in `replaceOn` replaces the resource instead.
  */
 export class Command extends pulumi.CustomResource {
   public readonly stdout: pulumi.Output<string>
   public readonly stderr: pulumi.Output<string>
+  /** exit code of the command */
+  public readonly exitCode: pulumi.Output<number>
+  /** The time the command was started, in RFC 3339 format */
+  public readonly startedAt: pulumi.Output<string>
+  /** The time the command exited, in RFC 3339 format */
+  public readonly finishedAt: pulumi.Output<string>
+  /** How long the command ran, in milliseconds */
+  public readonly durationMs: pulumi.Output<number>
+  /** The number of times the last command ran, including retries */
+  public readonly attempts: pulumi.Output<number>
+  /** stdout of the command decoded according to its `outputFormat` */
+  public readonly parsed: pulumi.Output<any>
+  /** The SHA-256 hash of the script of the last create or update, when it is a `script` */
+  public readonly scriptHash: pulumi.Output<string | undefined>
+  /** The digest of the image the last command ran in, when it has an `image` */
+  public readonly imageDigest: pulumi.Output<string | undefined>
+  /** stdout of the `read` command during the last refresh */
+  public readonly readStdout: pulumi.Output<string | undefined>
+  /** Whether the output of the `read` command differed from the expected output during the last refresh */
+  public readonly drifted: pulumi.Output<boolean | undefined>
 
   constructor(
     name: string,
@@ -80,9 +289,25 @@ export class Command extends pulumi.CustomResource {
       update: fix(args.update),
       delete: fix(args.delete),
       diff: fix(args.diff),
+      expected: args.expected,
+      idFrom: args.idFrom,
+      waitFor: fixWaitFor(args.waitFor),
+      secretOutputs: args.secretOutputs,
+      replaceOn: args.replaceOn,
+      deleteBeforeReplace: args.deleteBeforeReplace,
     }
     ;(inputs as any).stdout = undefined /* out */
     ;(inputs as any).stderr = undefined /* out */
+    ;(inputs as any).exitCode = undefined /* out */
+    ;(inputs as any).startedAt = undefined /* out */
+    ;(inputs as any).finishedAt = undefined /* out */
+    ;(inputs as any).durationMs = undefined /* out */
+    ;(inputs as any).attempts = undefined /* out */
+    ;(inputs as any).parsed = undefined /* out */
+    ;(inputs as any).scriptHash = undefined /* out */
+    ;(inputs as any).imageDigest = undefined /* out */
+    ;(inputs as any).readStdout = undefined /* out */
+    ;(inputs as any).drifted = undefined /* out */
     if (typeof args.update === 'undefined') {
       inputs.update = args.create
     }
@@ -102,3 +327,285 @@ export class Command extends pulumi.CustomResource {
     super('command:v1:exec', name, inputs, opts)
   }
 }
+
+export interface LocalFileArgs {
+  /** The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file. */
+  path: pulumi.Input<string>
+  /** The content of the file. Set either `content` or `contentBase64`. */
+  content?: pulumi.Input<string>
+  /** The base64-encoded content of a binary file */
+  contentBase64?: pulumi.Input<string>
+  /** The permission bits of the file in octal, such as `0600`. Defaults to `0644`. */
+  mode?: pulumi.Input<string>
+  /** The user that owns the file, optionally followed by a colon and its group, such as `www-data:adm`. */
+  owner?: pulumi.Input<string>
+}
+
+/** A file on the machine running Pulumi.
+ *
+ * The file is written atomically and compared by the SHA-256 hash of its content, so it is only rewritten when its content, mode or owner change.
+ * A refresh detects changes made outside of Pulumi and the next update restores the file.
+ * The ID of the resource is its path, which can be used to import an existing file.
+ */
+export class LocalFile extends pulumi.CustomResource {
+  public readonly path: pulumi.Output<string>
+  public readonly content: pulumi.Output<string | undefined>
+  public readonly contentBase64: pulumi.Output<string | undefined>
+  public readonly mode: pulumi.Output<string>
+  public readonly owner: pulumi.Output<string | undefined>
+  /** The SHA-256 hash of the content of the file */
+  public readonly sha256: pulumi.Output<string>
+  /** The size of the file in bytes */
+  public readonly size: pulumi.Output<number>
+
+  constructor(
+    name: string,
+    args: LocalFileArgs,
+    opts?: pulumi.CustomResourceOptions
+  ) {
+    if (args.path === undefined) {
+      throw new Error("Missing required property 'path'")
+    }
+    const inputs: pulumi.Inputs = {
+      path: args.path,
+      content: args.content,
+      contentBase64: args.contentBase64,
+      mode: args.mode,
+      owner: args.owner,
+    }
+    inputs.sha256 = undefined /* out */
+    inputs.size = undefined /* out */
+    super('command:v1:LocalFile', name, inputs, opts)
+  }
+}
+
+/** How to reach a host over SSH.
+ *
+ * The user authenticates with `privateKey`, `password` or an SSH agent, which is used by default
+ * when `SSH_AUTH_SOCK` is set and neither of the others is. */
+export interface Connection {
+  /** The address of the host */
+  host: pulumi.Input<string>
+  /** The port of the SSH server. Defaults to 22. */
+  port?: pulumi.Input<number>
+  /** The user to log in as. Defaults to `root`. */
+  user?: pulumi.Input<string>
+  /** The password of the user */
+  password?: pulumi.Input<string>
+  /** The private key of the user in PEM format */
+  privateKey?: pulumi.Input<string>
+  /** The password of an encrypted `privateKey` */
+  privateKeyPassword?: pulumi.Input<string>
+  /** The path of the socket of an SSH agent that holds the keys of the user */
+  agentSocketPath?: pulumi.Input<string>
+  /** Pins the key of the host, as a public key in `authorized_keys` format or as a `SHA256:...` fingerprint. Without it, any host key is accepted. */
+  hostKey?: pulumi.Input<string>
+  /** How long to keep trying to connect to a host that is not reachable yet, as a duration. Defaults to `2m`. */
+  dialTimeout?: pulumi.Input<string>
+  /** A bastion host through which the host is reached */
+  proxy?: pulumi.Input<Connection>
+}
+
+export interface RemoteCommandSet extends Omit<CommandSet, 'replaceOn'> {
+  /** The host the commands run on */
+  connection: pulumi.Input<Connection>
+  /** The inputs whose changes replace the resource instead of updating it. */
+  replaceOn?: pulumi.Input<
+    (
+      | 'compare'
+      | 'create'
+      | 'read'
+      | 'update'
+      | 'delete'
+      | 'diff'
+      | 'connection'
+    )[]
+  >
+}
+
+/** Execute commands on a remote host over SSH and save them as a resource.
+ *
+ * A `RemoteCommand` has the same lifecycle as a `Command`, but each command runs on the host of `connection`
+ * with the shell of the remote user. The environment of the provider is not passed to remote commands,
+ * only `environment` and the `PULUMI_COMMAND_*` variables.
+ * The update command also runs when the host, port or user of the connection change.
+ */
+export class RemoteCommand extends pulumi.CustomResource {
+  public readonly connection: pulumi.Output<Connection>
+  public readonly stdout: pulumi.Output<string>
+  public readonly stderr: pulumi.Output<string>
+  /** exit code of the command */
+  public readonly exitCode: pulumi.Output<number>
+  /** The time the command was started, in RFC 3339 format */
+  public readonly startedAt: pulumi.Output<string>
+  /** The time the command exited, in RFC 3339 format */
+  public readonly finishedAt: pulumi.Output<string>
+  /** How long the command ran, in milliseconds */
+  public readonly durationMs: pulumi.Output<number>
+  /** The number of times the last command ran, including retries */
+  public readonly attempts: pulumi.Output<number>
+  /** stdout of the command decoded according to its `outputFormat` */
+  public readonly parsed: pulumi.Output<any>
+  /** The SHA-256 hash of the script of the last create or update, when it is a `script` */
+  public readonly scriptHash: pulumi.Output<string | undefined>
+  /** stdout of the `read` command during the last refresh */
+  public readonly readStdout: pulumi.Output<string | undefined>
+  /** Whether the output of the `read` command differed from the expected output during the last refresh */
+  public readonly drifted: pulumi.Output<boolean | undefined>
+
+  constructor(
+    name: string,
+    args: RemoteCommandSet,
+    opts?: pulumi.CustomResourceOptions
+  ) {
+    if (args.connection === undefined) {
+      throw new Error("Missing required property 'connection'")
+    }
+    if (args.create === undefined) {
+      throw new Error("Missing required property 'create'")
+    }
+    const inputs: pulumi.Inputs = {
+      connection: args.connection,
+      create: fix(args.create),
+      read: fix(args.read),
+      update: fix(args.update ?? args.create),
+      delete: fix(args.delete),
+      diff: fix(args.diff),
+      expected: args.expected,
+      idFrom: args.idFrom,
+      waitFor: fixWaitFor(args.waitFor),
+      secretOutputs: args.secretOutputs,
+      replaceOn: args.replaceOn,
+      deleteBeforeReplace: args.deleteBeforeReplace,
+    }
+    if (typeof args.compare !== 'undefined') {
+      // Resolve outputs such as the hash of a copied file before hashing them.
+      inputs.compare = pulumi.output(args.compare).apply((compare) => {
+        try {
+          return hash(compare)
+        } catch (e) {
+          throw new pulumi.RunError(`Could not serialize compare prop ${e}`)
+        }
+      })
+    }
+    inputs.stdout = undefined /* out */
+    inputs.stderr = undefined /* out */
+    inputs.exitCode = undefined /* out */
+    inputs.startedAt = undefined /* out */
+    inputs.finishedAt = undefined /* out */
+    inputs.durationMs = undefined /* out */
+    inputs.attempts = undefined /* out */
+    inputs.parsed = undefined /* out */
+    inputs.scriptHash = undefined /* out */
+    inputs.readStdout = undefined /* out */
+    inputs.drifted = undefined /* out */
+    super('command:v1:RemoteCommand', name, inputs, opts)
+  }
+}
+
+export interface CopyFileArgs {
+  /** The host to copy to */
+  connection: pulumi.Input<Connection>
+  /** The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of `source`, `content` and `contentBase64`. */
+  source?: pulumi.Input<string>
+  /** The content of the file */
+  content?: pulumi.Input<string>
+  /** The base64-encoded content of a binary file */
+  contentBase64?: pulumi.Input<string>
+  /** The path on the host. Missing parent directories are created. Changing it replaces the copy. */
+  remotePath: pulumi.Input<string>
+  /** The permission bits of the remote file in octal, such as `0600`. Defaults to the mode of `source`, or `0644`. */
+  mode?: pulumi.Input<string>
+}
+
+/** Upload a file to a remote host over SFTP.
+ *
+ * The file is written atomically with its mode and compared by the SHA-256 hash of its content, so it is only uploaded again when its content changes.
+ * A refresh detects changes made on the host and the next update uploads the file again. Deleting the resource removes the remote file.
+ */
+export class CopyFile extends pulumi.CustomResource {
+  public readonly connection: pulumi.Output<Connection>
+  public readonly source: pulumi.Output<string | undefined>
+  public readonly content: pulumi.Output<string | undefined>
+  public readonly contentBase64: pulumi.Output<string | undefined>
+  public readonly remotePath: pulumi.Output<string>
+  public readonly mode: pulumi.Output<string>
+  /** The SHA-256 hash of the content of the file */
+  public readonly sha256: pulumi.Output<string>
+  /** The size of the file in bytes */
+  public readonly size: pulumi.Output<number>
+
+  constructor(
+    name: string,
+    args: CopyFileArgs,
+    opts?: pulumi.CustomResourceOptions
+  ) {
+    if (args.connection === undefined) {
+      throw new Error("Missing required property 'connection'")
+    }
+    if (args.remotePath === undefined) {
+      throw new Error("Missing required property 'remotePath'")
+    }
+    const inputs: pulumi.Inputs = {
+      connection: args.connection,
+      source: args.source,
+      content: args.content,
+      contentBase64: args.contentBase64,
+      remotePath: args.remotePath,
+      mode: args.mode,
+    }
+    inputs.sha256 = undefined /* out */
+    inputs.size = undefined /* out */
+    super('command:v1:CopyFile', name, inputs, opts)
+  }
+}
+
+export interface CopyDirectoryArgs {
+  /** The host to copy to */
+  connection: pulumi.Input<Connection>
+  /** The local directory to upload. Relative paths are resolved against the Pulumi project root. */
+  source: pulumi.Input<string>
+  /** The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy. */
+  remotePath: pulumi.Input<string>
+}
+
+/** Upload a directory tree to a remote host over SFTP.
+ *
+ * Files keep their mode bits. Each file is compared by the SHA-256 hash of its content, so an update only uploads
+ * the files that changed and removes those that were removed locally. Deleting the resource removes the remote directory.
+ */
+export class CopyDirectory extends pulumi.CustomResource {
+  public readonly connection: pulumi.Output<Connection>
+  public readonly source: pulumi.Output<string>
+  public readonly remotePath: pulumi.Output<string>
+  /** The SHA-256 hash of the tree, covering the paths, modes and content of its files and directories */
+  public readonly sha256: pulumi.Output<string>
+  /** The mode and SHA-256 hash of each file and directory of the tree, by its path relative to the directory */
+  public readonly files: pulumi.Output<{
+    [path: string]: { mode: string; sha256?: string }
+  }>
+
+  constructor(
+    name: string,
+    args: CopyDirectoryArgs,
+    opts?: pulumi.CustomResourceOptions
+  ) {
+    if (args.connection === undefined) {
+      throw new Error("Missing required property 'connection'")
+    }
+    if (args.source === undefined) {
+      throw new Error("Missing required property 'source'")
+    }
+    if (args.remotePath === undefined) {
+      throw new Error("Missing required property 'remotePath'")
+    }
+    const inputs: pulumi.Inputs = {
+      connection: args.connection,
+      source: args.source,
+      remotePath: args.remotePath,
+    }
+    inputs.sha256 = undefined /* out */
+    inputs.files = undefined /* out */
+    super('command:v1:CopyDirectory', name, inputs, opts)
+  }
+}
@@ -6,7 +6,12 @@ from . import _utilities
 import typing
 # Export this package's modules as members:
 from .command import *
+from .copy_directory import *
+from .copy_file import *
+from .local_file import *
 from .provider import *
+from .remote_command import *
+from .run import *
 from ._inputs import *
 from . import outputs
 _utilities.register(
@@ -17,7 +22,11 @@ _utilities.register(
   "mod": "v1",
   "fqn": "pulumi_command",
   "classes": {
-   "command:v1:Command": "Command"
+   "command:v1:Command": "Command",
+   "command:v1:CopyDirectory": "CopyDirectory",
+   "command:v1:CopyFile": "CopyFile",
+   "command:v1:LocalFile": "LocalFile",
+   "command:v1:RemoteCommand": "RemoteCommand"
   }
  }
 ]
@@ -10,37 +10,151 @@ from . import _utilities
 
 __all__ = [
     'CmdArgs',
+    'ConnectionArgs',
+    'IdFromArgs',
+    'Image',
+    'ImageArgs',
+    'Mount',
+    'MountArgs',
+    'Retry',
+    'RetryArgs',
+    'Sandbox',
+    'SandboxArgs',
+    'WaitForArgs',
 ]
 
 @pulumi.input_type
 class CmdArgs:
     def __init__(__self__, *,
-                 command: pulumi.Input[Sequence[pulumi.Input[str]]],
+                 allowed_exit_codes: Optional[pulumi.Input[Sequence[pulumi.Input[int]]]] = None,
+                 command: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None,
+                 dir: Optional[pulumi.Input[str]] = None,
+                 env_allowlist: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None,
                  environment: Optional[pulumi.Input[Mapping[str, pulumi.Input[str]]]] = None,
-                 stdin: Optional[pulumi.Input[str]] = None):
+                 image: Optional[pulumi.Input['ImageArgs']] = None,
+                 inherit_env: Optional[pulumi.Input[str]] = None,
+                 interpreter: Optional[pulumi.Input[str]] = None,
+                 output_format: Optional[pulumi.Input[str]] = None,
+                 retry: Optional[pulumi.Input['RetryArgs']] = None,
+                 sandbox: Optional[pulumi.Input['SandboxArgs']] = None,
+                 script: Optional[pulumi.Input[str]] = None,
+                 script_file: Optional[pulumi.Input[bool]] = None,
+                 stdin: Optional[pulumi.Input[str]] = None,
+                 stdin_from: Optional[pulumi.Input[str]] = None,
+                 stop_grace_period: Optional[pulumi.Input[str]] = None,
+                 stop_signal: Optional[pulumi.Input[str]] = None,
+                 timeout: Optional[pulumi.Input[str]] = None,
+                 unset_env: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None):
         """
         Command specification
-        :param pulumi.Input[Sequence[pulumi.Input[str]]] command: Specifiy the command to run as an array of arguments
+        :param pulumi.Input[Sequence[pulumi.Input[int]]] allowed_exit_codes: Non-zero exit codes that are treated as success.
+        :param pulumi.Input[Sequence[pulumi.Input[str]]] command: Specifiy the command to run as an array of arguments. Set either `command` or `script`.
+        :param pulumi.Input[str] dir: The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+        :param pulumi.Input[Sequence[pulumi.Input[str]]] env_allowlist: The names of the variables to inherit when `inheritEnv` is `allowlist`.
+        :param pulumi.Input['ImageArgs'] image: Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+        :param pulumi.Input[str] inherit_env: Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+               
+               The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+        :param pulumi.Input[str] interpreter: The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+        :param pulumi.Input[str] output_format: Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+        :param pulumi.Input['RetryArgs'] retry: Retry the command when it fails. Timeouts and cancellation are not retried.
+        :param pulumi.Input['SandboxArgs'] sandbox: Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+        :param pulumi.Input[str] script: A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+        :param pulumi.Input[bool] script_file: Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
         :param pulumi.Input[str] stdin: Pass the stdin to a command
+        :param pulumi.Input[str] stdin_from: Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`. Not available to the create command.
+        :param pulumi.Input[str] stop_grace_period: How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+        :param pulumi.Input[str] stop_signal: The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+        :param pulumi.Input[str] timeout: The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+        :param pulumi.Input[Sequence[pulumi.Input[str]]] unset_env: The names of inherited variables to remove from the command's environment.
         """
-        pulumi.set(__self__, "command", command)
+        if allowed_exit_codes is not None:
+            pulumi.set(__self__, "allowed_exit_codes", allowed_exit_codes)
+        if command is not None:
+            pulumi.set(__self__, "command", command)
+        if dir is not None:
+            pulumi.set(__self__, "dir", dir)
+        if env_allowlist is not None:
+            pulumi.set(__self__, "env_allowlist", env_allowlist)
         if environment is not None:
             pulumi.set(__self__, "environment", environment)
+        if image is not None:
+            pulumi.set(__self__, "image", image)
+        if inherit_env is not None:
+            pulumi.set(__self__, "inherit_env", inherit_env)
+        if interpreter is not None:
+            pulumi.set(__self__, "interpreter", interpreter)
+        if output_format is not None:
+            pulumi.set(__self__, "output_format", output_format)
+        if retry is not None:
+            pulumi.set(__self__, "retry", retry)
+        if sandbox is not None:
+            pulumi.set(__self__, "sandbox", sandbox)
+        if script is not None:
+            pulumi.set(__self__, "script", script)
+        if script_file is not None:
+            pulumi.set(__self__, "script_file", script_file)
         if stdin is not None:
             pulumi.set(__self__, "stdin", stdin)
+        if stdin_from is not None:
+            pulumi.set(__self__, "stdin_from", stdin_from)
+        if stop_grace_period is not None:
+            pulumi.set(__self__, "stop_grace_period", stop_grace_period)
+        if stop_signal is not None:
+            pulumi.set(__self__, "stop_signal", stop_signal)
+        if timeout is not None:
+            pulumi.set(__self__, "timeout", timeout)
+        if unset_env is not None:
+            pulumi.set(__self__, "unset_env", unset_env)
+
+    @property
+    @pulumi.getter(name="allowedExitCodes")
+    def allowed_exit_codes(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[int]]]]:
+        """
+        Non-zero exit codes that are treated as success.
+        """
+        return pulumi.get(self, "allowed_exit_codes")
+
+    @allowed_exit_codes.setter
+    def allowed_exit_codes(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[int]]]]):
+        pulumi.set(self, "allowed_exit_codes", value)
 
     @property
     @pulumi.getter
-    def command(self) -> pulumi.Input[Sequence[pulumi.Input[str]]]:
+    def command(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]:
         """
-        Specifiy the command to run as an array of arguments
+        Specifiy the command to run as an array of arguments. Set either `command` or `script`.
         """
         return pulumi.get(self, "command")
 
     @command.setter
-    def command(self, value: pulumi.Input[Sequence[pulumi.Input[str]]]):
+    def command(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]):
         pulumi.set(self, "command", value)
 
+    @property
+    @pulumi.getter
+    def dir(self) -> Optional[pulumi.Input[str]]:
+        """
+        The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+        """
+        return pulumi.get(self, "dir")
+
+    @dir.setter
+    def dir(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "dir", value)
+
+    @property
+    @pulumi.getter(name="envAllowlist")
+    def env_allowlist(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]:
+        """
+        The names of the variables to inherit when `inheritEnv` is `allowlist`.
+        """
+        return pulumi.get(self, "env_allowlist")
+
+    @env_allowlist.setter
+    def env_allowlist(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]):
+        pulumi.set(self, "env_allowlist", value)
+
     @property
     @pulumi.getter
     def environment(self) -> Optional[pulumi.Input[Mapping[str, pulumi.Input[str]]]]:
@@ -50,6 +164,104 @@ class CmdArgs:
     def environment(self, value: Optional[pulumi.Input[Mapping[str, pulumi.Input[str]]]]):
         pulumi.set(self, "environment", value)
 
+    @property
+    @pulumi.getter
+    def image(self) -> Optional[pulumi.Input['ImageArgs']]:
+        """
+        Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+        """
+        return pulumi.get(self, "image")
+
+    @image.setter
+    def image(self, value: Optional[pulumi.Input['ImageArgs']]):
+        pulumi.set(self, "image", value)
+
+    @property
+    @pulumi.getter(name="inheritEnv")
+    def inherit_env(self) -> Optional[pulumi.Input[str]]:
+        """
+        Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+
+        The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+        """
+        return pulumi.get(self, "inherit_env")
+
+    @inherit_env.setter
+    def inherit_env(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "inherit_env", value)
+
+    @property
+    @pulumi.getter
+    def interpreter(self) -> Optional[pulumi.Input[str]]:
+        """
+        The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+        """
+        return pulumi.get(self, "interpreter")
+
+    @interpreter.setter
+    def interpreter(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "interpreter", value)
+
+    @property
+    @pulumi.getter(name="outputFormat")
+    def output_format(self) -> Optional[pulumi.Input[str]]:
+        """
+        Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+        """
+        return pulumi.get(self, "output_format")
+
+    @output_format.setter
+    def output_format(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "output_format", value)
+
+    @property
+    @pulumi.getter
+    def retry(self) -> Optional[pulumi.Input['RetryArgs']]:
+        """
+        Retry the command when it fails. Timeouts and cancellation are not retried.
+        """
+        return pulumi.get(self, "retry")
+
+    @retry.setter
+    def retry(self, value: Optional[pulumi.Input['RetryArgs']]):
+        pulumi.set(self, "retry", value)
+
+    @property
+    @pulumi.getter
+    def sandbox(self) -> Optional[pulumi.Input['SandboxArgs']]:
+        """
+        Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+        """
+        return pulumi.get(self, "sandbox")
+
+    @sandbox.setter
+    def sandbox(self, value: Optional[pulumi.Input['SandboxArgs']]):
+        pulumi.set(self, "sandbox", value)
+
+    @property
+    @pulumi.getter
+    def script(self) -> Optional[pulumi.Input[str]]:
+        """
+        A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+        """
+        return pulumi.get(self, "script")
+
+    @script.setter
+    def script(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "script", value)
+
+    @property
+    @pulumi.getter(name="scriptFile")
+    def script_file(self) -> Optional[pulumi.Input[bool]]:
+        """
+        Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+        """
+        return pulumi.get(self, "script_file")
+
+    @script_file.setter
+    def script_file(self, value: Optional[pulumi.Input[bool]]):
+        pulumi.set(self, "script_file", value)
+
     @property
     @pulumi.getter
     def stdin(self) -> Optional[pulumi.Input[str]]:
@@ -62,4 +274,1040 @@ class CmdArgs:
     def stdin(self, value: Optional[pulumi.Input[str]]):
         pulumi.set(self, "stdin", value)
 
+    @property
+    @pulumi.getter(name="stdinFrom")
+    def stdin_from(self) -> Optional[pulumi.Input[str]]:
+        """
+        Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`. Not available to the create command.
+        """
+        return pulumi.get(self, "stdin_from")
+
+    @stdin_from.setter
+    def stdin_from(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "stdin_from", value)
+
+    @property
+    @pulumi.getter(name="stopGracePeriod")
+    def stop_grace_period(self) -> Optional[pulumi.Input[str]]:
+        """
+        How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+        """
+        return pulumi.get(self, "stop_grace_period")
+
+    @stop_grace_period.setter
+    def stop_grace_period(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "stop_grace_period", value)
+
+    @property
+    @pulumi.getter(name="stopSignal")
+    def stop_signal(self) -> Optional[pulumi.Input[str]]:
+        """
+        The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+        """
+        return pulumi.get(self, "stop_signal")
+
+    @stop_signal.setter
+    def stop_signal(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "stop_signal", value)
+
+    @property
+    @pulumi.getter
+    def timeout(self) -> Optional[pulumi.Input[str]]:
+        """
+        The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+        """
+        return pulumi.get(self, "timeout")
+
+    @timeout.setter
+    def timeout(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "timeout", value)
+
+    @property
+    @pulumi.getter(name="unsetEnv")
+    def unset_env(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]:
+        """
+        The names of inherited variables to remove from the command's environment.
+        """
+        return pulumi.get(self, "unset_env")
+
+    @unset_env.setter
+    def unset_env(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]):
+        pulumi.set(self, "unset_env", value)
+
+
+@pulumi.input_type
+class ConnectionArgs:
+    def __init__(__self__, *,
+                 host: pulumi.Input[str],
+                 agent_socket_path: Optional[pulumi.Input[str]] = None,
+                 dial_timeout: Optional[pulumi.Input[str]] = None,
+                 host_key: Optional[pulumi.Input[str]] = None,
+                 password: Optional[pulumi.Input[str]] = None,
+                 port: Optional[pulumi.Input[int]] = None,
+                 private_key: Optional[pulumi.Input[str]] = None,
+                 private_key_password: Optional[pulumi.Input[str]] = None,
+                 proxy: Optional[pulumi.Input['ConnectionArgs']] = None,
+                 user: Optional[pulumi.Input[str]] = None):
+        """
+        How to reach a host over SSH. The user authenticates with `privateKey`, `password` or an SSH agent, which is used by default when `SSH_AUTH_SOCK` is set and neither of the others is.
+        :param pulumi.Input[str] host: The address of the host
+        :param pulumi.Input[str] agent_socket_path: The path of the socket of an SSH agent that holds the keys of the user
+        :param pulumi.Input[str] dial_timeout: How long to keep trying to connect to a host that is not reachable yet, such as a machine that is still booting, as a duration. Defaults to `2m`.
+        :param pulumi.Input[str] host_key: Pins the key of the host, as a public key in `authorized_keys` format such as `ssh-ed25519 AAAA...` or as a fingerprint such as `SHA256:...`. Without it, any host key is accepted.
+        :param pulumi.Input[str] password: The password of the user
+        :param pulumi.Input[int] port: The port of the SSH server. Defaults to 22.
+        :param pulumi.Input[str] private_key: The private key of the user in PEM format
+        :param pulumi.Input[str] private_key_password: The password of an encrypted `privateKey`
+        :param pulumi.Input['ConnectionArgs'] proxy: A bastion host through which the host is reached
+        :param pulumi.Input[str] user: The user to log in as. Defaults to `root`.
+        """
+        pulumi.set(__self__, "host", host)
+        if agent_socket_path is not None:
+            pulumi.set(__self__, "agent_socket_path", agent_socket_path)
+        if dial_timeout is not None:
+            pulumi.set(__self__, "dial_timeout", dial_timeout)
+        if host_key is not None:
+            pulumi.set(__self__, "host_key", host_key)
+        if password is not None:
+            pulumi.set(__self__, "password", password)
+        if port is not None:
+            pulumi.set(__self__, "port", port)
+        if private_key is not None:
+            pulumi.set(__self__, "private_key", private_key)
+        if private_key_password is not None:
+            pulumi.set(__self__, "private_key_password", private_key_password)
+        if proxy is not None:
+            pulumi.set(__self__, "proxy", proxy)
+        if user is not None:
+            pulumi.set(__self__, "user", user)
+
+    @property
+    @pulumi.getter
+    def host(self) -> pulumi.Input[str]:
+        """
+        The address of the host
+        """
+        return pulumi.get(self, "host")
+
+    @host.setter
+    def host(self, value: pulumi.Input[str]):
+        pulumi.set(self, "host", value)
+
+    @property
+    @pulumi.getter(name="agentSocketPath")
+    def agent_socket_path(self) -> Optional[pulumi.Input[str]]:
+        """
+        The path of the socket of an SSH agent that holds the keys of the user
+        """
+        return pulumi.get(self, "agent_socket_path")
+
+    @agent_socket_path.setter
+    def agent_socket_path(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "agent_socket_path", value)
+
+    @property
+    @pulumi.getter(name="dialTimeout")
+    def dial_timeout(self) -> Optional[pulumi.Input[str]]:
+        """
+        How long to keep trying to connect to a host that is not reachable yet, such as a machine that is still booting, as a duration. Defaults to `2m`.
+        """
+        return pulumi.get(self, "dial_timeout")
+
+    @dial_timeout.setter
+    def dial_timeout(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "dial_timeout", value)
+
+    @property
+    @pulumi.getter(name="hostKey")
+    def host_key(self) -> Optional[pulumi.Input[str]]:
+        """
+        Pins the key of the host, as a public key in `authorized_keys` format such as `ssh-ed25519 AAAA...` or as a fingerprint such as `SHA256:...`. Without it, any host key is accepted.
+        """
+        return pulumi.get(self, "host_key")
+
+    @host_key.setter
+    def host_key(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "host_key", value)
+
+    @property
+    @pulumi.getter
+    def password(self) -> Optional[pulumi.Input[str]]:
+        """
+        The password of the user
+        """
+        return pulumi.get(self, "password")
+
+    @password.setter
+    def password(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "password", value)
+
+    @property
+    @pulumi.getter
+    def port(self) -> Optional[pulumi.Input[int]]:
+        """
+        The port of the SSH server. Defaults to 22.
+        """
+        return pulumi.get(self, "port")
+
+    @port.setter
+    def port(self, value: Optional[pulumi.Input[int]]):
+        pulumi.set(self, "port", value)
+
+    @property
+    @pulumi.getter(name="privateKey")
+    def private_key(self) -> Optional[pulumi.Input[str]]:
+        """
+        The private key of the user in PEM format
+        """
+        return pulumi.get(self, "private_key")
+
+    @private_key.setter
+    def private_key(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "private_key", value)
+
+    @property
+    @pulumi.getter(name="privateKeyPassword")
+    def private_key_password(self) -> Optional[pulumi.Input[str]]:
+        """
+        The password of an encrypted `privateKey`
+        """
+        return pulumi.get(self, "private_key_password")
+
+    @private_key_password.setter
+    def private_key_password(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "private_key_password", value)
+
+    @property
+    @pulumi.getter
+    def proxy(self) -> Optional[pulumi.Input['ConnectionArgs']]:
+        """
+        A bastion host through which the host is reached
+        """
+        return pulumi.get(self, "proxy")
+
+    @proxy.setter
+    def proxy(self, value: Optional[pulumi.Input['ConnectionArgs']]):
+        pulumi.set(self, "proxy", value)
+
+    @property
+    @pulumi.getter
+    def user(self) -> Optional[pulumi.Input[str]]:
+        """
+        The user to log in as. Defaults to `root`.
+        """
+        return pulumi.get(self, "user")
+
+    @user.setter
+    def user(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "user", value)
+
+
+@pulumi.input_type
+class IdFromArgs:
+    def __init__(__self__, *,
+                 path: Optional[pulumi.Input[str]] = None,
+                 stdout: Optional[pulumi.Input[bool]] = None,
+                 value: Optional[pulumi.Input[str]] = None):
+        """
+        How the ID of a resource is derived. Set exactly one field.
+        :param pulumi.Input[str] path: The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`.
+        :param pulumi.Input[bool] stdout: Use the stdout of the create command, without surrounding whitespace
+        :param pulumi.Input[str] value: A static ID
+        """
+        if path is not None:
+            pulumi.set(__self__, "path", path)
+        if stdout is not None:
+            pulumi.set(__self__, "stdout", stdout)
+        if value is not None:
+            pulumi.set(__self__, "value", value)
+
+    @property
+    @pulumi.getter
+    def path(self) -> Optional[pulumi.Input[str]]:
+        """
+        The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`.
+        """
+        return pulumi.get(self, "path")
+
+    @path.setter
+    def path(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "path", value)
+
+    @property
+    @pulumi.getter
+    def stdout(self) -> Optional[pulumi.Input[bool]]:
+        """
+        Use the stdout of the create command, without surrounding whitespace
+        """
+        return pulumi.get(self, "stdout")
+
+    @stdout.setter
+    def stdout(self, value: Optional[pulumi.Input[bool]]):
+        pulumi.set(self, "stdout", value)
+
+    @property
+    @pulumi.getter
+    def value(self) -> Optional[pulumi.Input[str]]:
+        """
+        A static ID
+        """
+        return pulumi.get(self, "value")
+
+    @value.setter
+    def value(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "value", value)
+
+
+@pulumi.input_type
+class Image:
+    def __init__(__self__, *,
+                 name: str,
+                 mounts: Optional[Sequence['Mount']] = None,
+                 network_mode: Optional[str] = None,
+                 user: Optional[str] = None):
+        """
+        A container image to run a command in.
+        :param str name: The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+        :param Sequence['Mount'] mounts: Paths of the machine running the provider to bind into the container
+        :param str network_mode: The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+        :param str user: The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+        """
+        pulumi.set(__self__, "name", name)
+        if mounts is not None:
+            pulumi.set(__self__, "mounts", mounts)
+        if network_mode is not None:
+            pulumi.set(__self__, "network_mode", network_mode)
+        if user is not None:
+            pulumi.set(__self__, "user", user)
+
+    @property
+    @pulumi.getter
+    def name(self) -> str:
+        """
+        The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+        """
+        return pulumi.get(self, "name")
+
+    @name.setter
+    def name(self, value: str):
+        pulumi.set(self, "name", value)
+
+    @property
+    @pulumi.getter
+    def mounts(self) -> Optional[Sequence['Mount']]:
+        """
+        Paths of the machine running the provider to bind into the container
+        """
+        return pulumi.get(self, "mounts")
+
+    @mounts.setter
+    def mounts(self, value: Optional[Sequence['Mount']]):
+        pulumi.set(self, "mounts", value)
+
+    @property
+    @pulumi.getter(name="networkMode")
+    def network_mode(self) -> Optional[str]:
+        """
+        The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+        """
+        return pulumi.get(self, "network_mode")
+
+    @network_mode.setter
+    def network_mode(self, value: Optional[str]):
+        pulumi.set(self, "network_mode", value)
+
+    @property
+    @pulumi.getter
+    def user(self) -> Optional[str]:
+        """
+        The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+        """
+        return pulumi.get(self, "user")
+
+    @user.setter
+    def user(self, value: Optional[str]):
+        pulumi.set(self, "user", value)
+
+
+@pulumi.input_type
+class ImageArgs:
+    def __init__(__self__, *,
+                 name: pulumi.Input[str],
+                 mounts: Optional[pulumi.Input[Sequence[pulumi.Input['MountArgs']]]] = None,
+                 network_mode: Optional[pulumi.Input[str]] = None,
+                 user: Optional[pulumi.Input[str]] = None):
+        """
+        A container image to run a command in.
+        :param pulumi.Input[str] name: The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+        :param pulumi.Input[Sequence[pulumi.Input['MountArgs']]] mounts: Paths of the machine running the provider to bind into the container
+        :param pulumi.Input[str] network_mode: The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+        :param pulumi.Input[str] user: The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+        """
+        pulumi.set(__self__, "name", name)
+        if mounts is not None:
+            pulumi.set(__self__, "mounts", mounts)
+        if network_mode is not None:
+            pulumi.set(__self__, "network_mode", network_mode)
+        if user is not None:
+            pulumi.set(__self__, "user", user)
+
+    @property
+    @pulumi.getter
+    def name(self) -> pulumi.Input[str]:
+        """
+        The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+        """
+        return pulumi.get(self, "name")
+
+    @name.setter
+    def name(self, value: pulumi.Input[str]):
+        pulumi.set(self, "name", value)
+
+    @property
+    @pulumi.getter
+    def mounts(self) -> Optional[pulumi.Input[Sequence[pulumi.Input['MountArgs']]]]:
+        """
+        Paths of the machine running the provider to bind into the container
+        """
+        return pulumi.get(self, "mounts")
+
+    @mounts.setter
+    def mounts(self, value: Optional[pulumi.Input[Sequence[pulumi.Input['MountArgs']]]]):
+        pulumi.set(self, "mounts", value)
+
+    @property
+    @pulumi.getter(name="networkMode")
+    def network_mode(self) -> Optional[pulumi.Input[str]]:
+        """
+        The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+        """
+        return pulumi.get(self, "network_mode")
+
+    @network_mode.setter
+    def network_mode(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "network_mode", value)
+
+    @property
+    @pulumi.getter
+    def user(self) -> Optional[pulumi.Input[str]]:
+        """
+        The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+        """
+        return pulumi.get(self, "user")
+
+    @user.setter
+    def user(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "user", value)
+
+
+@pulumi.input_type
+class Mount:
+    def __init__(__self__, *,
+                 source: str,
+                 target: str,
+                 read_only: Optional[bool] = None):
+        """
+        A path of the machine running the provider bound into a container.
+        :param str source: The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+        :param str target: The absolute path in the container
+        :param bool read_only: Whether the container may only read the mount
+        """
+        pulumi.set(__self__, "source", source)
+        pulumi.set(__self__, "target", target)
+        if read_only is not None:
+            pulumi.set(__self__, "read_only", read_only)
+
+    @property
+    @pulumi.getter
+    def source(self) -> str:
+        """
+        The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+        """
+        return pulumi.get(self, "source")
+
+    @source.setter
+    def source(self, value: str):
+        pulumi.set(self, "source", value)
+
+    @property
+    @pulumi.getter
+    def target(self) -> str:
+        """
+        The absolute path in the container
+        """
+        return pulumi.get(self, "target")
+
+    @target.setter
+    def target(self, value: str):
+        pulumi.set(self, "target", value)
+
+    @property
+    @pulumi.getter(name="readOnly")
+    def read_only(self) -> Optional[bool]:
+        """
+        Whether the container may only read the mount
+        """
+        return pulumi.get(self, "read_only")
+
+    @read_only.setter
+    def read_only(self, value: Optional[bool]):
+        pulumi.set(self, "read_only", value)
+
+
+@pulumi.input_type
+class MountArgs:
+    def __init__(__self__, *,
+                 source: pulumi.Input[str],
+                 target: pulumi.Input[str],
+                 read_only: Optional[pulumi.Input[bool]] = None):
+        """
+        A path of the machine running the provider bound into a container.
+        :param pulumi.Input[str] source: The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+        :param pulumi.Input[str] target: The absolute path in the container
+        :param pulumi.Input[bool] read_only: Whether the container may only read the mount
+        """
+        pulumi.set(__self__, "source", source)
+        pulumi.set(__self__, "target", target)
+        if read_only is not None:
+            pulumi.set(__self__, "read_only", read_only)
+
+    @property
+    @pulumi.getter
+    def source(self) -> pulumi.Input[str]:
+        """
+        The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+        """
+        return pulumi.get(self, "source")
+
+    @source.setter
+    def source(self, value: pulumi.Input[str]):
+        pulumi.set(self, "source", value)
+
+    @property
+    @pulumi.getter
+    def target(self) -> pulumi.Input[str]:
+        """
+        The absolute path in the container
+        """
+        return pulumi.get(self, "target")
+
+    @target.setter
+    def target(self, value: pulumi.Input[str]):
+        pulumi.set(self, "target", value)
+
+    @property
+    @pulumi.getter(name="readOnly")
+    def read_only(self) -> Optional[pulumi.Input[bool]]:
+        """
+        Whether the container may only read the mount
+        """
+        return pulumi.get(self, "read_only")
+
+    @read_only.setter
+    def read_only(self, value: Optional[pulumi.Input[bool]]):
+        pulumi.set(self, "read_only", value)
+
+
+@pulumi.input_type
+class Retry:
+    def __init__(__self__, *,
+                 attempts: Optional[int] = None,
+                 backoff_factor: Optional[float] = None,
+                 initial_delay: Optional[str] = None,
+                 max_delay: Optional[str] = None,
+                 retry_on_exit_codes: Optional[Sequence[int]] = None,
+                 retry_on_stderr_match: Optional[str] = None):
+        """
+        How a failing command is retried. Each retry waits `backoffFactor` times longer than the previous one, up to `maxDelay`.
+        :param int attempts: The maximum number of times the command runs, including the first. Defaults to 3.
+        :param float backoff_factor: The factor each delay is multiplied by. Defaults to 2.
+        :param str initial_delay: How long to wait before the first retry, as a duration. Defaults to `1s`.
+        :param str max_delay: The longest wait between attempts, as a duration. Defaults to `30s`.
+        :param Sequence[int] retry_on_exit_codes: Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+        :param str retry_on_stderr_match: Only retry when stderr matches this regular expression.
+        """
+        if attempts is not None:
+            pulumi.set(__self__, "attempts", attempts)
+        if backoff_factor is not None:
+            pulumi.set(__self__, "backoff_factor", backoff_factor)
+        if initial_delay is not None:
+            pulumi.set(__self__, "initial_delay", initial_delay)
+        if max_delay is not None:
+            pulumi.set(__self__, "max_delay", max_delay)
+        if retry_on_exit_codes is not None:
+            pulumi.set(__self__, "retry_on_exit_codes", retry_on_exit_codes)
+        if retry_on_stderr_match is not None:
+            pulumi.set(__self__, "retry_on_stderr_match", retry_on_stderr_match)
+
+    @property
+    @pulumi.getter
+    def attempts(self) -> Optional[int]:
+        """
+        The maximum number of times the command runs, including the first. Defaults to 3.
+        """
+        return pulumi.get(self, "attempts")
+
+    @attempts.setter
+    def attempts(self, value: Optional[int]):
+        pulumi.set(self, "attempts", value)
+
+    @property
+    @pulumi.getter(name="backoffFactor")
+    def backoff_factor(self) -> Optional[float]:
+        """
+        The factor each delay is multiplied by. Defaults to 2.
+        """
+        return pulumi.get(self, "backoff_factor")
+
+    @backoff_factor.setter
+    def backoff_factor(self, value: Optional[float]):
+        pulumi.set(self, "backoff_factor", value)
+
+    @property
+    @pulumi.getter(name="initialDelay")
+    def initial_delay(self) -> Optional[str]:
+        """
+        How long to wait before the first retry, as a duration. Defaults to `1s`.
+        """
+        return pulumi.get(self, "initial_delay")
+
+    @initial_delay.setter
+    def initial_delay(self, value: Optional[str]):
+        pulumi.set(self, "initial_delay", value)
+
+    @property
+    @pulumi.getter(name="maxDelay")
+    def max_delay(self) -> Optional[str]:
+        """
+        The longest wait between attempts, as a duration. Defaults to `30s`.
+        """
+        return pulumi.get(self, "max_delay")
+
+    @max_delay.setter
+    def max_delay(self, value: Optional[str]):
+        pulumi.set(self, "max_delay", value)
+
+    @property
+    @pulumi.getter(name="retryOnExitCodes")
+    def retry_on_exit_codes(self) -> Optional[Sequence[int]]:
+        """
+        Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+        """
+        return pulumi.get(self, "retry_on_exit_codes")
+
+    @retry_on_exit_codes.setter
+    def retry_on_exit_codes(self, value: Optional[Sequence[int]]):
+        pulumi.set(self, "retry_on_exit_codes", value)
+
+    @property
+    @pulumi.getter(name="retryOnStderrMatch")
+    def retry_on_stderr_match(self) -> Optional[str]:
+        """
+        Only retry when stderr matches this regular expression.
+        """
+        return pulumi.get(self, "retry_on_stderr_match")
+
+    @retry_on_stderr_match.setter
+    def retry_on_stderr_match(self, value: Optional[str]):
+        pulumi.set(self, "retry_on_stderr_match", value)
+
+
+@pulumi.input_type
+class RetryArgs:
+    def __init__(__self__, *,
+                 attempts: Optional[pulumi.Input[int]] = None,
+                 backoff_factor: Optional[pulumi.Input[float]] = None,
+                 initial_delay: Optional[pulumi.Input[str]] = None,
+                 max_delay: Optional[pulumi.Input[str]] = None,
+                 retry_on_exit_codes: Optional[pulumi.Input[Sequence[pulumi.Input[int]]]] = None,
+                 retry_on_stderr_match: Optional[pulumi.Input[str]] = None):
+        """
+        How a failing command is retried. Each retry waits `backoffFactor` times longer than the previous one, up to `maxDelay`.
+        :param pulumi.Input[int] attempts: The maximum number of times the command runs, including the first. Defaults to 3.
+        :param pulumi.Input[float] backoff_factor: The factor each delay is multiplied by. Defaults to 2.
+        :param pulumi.Input[str] initial_delay: How long to wait before the first retry, as a duration. Defaults to `1s`.
+        :param pulumi.Input[str] max_delay: The longest wait between attempts, as a duration. Defaults to `30s`.
+        :param pulumi.Input[Sequence[pulumi.Input[int]]] retry_on_exit_codes: Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+        :param pulumi.Input[str] retry_on_stderr_match: Only retry when stderr matches this regular expression.
+        """
+        if attempts is not None:
+            pulumi.set(__self__, "attempts", attempts)
+        if backoff_factor is not None:
+            pulumi.set(__self__, "backoff_factor", backoff_factor)
+        if initial_delay is not None:
+            pulumi.set(__self__, "initial_delay", initial_delay)
+        if max_delay is not None:
+            pulumi.set(__self__, "max_delay", max_delay)
+        if retry_on_exit_codes is not None:
+            pulumi.set(__self__, "retry_on_exit_codes", retry_on_exit_codes)
+        if retry_on_stderr_match is not None:
+            pulumi.set(__self__, "retry_on_stderr_match", retry_on_stderr_match)
+
+    @property
+    @pulumi.getter
+    def attempts(self) -> Optional[pulumi.Input[int]]:
+        """
+        The maximum number of times the command runs, including the first. Defaults to 3.
+        """
+        return pulumi.get(self, "attempts")
+
+    @attempts.setter
+    def attempts(self, value: Optional[pulumi.Input[int]]):
+        pulumi.set(self, "attempts", value)
+
+    @property
+    @pulumi.getter(name="backoffFactor")
+    def backoff_factor(self) -> Optional[pulumi.Input[float]]:
+        """
+        The factor each delay is multiplied by. Defaults to 2.
+        """
+        return pulumi.get(self, "backoff_factor")
+
+    @backoff_factor.setter
+    def backoff_factor(self, value: Optional[pulumi.Input[float]]):
+        pulumi.set(self, "backoff_factor", value)
+
+    @property
+    @pulumi.getter(name="initialDelay")
+    def initial_delay(self) -> Optional[pulumi.Input[str]]:
+        """
+        How long to wait before the first retry, as a duration. Defaults to `1s`.
+        """
+        return pulumi.get(self, "initial_delay")
+
+    @initial_delay.setter
+    def initial_delay(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "initial_delay", value)
+
+    @property
+    @pulumi.getter(name="maxDelay")
+    def max_delay(self) -> Optional[pulumi.Input[str]]:
+        """
+        The longest wait between attempts, as a duration. Defaults to `30s`.
+        """
+        return pulumi.get(self, "max_delay")
+
+    @max_delay.setter
+    def max_delay(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "max_delay", value)
+
+    @property
+    @pulumi.getter(name="retryOnExitCodes")
+    def retry_on_exit_codes(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[int]]]]:
+        """
+        Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+        """
+        return pulumi.get(self, "retry_on_exit_codes")
+
+    @retry_on_exit_codes.setter
+    def retry_on_exit_codes(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[int]]]]):
+        pulumi.set(self, "retry_on_exit_codes", value)
+
+    @property
+    @pulumi.getter(name="retryOnStderrMatch")
+    def retry_on_stderr_match(self) -> Optional[pulumi.Input[str]]:
+        """
+        Only retry when stderr matches this regular expression.
+        """
+        return pulumi.get(self, "retry_on_stderr_match")
+
+    @retry_on_stderr_match.setter
+    def retry_on_stderr_match(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "retry_on_stderr_match", value)
+
+
+@pulumi.input_type
+class Sandbox:
+    def __init__(__self__, *,
+                 cpu_seconds: Optional[int] = None,
+                 max_processes: Optional[int] = None,
+                 memory_mb: Optional[int] = None,
+                 network: Optional[bool] = None,
+                 open_files: Optional[int] = None):
+        """
+        Restrictions on a command that runs on the machine running the provider. Each process of the command is subject to the limits that are set.
+        :param int cpu_seconds: The CPU time each process may use, in seconds, after which it is killed
+        :param int max_processes: The number of processes the user of the command may run, including those outside of the sandbox
+        :param int memory_mb: The size of the address space of each process, in mebibytes
+        :param bool network: Keep the network of the provider instead of running the command in an empty network namespace
+        :param int open_files: The number of files each process may have open
+        """
+        if cpu_seconds is not None:
+            pulumi.set(__self__, "cpu_seconds", cpu_seconds)
+        if max_processes is not None:
+            pulumi.set(__self__, "max_processes", max_processes)
+        if memory_mb is not None:
+            pulumi.set(__self__, "memory_mb", memory_mb)
+        if network is not None:
+            pulumi.set(__self__, "network", network)
+        if open_files is not None:
+            pulumi.set(__self__, "open_files", open_files)
+
+    @property
+    @pulumi.getter(name="cpuSeconds")
+    def cpu_seconds(self) -> Optional[int]:
+        """
+        The CPU time each process may use, in seconds, after which it is killed
+        """
+        return pulumi.get(self, "cpu_seconds")
+
+    @cpu_seconds.setter
+    def cpu_seconds(self, value: Optional[int]):
+        pulumi.set(self, "cpu_seconds", value)
+
+    @property
+    @pulumi.getter(name="maxProcesses")
+    def max_processes(self) -> Optional[int]:
+        """
+        The number of processes the user of the command may run, including those outside of the sandbox
+        """
+        return pulumi.get(self, "max_processes")
+
+    @max_processes.setter
+    def max_processes(self, value: Optional[int]):
+        pulumi.set(self, "max_processes", value)
+
+    @property
+    @pulumi.getter(name="memoryMB")
+    def memory_mb(self) -> Optional[int]:
+        """
+        The size of the address space of each process, in mebibytes
+        """
+        return pulumi.get(self, "memory_mb")
+
+    @memory_mb.setter
+    def memory_mb(self, value: Optional[int]):
+        pulumi.set(self, "memory_mb", value)
+
+    @property
+    @pulumi.getter
+    def network(self) -> Optional[bool]:
+        """
+        Keep the network of the provider instead of running the command in an empty network namespace
+        """
+        return pulumi.get(self, "network")
+
+    @network.setter
+    def network(self, value: Optional[bool]):
+        pulumi.set(self, "network", value)
+
+    @property
+    @pulumi.getter(name="openFiles")
+    def open_files(self) -> Optional[int]:
+        """
+        The number of files each process may have open
+        """
+        return pulumi.get(self, "open_files")
+
+    @open_files.setter
+    def open_files(self, value: Optional[int]):
+        pulumi.set(self, "open_files", value)
+
+
+@pulumi.input_type
+class SandboxArgs:
+    def __init__(__self__, *,
+                 cpu_seconds: Optional[pulumi.Input[int]] = None,
+                 max_processes: Optional[pulumi.Input[int]] = None,
+                 memory_mb: Optional[pulumi.Input[int]] = None,
+                 network: Optional[pulumi.Input[bool]] = None,
+                 open_files: Optional[pulumi.Input[int]] = None):
+        """
+        Restrictions on a command that runs on the machine running the provider. Each process of the command is subject to the limits that are set.
+        :param pulumi.Input[int] cpu_seconds: The CPU time each process may use, in seconds, after which it is killed
+        :param pulumi.Input[int] max_processes: The number of processes the user of the command may run, including those outside of the sandbox
+        :param pulumi.Input[int] memory_mb: The size of the address space of each process, in mebibytes
+        :param pulumi.Input[bool] network: Keep the network of the provider instead of running the command in an empty network namespace
+        :param pulumi.Input[int] open_files: The number of files each process may have open
+        """
+        if cpu_seconds is not None:
+            pulumi.set(__self__, "cpu_seconds", cpu_seconds)
+        if max_processes is not None:
+            pulumi.set(__self__, "max_processes", max_processes)
+        if memory_mb is not None:
+            pulumi.set(__self__, "memory_mb", memory_mb)
+        if network is not None:
+            pulumi.set(__self__, "network", network)
+        if open_files is not None:
+            pulumi.set(__self__, "open_files", open_files)
+
+    @property
+    @pulumi.getter(name="cpuSeconds")
+    def cpu_seconds(self) -> Optional[pulumi.Input[int]]:
+        """
+        The CPU time each process may use, in seconds, after which it is killed
+        """
+        return pulumi.get(self, "cpu_seconds")
+
+    @cpu_seconds.setter
+    def cpu_seconds(self, value: Optional[pulumi.Input[int]]):
+        pulumi.set(self, "cpu_seconds", value)
+
+    @property
+    @pulumi.getter(name="maxProcesses")
+    def max_processes(self) -> Optional[pulumi.Input[int]]:
+        """
+        The number of processes the user of the command may run, including those outside of the sandbox
+        """
+        return pulumi.get(self, "max_processes")
+
+    @max_processes.setter
+    def max_processes(self, value: Optional[pulumi.Input[int]]):
+        pulumi.set(self, "max_processes", value)
+
+    @property
+    @pulumi.getter(name="memoryMB")
+    def memory_mb(self) -> Optional[pulumi.Input[int]]:
+        """
+        The size of the address space of each process, in mebibytes
+        """
+        return pulumi.get(self, "memory_mb")
+
+    @memory_mb.setter
+    def memory_mb(self, value: Optional[pulumi.Input[int]]):
+        pulumi.set(self, "memory_mb", value)
+
+    @property
+    @pulumi.getter
+    def network(self) -> Optional[pulumi.Input[bool]]:
+        """
+        Keep the network of the provider instead of running the command in an empty network namespace
+        """
+        return pulumi.get(self, "network")
+
+    @network.setter
+    def network(self, value: Optional[pulumi.Input[bool]]):
+        pulumi.set(self, "network", value)
+
+    @property
+    @pulumi.getter(name="openFiles")
+    def open_files(self) -> Optional[pulumi.Input[int]]:
+        """
+        The number of files each process may have open
+        """
+        return pulumi.get(self, "open_files")
+
+    @open_files.setter
+    def open_files(self, value: Optional[pulumi.Input[int]]):
+        pulumi.set(self, "open_files", value)
+
+
+@pulumi.input_type
+class WaitForArgs:
+    def __init__(__self__, *,
+                 probe: pulumi.Input['CmdArgs'],
+                 equals: Optional[pulumi.Input[str]] = None,
+                 exit_code: Optional[pulumi.Input[int]] = None,
+                 interval: Optional[pulumi.Input[str]] = None,
+                 json_path: Optional[pulumi.Input[str]] = None,
+                 stdout_match: Optional[pulumi.Input[str]] = None,
+                 timeout: Optional[pulumi.Input[str]] = None):
+        """
+        A probe that runs after create and update until the resource is ready. The resource is ready when the probe exits with `exitCode`, prints stdout matching `stdoutMatch`, or prints JSON whose value at `jsonPath` equals `equals`. Without a criterion the probe must exit with 0.
+        :param pulumi.Input['CmdArgs'] probe: The command that checks whether the resource is ready
+        :param pulumi.Input[str] equals: The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`.
+        :param pulumi.Input[int] exit_code: The exit code of the probe when the resource is ready
+        :param pulumi.Input[str] interval: The time between probes, as a duration. Defaults to `5s`.
+        :param pulumi.Input[str] json_path: The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`.
+        :param pulumi.Input[str] stdout_match: A regular expression that the stdout of the probe matches when the resource is ready
+        :param pulumi.Input[str] timeout: How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`.
+        """
+        pulumi.set(__self__, "probe", probe)
+        if equals is not None:
+            pulumi.set(__self__, "equals", equals)
+        if exit_code is not None:
+            pulumi.set(__self__, "exit_code", exit_code)
+        if interval is not None:
+            pulumi.set(__self__, "interval", interval)
+        if json_path is not None:
+            pulumi.set(__self__, "json_path", json_path)
+        if stdout_match is not None:
+            pulumi.set(__self__, "stdout_match", stdout_match)
+        if timeout is not None:
+            pulumi.set(__self__, "timeout", timeout)
+
+    @property
+    @pulumi.getter
+    def probe(self) -> pulumi.Input['CmdArgs']:
+        """
+        The command that checks whether the resource is ready
+        """
+        return pulumi.get(self, "probe")
+
+    @probe.setter
+    def probe(self, value: pulumi.Input['CmdArgs']):
+        pulumi.set(self, "probe", value)
+
+    @property
+    @pulumi.getter
+    def equals(self) -> Optional[pulumi.Input[str]]:
+        """
+        The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`.
+        """
+        return pulumi.get(self, "equals")
+
+    @equals.setter
+    def equals(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "equals", value)
+
+    @property
+    @pulumi.getter(name="exitCode")
+    def exit_code(self) -> Optional[pulumi.Input[int]]:
+        """
+        The exit code of the probe when the resource is ready
+        """
+        return pulumi.get(self, "exit_code")
+
+    @exit_code.setter
+    def exit_code(self, value: Optional[pulumi.Input[int]]):
+        pulumi.set(self, "exit_code", value)
+
+    @property
+    @pulumi.getter
+    def interval(self) -> Optional[pulumi.Input[str]]:
+        """
+        The time between probes, as a duration. Defaults to `5s`.
+        """
+        return pulumi.get(self, "interval")
+
+    @interval.setter
+    def interval(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "interval", value)
+
+    @property
+    @pulumi.getter(name="jsonPath")
+    def json_path(self) -> Optional[pulumi.Input[str]]:
+        """
+        The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`.
+        """
+        return pulumi.get(self, "json_path")
+
+    @json_path.setter
+    def json_path(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "json_path", value)
+
+    @property
+    @pulumi.getter(name="stdoutMatch")
+    def stdout_match(self) -> Optional[pulumi.Input[str]]:
+        """
+        A regular expression that the stdout of the probe matches when the resource is ready
+        """
+        return pulumi.get(self, "stdout_match")
+
+    @stdout_match.setter
+    def stdout_match(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "stdout_match", value)
+
+    @property
+    @pulumi.getter
+    def timeout(self) -> Optional[pulumi.Input[str]]:
+        """
+        How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`.
+        """
+        return pulumi.get(self, "timeout")
+
+    @timeout.setter
+    def timeout(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "timeout", value)
+
 
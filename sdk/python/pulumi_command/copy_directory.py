@@ -0,0 +1,208 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumigen. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+from . import _utilities
+from . import outputs
+from ._inputs import *
+
+__all__ = ['CopyDirectoryArgs', 'CopyDirectory']
+
+@pulumi.input_type
+class CopyDirectoryArgs:
+    def __init__(__self__, *,
+                 connection: pulumi.Input['ConnectionArgs'],
+                 remote_path: pulumi.Input[str],
+                 source: pulumi.Input[str]):
+        """
+        The set of arguments for constructing a CopyDirectory resource.
+        :param pulumi.Input['ConnectionArgs'] connection: The host to copy to
+        :param pulumi.Input[str] remote_path: The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy.
+        :param pulumi.Input[str] source: The local directory to upload. Relative paths are resolved against the Pulumi project root.
+        """
+        pulumi.set(__self__, "connection", connection)
+        pulumi.set(__self__, "remote_path", remote_path)
+        pulumi.set(__self__, "source", source)
+
+    @property
+    @pulumi.getter
+    def connection(self) -> pulumi.Input['ConnectionArgs']:
+        """
+        The host to copy to
+        """
+        return pulumi.get(self, "connection")
+
+    @connection.setter
+    def connection(self, value: pulumi.Input['ConnectionArgs']):
+        pulumi.set(self, "connection", value)
+
+    @property
+    @pulumi.getter(name="remotePath")
+    def remote_path(self) -> pulumi.Input[str]:
+        """
+        The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy.
+        """
+        return pulumi.get(self, "remote_path")
+
+    @remote_path.setter
+    def remote_path(self, value: pulumi.Input[str]):
+        pulumi.set(self, "remote_path", value)
+
+    @property
+    @pulumi.getter
+    def source(self) -> pulumi.Input[str]:
+        """
+        The local directory to upload. Relative paths are resolved against the Pulumi project root.
+        """
+        return pulumi.get(self, "source")
+
+    @source.setter
+    def source(self, value: pulumi.Input[str]):
+        pulumi.set(self, "source", value)
+
+
+class CopyDirectory(pulumi.CustomResource):
+    @overload
+    def __init__(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 connection: Optional[pulumi.Input[pulumi.InputType['ConnectionArgs']]] = None,
+                 remote_path: Optional[pulumi.Input[str]] = None,
+                 source: Optional[pulumi.Input[str]] = None,
+                 __props__=None):
+        """
+        Upload a directory tree to a remote host over SFTP.
+
+        Files keep their mode bits. Each file is compared by the SHA-256 hash of its content, so an update only uploads the files that changed and removes those that were removed locally. A refresh detects changes made on the host. Deleting the resource removes the remote directory. The ID of the resource is the remote path.
+
+        :param str resource_name: The name of the resource.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        :param pulumi.Input[pulumi.InputType['ConnectionArgs']] connection: The host to copy to
+        :param pulumi.Input[str] remote_path: The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy.
+        :param pulumi.Input[str] source: The local directory to upload. Relative paths are resolved against the Pulumi project root.
+        """
+        ...
+    @overload
+    def __init__(__self__,
+                 resource_name: str,
+                 args: CopyDirectoryArgs,
+                 opts: Optional[pulumi.ResourceOptions] = None):
+        """
+        Upload a directory tree to a remote host over SFTP.
+
+        Files keep their mode bits. Each file is compared by the SHA-256 hash of its content, so an update only uploads the files that changed and removes those that were removed locally. A refresh detects changes made on the host. Deleting the resource removes the remote directory. The ID of the resource is the remote path.
+
+        :param str resource_name: The name of the resource.
+        :param CopyDirectoryArgs args: The arguments to use to populate this resource's properties.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        ...
+    def __init__(__self__, resource_name: str, *args, **kwargs):
+        resource_args, opts = _utilities.get_resource_args_opts(CopyDirectoryArgs, pulumi.ResourceOptions, *args, **kwargs)
+        if resource_args is not None:
+            __self__._internal_init(resource_name, opts, **resource_args.__dict__)
+        else:
+            __self__._internal_init(resource_name, *args, **kwargs)
+
+    def _internal_init(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 connection: Optional[pulumi.Input[pulumi.InputType['ConnectionArgs']]] = None,
+                 remote_path: Optional[pulumi.Input[str]] = None,
+                 source: Optional[pulumi.Input[str]] = None,
+                 __props__=None):
+        if opts is None:
+            opts = pulumi.ResourceOptions()
+        if not isinstance(opts, pulumi.ResourceOptions):
+            raise TypeError('Expected resource options to be a ResourceOptions instance')
+        if opts.version is None:
+            opts.version = _utilities.get_version()
+        if opts.id is None:
+            if __props__ is not None:
+                raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
+            __props__ = CopyDirectoryArgs.__new__(CopyDirectoryArgs)
+
+            if connection is None and not opts.urn:
+                raise TypeError("Missing required property 'connection'")
+            __props__.__dict__["connection"] = connection
+            if remote_path is None and not opts.urn:
+                raise TypeError("Missing required property 'remote_path'")
+            __props__.__dict__["remote_path"] = remote_path
+            if source is None and not opts.urn:
+                raise TypeError("Missing required property 'source'")
+            __props__.__dict__["source"] = source
+            __props__.__dict__["files"] = None
+            __props__.__dict__["sha256"] = None
+        super(CopyDirectory, __self__).__init__(
+            'command:v1:CopyDirectory',
+            resource_name,
+            __props__,
+            opts)
+
+    @staticmethod
+    def get(resource_name: str,
+            id: pulumi.Input[str],
+            opts: Optional[pulumi.ResourceOptions] = None) -> 'CopyDirectory':
+        """
+        Get an existing CopyDirectory resource's state with the given name, id, and optional extra
+        properties used to qualify the lookup.
+
+        :param str resource_name: The unique name of the resulting resource.
+        :param pulumi.Input[str] id: The unique provider ID of the resource to lookup.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        opts = pulumi.ResourceOptions.merge(opts, pulumi.ResourceOptions(id=id))
+
+        __props__ = CopyDirectoryArgs.__new__(CopyDirectoryArgs)
+
+        __props__.__dict__["connection"] = None
+        __props__.__dict__["files"] = None
+        __props__.__dict__["remote_path"] = None
+        __props__.__dict__["sha256"] = None
+        __props__.__dict__["source"] = None
+        return CopyDirectory(resource_name, opts=opts, __props__=__props__)
+
+    @property
+    @pulumi.getter
+    def connection(self) -> pulumi.Output['outputs.Connection']:
+        """
+        The host to copy to
+        """
+        return pulumi.get(self, "connection")
+
+    @property
+    @pulumi.getter
+    def files(self) -> pulumi.Output[Mapping[str, Any]]:
+        """
+        The mode and SHA-256 hash of each file and directory of the tree, by its path relative to the directory
+        """
+        return pulumi.get(self, "files")
+
+    @property
+    @pulumi.getter(name="remotePath")
+    def remote_path(self) -> pulumi.Output[str]:
+        """
+        The path of the directory on the host. Missing parent directories are created. Changing it replaces the copy.
+        """
+        return pulumi.get(self, "remote_path")
+
+    @property
+    @pulumi.getter
+    def sha256(self) -> pulumi.Output[str]:
+        """
+        The SHA-256 hash of the tree, covering the paths, modes and content of its files and directories
+        """
+        return pulumi.get(self, "sha256")
+
+    @property
+    @pulumi.getter
+    def source(self) -> pulumi.Output[str]:
+        """
+        The local directory to upload. Relative paths are resolved against the Pulumi project root.
+        """
+        return pulumi.get(self, "source")
+
@@ -0,0 +1,294 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumigen. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+from . import _utilities
+from . import outputs
+from ._inputs import *
+
+__all__ = ['CopyFileArgs', 'CopyFile']
+
+@pulumi.input_type
+class CopyFileArgs:
+    def __init__(__self__, *,
+                 connection: pulumi.Input['ConnectionArgs'],
+                 remote_path: pulumi.Input[str],
+                 content: Optional[pulumi.Input[str]] = None,
+                 content_base64: Optional[pulumi.Input[str]] = None,
+                 mode: Optional[pulumi.Input[str]] = None,
+                 source: Optional[pulumi.Input[str]] = None):
+        """
+        The set of arguments for constructing a CopyFile resource.
+        :param pulumi.Input['ConnectionArgs'] connection: The host to copy to
+        :param pulumi.Input[str] remote_path: The path on the host. Missing parent directories are created. Changing it replaces the copy.
+        :param pulumi.Input[str] content: The content of the file
+        :param pulumi.Input[str] content_base64: The base64-encoded content of a binary file
+        :param pulumi.Input[str] mode: The permission bits of the remote file in octal, such as `0600`. Defaults to the mode of `source`, or `0644`.
+        :param pulumi.Input[str] source: The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of `source`, `content` and `contentBase64`.
+        """
+        pulumi.set(__self__, "connection", connection)
+        pulumi.set(__self__, "remote_path", remote_path)
+        if content is not None:
+            pulumi.set(__self__, "content", content)
+        if content_base64 is not None:
+            pulumi.set(__self__, "content_base64", content_base64)
+        if mode is not None:
+            pulumi.set(__self__, "mode", mode)
+        if source is not None:
+            pulumi.set(__self__, "source", source)
+
+    @property
+    @pulumi.getter
+    def connection(self) -> pulumi.Input['ConnectionArgs']:
+        """
+        The host to copy to
+        """
+        return pulumi.get(self, "connection")
+
+    @connection.setter
+    def connection(self, value: pulumi.Input['ConnectionArgs']):
+        pulumi.set(self, "connection", value)
+
+    @property
+    @pulumi.getter(name="remotePath")
+    def remote_path(self) -> pulumi.Input[str]:
+        """
+        The path on the host. Missing parent directories are created. Changing it replaces the copy.
+        """
+        return pulumi.get(self, "remote_path")
+
+    @remote_path.setter
+    def remote_path(self, value: pulumi.Input[str]):
+        pulumi.set(self, "remote_path", value)
+
+    @property
+    @pulumi.getter
+    def content(self) -> Optional[pulumi.Input[str]]:
+        """
+        The content of the file
+        """
+        return pulumi.get(self, "content")
+
+    @content.setter
+    def content(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "content", value)
+
+    @property
+    @pulumi.getter(name="contentBase64")
+    def content_base64(self) -> Optional[pulumi.Input[str]]:
+        """
+        The base64-encoded content of a binary file
+        """
+        return pulumi.get(self, "content_base64")
+
+    @content_base64.setter
+    def content_base64(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "content_base64", value)
+
+    @property
+    @pulumi.getter
+    def mode(self) -> Optional[pulumi.Input[str]]:
+        """
+        The permission bits of the remote file in octal, such as `0600`. Defaults to the mode of `source`, or `0644`.
+        """
+        return pulumi.get(self, "mode")
+
+    @mode.setter
+    def mode(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "mode", value)
+
+    @property
+    @pulumi.getter
+    def source(self) -> Optional[pulumi.Input[str]]:
+        """
+        The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of `source`, `content` and `contentBase64`.
+        """
+        return pulumi.get(self, "source")
+
+    @source.setter
+    def source(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "source", value)
+
+
+class CopyFile(pulumi.CustomResource):
+    @overload
+    def __init__(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 connection: Optional[pulumi.Input[pulumi.InputType['ConnectionArgs']]] = None,
+                 content: Optional[pulumi.Input[str]] = None,
+                 content_base64: Optional[pulumi.Input[str]] = None,
+                 mode: Optional[pulumi.Input[str]] = None,
+                 remote_path: Optional[pulumi.Input[str]] = None,
+                 source: Optional[pulumi.Input[str]] = None,
+                 __props__=None):
+        """
+        Upload a file to a remote host over SFTP.
+
+        The file is written atomically with its mode and compared by the SHA-256 hash of its content, so it is only uploaded again when its content changes. A refresh detects changes made on the host and the next update uploads the file again. Deleting the resource removes the remote file. The ID of the resource is the remote path.
+
+        :param str resource_name: The name of the resource.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        :param pulumi.Input[pulumi.InputType['ConnectionArgs']] connection: The host to copy to
+        :param pulumi.Input[str] content: The content of the file
+        :param pulumi.Input[str] content_base64: The base64-encoded content of a binary file
+        :param pulumi.Input[str] mode: The permission bits of the remote file in octal, such as `0600`. Defaults to the mode of `source`, or `0644`.
+        :param pulumi.Input[str] remote_path: The path on the host. Missing parent directories are created. Changing it replaces the copy.
+        :param pulumi.Input[str] source: The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of `source`, `content` and `contentBase64`.
+        """
+        ...
+    @overload
+    def __init__(__self__,
+                 resource_name: str,
+                 args: CopyFileArgs,
+                 opts: Optional[pulumi.ResourceOptions] = None):
+        """
+        Upload a file to a remote host over SFTP.
+
+        The file is written atomically with its mode and compared by the SHA-256 hash of its content, so it is only uploaded again when its content changes. A refresh detects changes made on the host and the next update uploads the file again. Deleting the resource removes the remote file. The ID of the resource is the remote path.
+
+        :param str resource_name: The name of the resource.
+        :param CopyFileArgs args: The arguments to use to populate this resource's properties.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        ...
+    def __init__(__self__, resource_name: str, *args, **kwargs):
+        resource_args, opts = _utilities.get_resource_args_opts(CopyFileArgs, pulumi.ResourceOptions, *args, **kwargs)
+        if resource_args is not None:
+            __self__._internal_init(resource_name, opts, **resource_args.__dict__)
+        else:
+            __self__._internal_init(resource_name, *args, **kwargs)
+
+    def _internal_init(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 connection: Optional[pulumi.Input[pulumi.InputType['ConnectionArgs']]] = None,
+                 content: Optional[pulumi.Input[str]] = None,
+                 content_base64: Optional[pulumi.Input[str]] = None,
+                 mode: Optional[pulumi.Input[str]] = None,
+                 remote_path: Optional[pulumi.Input[str]] = None,
+                 source: Optional[pulumi.Input[str]] = None,
+                 __props__=None):
+        if opts is None:
+            opts = pulumi.ResourceOptions()
+        if not isinstance(opts, pulumi.ResourceOptions):
+            raise TypeError('Expected resource options to be a ResourceOptions instance')
+        if opts.version is None:
+            opts.version = _utilities.get_version()
+        if opts.id is None:
+            if __props__ is not None:
+                raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
+            __props__ = CopyFileArgs.__new__(CopyFileArgs)
+
+            if connection is None and not opts.urn:
+                raise TypeError("Missing required property 'connection'")
+            __props__.__dict__["connection"] = connection
+            __props__.__dict__["content"] = content
+            __props__.__dict__["content_base64"] = content_base64
+            __props__.__dict__["mode"] = mode
+            if remote_path is None and not opts.urn:
+                raise TypeError("Missing required property 'remote_path'")
+            __props__.__dict__["remote_path"] = remote_path
+            __props__.__dict__["source"] = source
+            __props__.__dict__["sha256"] = None
+            __props__.__dict__["size"] = None
+        super(CopyFile, __self__).__init__(
+            'command:v1:CopyFile',
+            resource_name,
+            __props__,
+            opts)
+
+    @staticmethod
+    def get(resource_name: str,
+            id: pulumi.Input[str],
+            opts: Optional[pulumi.ResourceOptions] = None) -> 'CopyFile':
+        """
+        Get an existing CopyFile resource's state with the given name, id, and optional extra
+        properties used to qualify the lookup.
+
+        :param str resource_name: The unique name of the resulting resource.
+        :param pulumi.Input[str] id: The unique provider ID of the resource to lookup.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        opts = pulumi.ResourceOptions.merge(opts, pulumi.ResourceOptions(id=id))
+
+        __props__ = CopyFileArgs.__new__(CopyFileArgs)
+
+        __props__.__dict__["connection"] = None
+        __props__.__dict__["content"] = None
+        __props__.__dict__["content_base64"] = None
+        __props__.__dict__["mode"] = None
+        __props__.__dict__["remote_path"] = None
+        __props__.__dict__["sha256"] = None
+        __props__.__dict__["size"] = None
+        __props__.__dict__["source"] = None
+        return CopyFile(resource_name, opts=opts, __props__=__props__)
+
+    @property
+    @pulumi.getter
+    def connection(self) -> pulumi.Output['outputs.Connection']:
+        """
+        The host to copy to
+        """
+        return pulumi.get(self, "connection")
+
+    @property
+    @pulumi.getter
+    def content(self) -> pulumi.Output[Optional[str]]:
+        """
+        The content of the file
+        """
+        return pulumi.get(self, "content")
+
+    @property
+    @pulumi.getter(name="contentBase64")
+    def content_base64(self) -> pulumi.Output[Optional[str]]:
+        """
+        The base64-encoded content of a binary file
+        """
+        return pulumi.get(self, "content_base64")
+
+    @property
+    @pulumi.getter
+    def mode(self) -> pulumi.Output[str]:
+        """
+        The permission bits of the remote file in octal, such as `0600`. Defaults to the mode of `source`, or `0644`.
+        """
+        return pulumi.get(self, "mode")
+
+    @property
+    @pulumi.getter(name="remotePath")
+    def remote_path(self) -> pulumi.Output[str]:
+        """
+        The path on the host. Missing parent directories are created. Changing it replaces the copy.
+        """
+        return pulumi.get(self, "remote_path")
+
+    @property
+    @pulumi.getter
+    def sha256(self) -> pulumi.Output[str]:
+        """
+        The SHA-256 hash of the content of the file
+        """
+        return pulumi.get(self, "sha256")
+
+    @property
+    @pulumi.getter
+    def size(self) -> pulumi.Output[int]:
+        """
+        The size of the file in bytes
+        """
+        return pulumi.get(self, "size")
+
+    @property
+    @pulumi.getter
+    def source(self) -> pulumi.Output[Optional[str]]:
+        """
+        The local file to upload. Relative paths are resolved against the Pulumi project root. Set one of `source`, `content` and `contentBase64`.
+        """
+        return pulumi.get(self, "source")
+
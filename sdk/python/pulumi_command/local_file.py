@@ -0,0 +1,262 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumigen. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+from . import _utilities
+
+__all__ = ['LocalFileArgs', 'LocalFile']
+
+@pulumi.input_type
+class LocalFileArgs:
+    def __init__(__self__, *,
+                 path: pulumi.Input[str],
+                 content: Optional[pulumi.Input[str]] = None,
+                 content_base64: Optional[pulumi.Input[str]] = None,
+                 mode: Optional[pulumi.Input[str]] = None,
+                 owner: Optional[pulumi.Input[str]] = None):
+        """
+        The set of arguments for constructing a LocalFile resource.
+        :param pulumi.Input[str] path: The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file.
+        :param pulumi.Input[str] content: The content of the file. Set either `content` or `contentBase64`.
+        :param pulumi.Input[str] content_base64: The base64-encoded content of a binary file
+        :param pulumi.Input[str] mode: The permission bits of the file in octal, such as `0600`. Defaults to `0644`.
+        :param pulumi.Input[str] owner: The user that owns the file, optionally followed by a colon and its group, such as `www-data:adm`. Names and numeric IDs are accepted.
+        """
+        pulumi.set(__self__, "path", path)
+        if content is not None:
+            pulumi.set(__self__, "content", content)
+        if content_base64 is not None:
+            pulumi.set(__self__, "content_base64", content_base64)
+        if mode is not None:
+            pulumi.set(__self__, "mode", mode)
+        if owner is not None:
+            pulumi.set(__self__, "owner", owner)
+
+    @property
+    @pulumi.getter
+    def path(self) -> pulumi.Input[str]:
+        """
+        The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file.
+        """
+        return pulumi.get(self, "path")
+
+    @path.setter
+    def path(self, value: pulumi.Input[str]):
+        pulumi.set(self, "path", value)
+
+    @property
+    @pulumi.getter
+    def content(self) -> Optional[pulumi.Input[str]]:
+        """
+        The content of the file. Set either `content` or `contentBase64`.
+        """
+        return pulumi.get(self, "content")
+
+    @content.setter
+    def content(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "content", value)
+
+    @property
+    @pulumi.getter(name="contentBase64")
+    def content_base64(self) -> Optional[pulumi.Input[str]]:
+        """
+        The base64-encoded content of a binary file
+        """
+        return pulumi.get(self, "content_base64")
+
+    @content_base64.setter
+    def content_base64(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "content_base64", value)
+
+    @property
+    @pulumi.getter
+    def mode(self) -> Optional[pulumi.Input[str]]:
+        """
+        The permission bits of the file in octal, such as `0600`. Defaults to `0644`.
+        """
+        return pulumi.get(self, "mode")
+
+    @mode.setter
+    def mode(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "mode", value)
+
+    @property
+    @pulumi.getter
+    def owner(self) -> Optional[pulumi.Input[str]]:
+        """
+        The user that owns the file, optionally followed by a colon and its group, such as `www-data:adm`. Names and numeric IDs are accepted.
+        """
+        return pulumi.get(self, "owner")
+
+    @owner.setter
+    def owner(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "owner", value)
+
+
+class LocalFile(pulumi.CustomResource):
+    @overload
+    def __init__(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 content: Optional[pulumi.Input[str]] = None,
+                 content_base64: Optional[pulumi.Input[str]] = None,
+                 mode: Optional[pulumi.Input[str]] = None,
+                 owner: Optional[pulumi.Input[str]] = None,
+                 path: Optional[pulumi.Input[str]] = None,
+                 __props__=None):
+        """
+        A file on the machine running Pulumi.
+
+        The file is written atomically and compared by the SHA-256 hash of its content, so it is only rewritten when its content, mode or owner change. A refresh detects changes made outside of Pulumi and the next update restores the file. The ID of the resource is its path, which can be used to import an existing file.
+
+        :param str resource_name: The name of the resource.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        :param pulumi.Input[str] content: The content of the file. Set either `content` or `contentBase64`.
+        :param pulumi.Input[str] content_base64: The base64-encoded content of a binary file
+        :param pulumi.Input[str] mode: The permission bits of the file in octal, such as `0600`. Defaults to `0644`.
+        :param pulumi.Input[str] owner: The user that owns the file, optionally followed by a colon and its group, such as `www-data:adm`. Names and numeric IDs are accepted.
+        :param pulumi.Input[str] path: The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file.
+        """
+        ...
+    @overload
+    def __init__(__self__,
+                 resource_name: str,
+                 args: LocalFileArgs,
+                 opts: Optional[pulumi.ResourceOptions] = None):
+        """
+        A file on the machine running Pulumi.
+
+        The file is written atomically and compared by the SHA-256 hash of its content, so it is only rewritten when its content, mode or owner change. A refresh detects changes made outside of Pulumi and the next update restores the file. The ID of the resource is its path, which can be used to import an existing file.
+
+        :param str resource_name: The name of the resource.
+        :param LocalFileArgs args: The arguments to use to populate this resource's properties.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        ...
+    def __init__(__self__, resource_name: str, *args, **kwargs):
+        resource_args, opts = _utilities.get_resource_args_opts(LocalFileArgs, pulumi.ResourceOptions, *args, **kwargs)
+        if resource_args is not None:
+            __self__._internal_init(resource_name, opts, **resource_args.__dict__)
+        else:
+            __self__._internal_init(resource_name, *args, **kwargs)
+
+    def _internal_init(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 content: Optional[pulumi.Input[str]] = None,
+                 content_base64: Optional[pulumi.Input[str]] = None,
+                 mode: Optional[pulumi.Input[str]] = None,
+                 owner: Optional[pulumi.Input[str]] = None,
+                 path: Optional[pulumi.Input[str]] = None,
+                 __props__=None):
+        if opts is None:
+            opts = pulumi.ResourceOptions()
+        if not isinstance(opts, pulumi.ResourceOptions):
+            raise TypeError('Expected resource options to be a ResourceOptions instance')
+        if opts.version is None:
+            opts.version = _utilities.get_version()
+        if opts.id is None:
+            if __props__ is not None:
+                raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
+            __props__ = LocalFileArgs.__new__(LocalFileArgs)
+
+            __props__.__dict__["content"] = content
+            __props__.__dict__["content_base64"] = content_base64
+            __props__.__dict__["mode"] = mode
+            __props__.__dict__["owner"] = owner
+            if path is None and not opts.urn:
+                raise TypeError("Missing required property 'path'")
+            __props__.__dict__["path"] = path
+            __props__.__dict__["sha256"] = None
+            __props__.__dict__["size"] = None
+        super(LocalFile, __self__).__init__(
+            'command:v1:LocalFile',
+            resource_name,
+            __props__,
+            opts)
+
+    @staticmethod
+    def get(resource_name: str,
+            id: pulumi.Input[str],
+            opts: Optional[pulumi.ResourceOptions] = None) -> 'LocalFile':
+        """
+        Get an existing LocalFile resource's state with the given name, id, and optional extra
+        properties used to qualify the lookup.
+
+        :param str resource_name: The unique name of the resulting resource.
+        :param pulumi.Input[str] id: The unique provider ID of the resource to lookup.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        opts = pulumi.ResourceOptions.merge(opts, pulumi.ResourceOptions(id=id))
+
+        __props__ = LocalFileArgs.__new__(LocalFileArgs)
+
+        __props__.__dict__["content"] = None
+        __props__.__dict__["content_base64"] = None
+        __props__.__dict__["mode"] = None
+        __props__.__dict__["owner"] = None
+        __props__.__dict__["path"] = None
+        __props__.__dict__["sha256"] = None
+        __props__.__dict__["size"] = None
+        return LocalFile(resource_name, opts=opts, __props__=__props__)
+
+    @property
+    @pulumi.getter
+    def content(self) -> pulumi.Output[Optional[str]]:
+        """
+        The content of the file. Set either `content` or `contentBase64`.
+        """
+        return pulumi.get(self, "content")
+
+    @property
+    @pulumi.getter(name="contentBase64")
+    def content_base64(self) -> pulumi.Output[Optional[str]]:
+        """
+        The base64-encoded content of a binary file
+        """
+        return pulumi.get(self, "content_base64")
+
+    @property
+    @pulumi.getter
+    def mode(self) -> pulumi.Output[str]:
+        """
+        The permission bits of the file in octal, such as `0600`. Defaults to `0644`.
+        """
+        return pulumi.get(self, "mode")
+
+    @property
+    @pulumi.getter
+    def owner(self) -> pulumi.Output[Optional[str]]:
+        """
+        The user that owns the file, optionally followed by a colon and its group, such as `www-data:adm`. Names and numeric IDs are accepted.
+        """
+        return pulumi.get(self, "owner")
+
+    @property
+    @pulumi.getter
+    def path(self) -> pulumi.Output[str]:
+        """
+        The path of the file. Relative paths are resolved against the Pulumi project root. Changing it replaces the file.
+        """
+        return pulumi.get(self, "path")
+
+    @property
+    @pulumi.getter
+    def sha256(self) -> pulumi.Output[str]:
+        """
+        The SHA-256 hash of the content of the file
+        """
+        return pulumi.get(self, "sha256")
+
+    @property
+    @pulumi.getter
+    def size(self) -> pulumi.Output[int]:
+        """
+        The size of the file in bytes
+        """
+        return pulumi.get(self, "size")
+
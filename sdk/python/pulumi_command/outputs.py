@@ -7,9 +7,17 @@ import pulumi
 import pulumi.runtime
 from typing import Any, Mapping, Optional, Sequence, Union, overload
 from . import _utilities
+from . import outputs
 
 __all__ = [
     'Cmd',
+    'Connection',
+    'IdFrom',
+    'Image',
+    'Mount',
+    'Retry',
+    'Sandbox',
+    'WaitFor',
 ]
 
 @pulumi.output_type
@@ -17,34 +25,224 @@ class Cmd(dict):
     """
     Command specification
     """
+    @staticmethod
+    def __key_warning(key: str):
+        suggest = None
+        if key == "allowedExitCodes":
+            suggest = "allowed_exit_codes"
+        elif key == "envAllowlist":
+            suggest = "env_allowlist"
+        elif key == "inheritEnv":
+            suggest = "inherit_env"
+        elif key == "outputFormat":
+            suggest = "output_format"
+        elif key == "scriptFile":
+            suggest = "script_file"
+        elif key == "stdinFrom":
+            suggest = "stdin_from"
+        elif key == "stopGracePeriod":
+            suggest = "stop_grace_period"
+        elif key == "stopSignal":
+            suggest = "stop_signal"
+        elif key == "unsetEnv":
+            suggest = "unset_env"
+
+        if suggest:
+            pulumi.log.warn(f"Key '{key}' not found in Cmd. Access the value via the '{suggest}' property getter instead.")
+
+    def __getitem__(self, key: str) -> Any:
+        Cmd.__key_warning(key)
+        return super().__getitem__(key)
+
+    def get(self, key: str, default = None) -> Any:
+        Cmd.__key_warning(key)
+        return super().get(key, default)
+
     def __init__(__self__, *,
-                 command: Sequence[str],
+                 allowed_exit_codes: Optional[Sequence[int]] = None,
+                 command: Optional[Sequence[str]] = None,
+                 dir: Optional[str] = None,
+                 env_allowlist: Optional[Sequence[str]] = None,
                  environment: Optional[Mapping[str, str]] = None,
-                 stdin: Optional[str] = None):
+                 image: Optional['outputs.Image'] = None,
+                 inherit_env: Optional[str] = None,
+                 interpreter: Optional[str] = None,
+                 output_format: Optional[str] = None,
+                 retry: Optional['outputs.Retry'] = None,
+                 sandbox: Optional['outputs.Sandbox'] = None,
+                 script: Optional[str] = None,
+                 script_file: Optional[bool] = None,
+                 stdin: Optional[str] = None,
+                 stdin_from: Optional[str] = None,
+                 stop_grace_period: Optional[str] = None,
+                 stop_signal: Optional[str] = None,
+                 timeout: Optional[str] = None,
+                 unset_env: Optional[Sequence[str]] = None):
         """
         Command specification
-        :param Sequence[str] command: Specifiy the command to run as an array of arguments
+        :param Sequence[int] allowed_exit_codes: Non-zero exit codes that are treated as success.
+        :param Sequence[str] command: Specifiy the command to run as an array of arguments. Set either `command` or `script`.
+        :param str dir: The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+        :param Sequence[str] env_allowlist: The names of the variables to inherit when `inheritEnv` is `allowlist`.
+        :param 'Image' image: Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+        :param str inherit_env: Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+               
+               The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+        :param str interpreter: The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+        :param str output_format: Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+        :param 'Retry' retry: Retry the command when it fails. Timeouts and cancellation are not retried.
+        :param 'Sandbox' sandbox: Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+        :param str script: A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+        :param bool script_file: Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
         :param str stdin: Pass the stdin to a command
+        :param str stdin_from: Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`. Not available to the create command.
+        :param str stop_grace_period: How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+        :param str stop_signal: The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+        :param str timeout: The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+        :param Sequence[str] unset_env: The names of inherited variables to remove from the command's environment.
         """
-        pulumi.set(__self__, "command", command)
+        if allowed_exit_codes is not None:
+            pulumi.set(__self__, "allowed_exit_codes", allowed_exit_codes)
+        if command is not None:
+            pulumi.set(__self__, "command", command)
+        if dir is not None:
+            pulumi.set(__self__, "dir", dir)
+        if env_allowlist is not None:
+            pulumi.set(__self__, "env_allowlist", env_allowlist)
         if environment is not None:
             pulumi.set(__self__, "environment", environment)
+        if image is not None:
+            pulumi.set(__self__, "image", image)
+        if inherit_env is not None:
+            pulumi.set(__self__, "inherit_env", inherit_env)
+        if interpreter is not None:
+            pulumi.set(__self__, "interpreter", interpreter)
+        if output_format is not None:
+            pulumi.set(__self__, "output_format", output_format)
+        if retry is not None:
+            pulumi.set(__self__, "retry", retry)
+        if sandbox is not None:
+            pulumi.set(__self__, "sandbox", sandbox)
+        if script is not None:
+            pulumi.set(__self__, "script", script)
+        if script_file is not None:
+            pulumi.set(__self__, "script_file", script_file)
         if stdin is not None:
             pulumi.set(__self__, "stdin", stdin)
+        if stdin_from is not None:
+            pulumi.set(__self__, "stdin_from", stdin_from)
+        if stop_grace_period is not None:
+            pulumi.set(__self__, "stop_grace_period", stop_grace_period)
+        if stop_signal is not None:
+            pulumi.set(__self__, "stop_signal", stop_signal)
+        if timeout is not None:
+            pulumi.set(__self__, "timeout", timeout)
+        if unset_env is not None:
+            pulumi.set(__self__, "unset_env", unset_env)
+
+    @property
+    @pulumi.getter(name="allowedExitCodes")
+    def allowed_exit_codes(self) -> Optional[Sequence[int]]:
+        """
+        Non-zero exit codes that are treated as success.
+        """
+        return pulumi.get(self, "allowed_exit_codes")
 
     @property
     @pulumi.getter
-    def command(self) -> Sequence[str]:
+    def command(self) -> Optional[Sequence[str]]:
         """
-        Specifiy the command to run as an array of arguments
+        Specifiy the command to run as an array of arguments. Set either `command` or `script`.
         """
         return pulumi.get(self, "command")
 
+    @property
+    @pulumi.getter
+    def dir(self) -> Optional[str]:
+        """
+        The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+        """
+        return pulumi.get(self, "dir")
+
+    @property
+    @pulumi.getter(name="envAllowlist")
+    def env_allowlist(self) -> Optional[Sequence[str]]:
+        """
+        The names of the variables to inherit when `inheritEnv` is `allowlist`.
+        """
+        return pulumi.get(self, "env_allowlist")
+
     @property
     @pulumi.getter
     def environment(self) -> Optional[Mapping[str, str]]:
         return pulumi.get(self, "environment")
 
+    @property
+    @pulumi.getter
+    def image(self) -> Optional['outputs.Image']:
+        """
+        Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+        """
+        return pulumi.get(self, "image")
+
+    @property
+    @pulumi.getter(name="inheritEnv")
+    def inherit_env(self) -> Optional[str]:
+        """
+        Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+
+        The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+        """
+        return pulumi.get(self, "inherit_env")
+
+    @property
+    @pulumi.getter
+    def interpreter(self) -> Optional[str]:
+        """
+        The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+        """
+        return pulumi.get(self, "interpreter")
+
+    @property
+    @pulumi.getter(name="outputFormat")
+    def output_format(self) -> Optional[str]:
+        """
+        Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+        """
+        return pulumi.get(self, "output_format")
+
+    @property
+    @pulumi.getter
+    def retry(self) -> Optional['outputs.Retry']:
+        """
+        Retry the command when it fails. Timeouts and cancellation are not retried.
+        """
+        return pulumi.get(self, "retry")
+
+    @property
+    @pulumi.getter
+    def sandbox(self) -> Optional['outputs.Sandbox']:
+        """
+        Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+        """
+        return pulumi.get(self, "sandbox")
+
+    @property
+    @pulumi.getter
+    def script(self) -> Optional[str]:
+        """
+        A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+        """
+        return pulumi.get(self, "script")
+
+    @property
+    @pulumi.getter(name="scriptFile")
+    def script_file(self) -> Optional[bool]:
+        """
+        Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+        """
+        return pulumi.get(self, "script_file")
+
     @property
     @pulumi.getter
     def stdin(self) -> Optional[str]:
@@ -53,4 +251,700 @@ class Cmd(dict):
         """
         return pulumi.get(self, "stdin")
 
+    @property
+    @pulumi.getter(name="stdinFrom")
+    def stdin_from(self) -> Optional[str]:
+        """
+        Set to `previousState` to pass a JSON document of the previous `stdout`, `stderr`, `parsed` output and `inputs` of the resource to stdin instead of `stdin`. Not available to the create command.
+        """
+        return pulumi.get(self, "stdin_from")
+
+    @property
+    @pulumi.getter(name="stopGracePeriod")
+    def stop_grace_period(self) -> Optional[str]:
+        """
+        How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+        """
+        return pulumi.get(self, "stop_grace_period")
+
+    @property
+    @pulumi.getter(name="stopSignal")
+    def stop_signal(self) -> Optional[str]:
+        """
+        The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+        """
+        return pulumi.get(self, "stop_signal")
+
+    @property
+    @pulumi.getter
+    def timeout(self) -> Optional[str]:
+        """
+        The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+        """
+        return pulumi.get(self, "timeout")
+
+    @property
+    @pulumi.getter(name="unsetEnv")
+    def unset_env(self) -> Optional[Sequence[str]]:
+        """
+        The names of inherited variables to remove from the command's environment.
+        """
+        return pulumi.get(self, "unset_env")
+
+
+@pulumi.output_type
+class Connection(dict):
+    """
+    How to reach a host over SSH. The user authenticates with `privateKey`, `password` or an SSH agent, which is used by default when `SSH_AUTH_SOCK` is set and neither of the others is.
+    """
+    @staticmethod
+    def __key_warning(key: str):
+        suggest = None
+        if key == "agentSocketPath":
+            suggest = "agent_socket_path"
+        elif key == "dialTimeout":
+            suggest = "dial_timeout"
+        elif key == "hostKey":
+            suggest = "host_key"
+        elif key == "privateKey":
+            suggest = "private_key"
+        elif key == "privateKeyPassword":
+            suggest = "private_key_password"
+
+        if suggest:
+            pulumi.log.warn(f"Key '{key}' not found in Connection. Access the value via the '{suggest}' property getter instead.")
+
+    def __getitem__(self, key: str) -> Any:
+        Connection.__key_warning(key)
+        return super().__getitem__(key)
+
+    def get(self, key: str, default = None) -> Any:
+        Connection.__key_warning(key)
+        return super().get(key, default)
+
+    def __init__(__self__, *,
+                 host: str,
+                 agent_socket_path: Optional[str] = None,
+                 dial_timeout: Optional[str] = None,
+                 host_key: Optional[str] = None,
+                 password: Optional[str] = None,
+                 port: Optional[int] = None,
+                 private_key: Optional[str] = None,
+                 private_key_password: Optional[str] = None,
+                 proxy: Optional['outputs.Connection'] = None,
+                 user: Optional[str] = None):
+        """
+        How to reach a host over SSH. The user authenticates with `privateKey`, `password` or an SSH agent, which is used by default when `SSH_AUTH_SOCK` is set and neither of the others is.
+        :param str host: The address of the host
+        :param str agent_socket_path: The path of the socket of an SSH agent that holds the keys of the user
+        :param str dial_timeout: How long to keep trying to connect to a host that is not reachable yet, such as a machine that is still booting, as a duration. Defaults to `2m`.
+        :param str host_key: Pins the key of the host, as a public key in `authorized_keys` format such as `ssh-ed25519 AAAA...` or as a fingerprint such as `SHA256:...`. Without it, any host key is accepted.
+        :param str password: The password of the user
+        :param int port: The port of the SSH server. Defaults to 22.
+        :param str private_key: The private key of the user in PEM format
+        :param str private_key_password: The password of an encrypted `privateKey`
+        :param 'Connection' proxy: A bastion host through which the host is reached
+        :param str user: The user to log in as. Defaults to `root`.
+        """
+        pulumi.set(__self__, "host", host)
+        if agent_socket_path is not None:
+            pulumi.set(__self__, "agent_socket_path", agent_socket_path)
+        if dial_timeout is not None:
+            pulumi.set(__self__, "dial_timeout", dial_timeout)
+        if host_key is not None:
+            pulumi.set(__self__, "host_key", host_key)
+        if password is not None:
+            pulumi.set(__self__, "password", password)
+        if port is not None:
+            pulumi.set(__self__, "port", port)
+        if private_key is not None:
+            pulumi.set(__self__, "private_key", private_key)
+        if private_key_password is not None:
+            pulumi.set(__self__, "private_key_password", private_key_password)
+        if proxy is not None:
+            pulumi.set(__self__, "proxy", proxy)
+        if user is not None:
+            pulumi.set(__self__, "user", user)
+
+    @property
+    @pulumi.getter
+    def host(self) -> str:
+        """
+        The address of the host
+        """
+        return pulumi.get(self, "host")
+
+    @property
+    @pulumi.getter(name="agentSocketPath")
+    def agent_socket_path(self) -> Optional[str]:
+        """
+        The path of the socket of an SSH agent that holds the keys of the user
+        """
+        return pulumi.get(self, "agent_socket_path")
+
+    @property
+    @pulumi.getter(name="dialTimeout")
+    def dial_timeout(self) -> Optional[str]:
+        """
+        How long to keep trying to connect to a host that is not reachable yet, such as a machine that is still booting, as a duration. Defaults to `2m`.
+        """
+        return pulumi.get(self, "dial_timeout")
+
+    @property
+    @pulumi.getter(name="hostKey")
+    def host_key(self) -> Optional[str]:
+        """
+        Pins the key of the host, as a public key in `authorized_keys` format such as `ssh-ed25519 AAAA...` or as a fingerprint such as `SHA256:...`. Without it, any host key is accepted.
+        """
+        return pulumi.get(self, "host_key")
+
+    @property
+    @pulumi.getter
+    def password(self) -> Optional[str]:
+        """
+        The password of the user
+        """
+        return pulumi.get(self, "password")
+
+    @property
+    @pulumi.getter
+    def port(self) -> Optional[int]:
+        """
+        The port of the SSH server. Defaults to 22.
+        """
+        return pulumi.get(self, "port")
+
+    @property
+    @pulumi.getter(name="privateKey")
+    def private_key(self) -> Optional[str]:
+        """
+        The private key of the user in PEM format
+        """
+        return pulumi.get(self, "private_key")
+
+    @property
+    @pulumi.getter(name="privateKeyPassword")
+    def private_key_password(self) -> Optional[str]:
+        """
+        The password of an encrypted `privateKey`
+        """
+        return pulumi.get(self, "private_key_password")
+
+    @property
+    @pulumi.getter
+    def proxy(self) -> Optional['outputs.Connection']:
+        """
+        A bastion host through which the host is reached
+        """
+        return pulumi.get(self, "proxy")
+
+    @property
+    @pulumi.getter
+    def user(self) -> Optional[str]:
+        """
+        The user to log in as. Defaults to `root`.
+        """
+        return pulumi.get(self, "user")
+
+
+@pulumi.output_type
+class IdFrom(dict):
+    """
+    How the ID of a resource is derived. Set exactly one field.
+    """
+    def __init__(__self__, *,
+                 path: Optional[str] = None,
+                 stdout: Optional[bool] = None,
+                 value: Optional[str] = None):
+        """
+        How the ID of a resource is derived. Set exactly one field.
+        :param str path: The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`.
+        :param bool stdout: Use the stdout of the create command, without surrounding whitespace
+        :param str value: A static ID
+        """
+        if path is not None:
+            pulumi.set(__self__, "path", path)
+        if stdout is not None:
+            pulumi.set(__self__, "stdout", stdout)
+        if value is not None:
+            pulumi.set(__self__, "value", value)
+
+    @property
+    @pulumi.getter
+    def path(self) -> Optional[str]:
+        """
+        The path of a value in the parsed output of the create command, such as `items[0].id`. Requires `outputFormat`.
+        """
+        return pulumi.get(self, "path")
+
+    @property
+    @pulumi.getter
+    def stdout(self) -> Optional[bool]:
+        """
+        Use the stdout of the create command, without surrounding whitespace
+        """
+        return pulumi.get(self, "stdout")
+
+    @property
+    @pulumi.getter
+    def value(self) -> Optional[str]:
+        """
+        A static ID
+        """
+        return pulumi.get(self, "value")
+
+
+@pulumi.output_type
+class Image(dict):
+    """
+    A container image to run a command in.
+    """
+    @staticmethod
+    def __key_warning(key: str):
+        suggest = None
+        if key == "networkMode":
+            suggest = "network_mode"
+
+        if suggest:
+            pulumi.log.warn(f"Key '{key}' not found in Image. Access the value via the '{suggest}' property getter instead.")
+
+    def __getitem__(self, key: str) -> Any:
+        Image.__key_warning(key)
+        return super().__getitem__(key)
+
+    def get(self, key: str, default = None) -> Any:
+        Image.__key_warning(key)
+        return super().get(key, default)
+
+    def __init__(__self__, *,
+                 name: str,
+                 mounts: Optional[Sequence['outputs.Mount']] = None,
+                 network_mode: Optional[str] = None,
+                 user: Optional[str] = None):
+        """
+        A container image to run a command in.
+        :param str name: The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+        :param Sequence['Mount'] mounts: Paths of the machine running the provider to bind into the container
+        :param str network_mode: The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+        :param str user: The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+        """
+        pulumi.set(__self__, "name", name)
+        if mounts is not None:
+            pulumi.set(__self__, "mounts", mounts)
+        if network_mode is not None:
+            pulumi.set(__self__, "network_mode", network_mode)
+        if user is not None:
+            pulumi.set(__self__, "user", user)
+
+    @property
+    @pulumi.getter
+    def name(self) -> str:
+        """
+        The image reference, such as `alpine:3.14` or `alpine@sha256:...`. The digest it resolves to is looked up in the registry of the image on every diff, and a new digest updates the resource.
+        """
+        return pulumi.get(self, "name")
+
+    @property
+    @pulumi.getter
+    def mounts(self) -> Optional[Sequence['outputs.Mount']]:
+        """
+        Paths of the machine running the provider to bind into the container
+        """
+        return pulumi.get(self, "mounts")
+
+    @property
+    @pulumi.getter(name="networkMode")
+    def network_mode(self) -> Optional[str]:
+        """
+        The network of the container, such as `none`, `host` or the name of a network. Defaults to the default network of Docker.
+        """
+        return pulumi.get(self, "network_mode")
+
+    @property
+    @pulumi.getter
+    def user(self) -> Optional[str]:
+        """
+        The user the command runs as, such as `nobody` or `1000:1000`. Defaults to the user of the image.
+        """
+        return pulumi.get(self, "user")
+
+
+@pulumi.output_type
+class Mount(dict):
+    """
+    A path of the machine running the provider bound into a container.
+    """
+    @staticmethod
+    def __key_warning(key: str):
+        suggest = None
+        if key == "readOnly":
+            suggest = "read_only"
+
+        if suggest:
+            pulumi.log.warn(f"Key '{key}' not found in Mount. Access the value via the '{suggest}' property getter instead.")
+
+    def __getitem__(self, key: str) -> Any:
+        Mount.__key_warning(key)
+        return super().__getitem__(key)
+
+    def get(self, key: str, default = None) -> Any:
+        Mount.__key_warning(key)
+        return super().get(key, default)
+
+    def __init__(__self__, *,
+                 source: str,
+                 target: str,
+                 read_only: Optional[bool] = None):
+        """
+        A path of the machine running the provider bound into a container.
+        :param str source: The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+        :param str target: The absolute path in the container
+        :param bool read_only: Whether the container may only read the mount
+        """
+        pulumi.set(__self__, "source", source)
+        pulumi.set(__self__, "target", target)
+        if read_only is not None:
+            pulumi.set(__self__, "read_only", read_only)
+
+    @property
+    @pulumi.getter
+    def source(self) -> str:
+        """
+        The path on the machine running the provider. Relative paths are resolved against the Pulumi project root.
+        """
+        return pulumi.get(self, "source")
+
+    @property
+    @pulumi.getter
+    def target(self) -> str:
+        """
+        The absolute path in the container
+        """
+        return pulumi.get(self, "target")
+
+    @property
+    @pulumi.getter(name="readOnly")
+    def read_only(self) -> Optional[bool]:
+        """
+        Whether the container may only read the mount
+        """
+        return pulumi.get(self, "read_only")
+
+
+@pulumi.output_type
+class Retry(dict):
+    """
+    How a failing command is retried. Each retry waits `backoffFactor` times longer than the previous one, up to `maxDelay`.
+    """
+    @staticmethod
+    def __key_warning(key: str):
+        suggest = None
+        if key == "backoffFactor":
+            suggest = "backoff_factor"
+        elif key == "initialDelay":
+            suggest = "initial_delay"
+        elif key == "maxDelay":
+            suggest = "max_delay"
+        elif key == "retryOnExitCodes":
+            suggest = "retry_on_exit_codes"
+        elif key == "retryOnStderrMatch":
+            suggest = "retry_on_stderr_match"
+
+        if suggest:
+            pulumi.log.warn(f"Key '{key}' not found in Retry. Access the value via the '{suggest}' property getter instead.")
+
+    def __getitem__(self, key: str) -> Any:
+        Retry.__key_warning(key)
+        return super().__getitem__(key)
+
+    def get(self, key: str, default = None) -> Any:
+        Retry.__key_warning(key)
+        return super().get(key, default)
+
+    def __init__(__self__, *,
+                 attempts: Optional[int] = None,
+                 backoff_factor: Optional[float] = None,
+                 initial_delay: Optional[str] = None,
+                 max_delay: Optional[str] = None,
+                 retry_on_exit_codes: Optional[Sequence[int]] = None,
+                 retry_on_stderr_match: Optional[str] = None):
+        """
+        How a failing command is retried. Each retry waits `backoffFactor` times longer than the previous one, up to `maxDelay`.
+        :param int attempts: The maximum number of times the command runs, including the first. Defaults to 3.
+        :param float backoff_factor: The factor each delay is multiplied by. Defaults to 2.
+        :param str initial_delay: How long to wait before the first retry, as a duration. Defaults to `1s`.
+        :param str max_delay: The longest wait between attempts, as a duration. Defaults to `30s`.
+        :param Sequence[int] retry_on_exit_codes: Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+        :param str retry_on_stderr_match: Only retry when stderr matches this regular expression.
+        """
+        if attempts is not None:
+            pulumi.set(__self__, "attempts", attempts)
+        if backoff_factor is not None:
+            pulumi.set(__self__, "backoff_factor", backoff_factor)
+        if initial_delay is not None:
+            pulumi.set(__self__, "initial_delay", initial_delay)
+        if max_delay is not None:
+            pulumi.set(__self__, "max_delay", max_delay)
+        if retry_on_exit_codes is not None:
+            pulumi.set(__self__, "retry_on_exit_codes", retry_on_exit_codes)
+        if retry_on_stderr_match is not None:
+            pulumi.set(__self__, "retry_on_stderr_match", retry_on_stderr_match)
+
+    @property
+    @pulumi.getter
+    def attempts(self) -> Optional[int]:
+        """
+        The maximum number of times the command runs, including the first. Defaults to 3.
+        """
+        return pulumi.get(self, "attempts")
+
+    @property
+    @pulumi.getter(name="backoffFactor")
+    def backoff_factor(self) -> Optional[float]:
+        """
+        The factor each delay is multiplied by. Defaults to 2.
+        """
+        return pulumi.get(self, "backoff_factor")
+
+    @property
+    @pulumi.getter(name="initialDelay")
+    def initial_delay(self) -> Optional[str]:
+        """
+        How long to wait before the first retry, as a duration. Defaults to `1s`.
+        """
+        return pulumi.get(self, "initial_delay")
+
+    @property
+    @pulumi.getter(name="maxDelay")
+    def max_delay(self) -> Optional[str]:
+        """
+        The longest wait between attempts, as a duration. Defaults to `30s`.
+        """
+        return pulumi.get(self, "max_delay")
+
+    @property
+    @pulumi.getter(name="retryOnExitCodes")
+    def retry_on_exit_codes(self) -> Optional[Sequence[int]]:
+        """
+        Only retry when the command exits with one of these codes. Defaults to any failing exit code.
+        """
+        return pulumi.get(self, "retry_on_exit_codes")
+
+    @property
+    @pulumi.getter(name="retryOnStderrMatch")
+    def retry_on_stderr_match(self) -> Optional[str]:
+        """
+        Only retry when stderr matches this regular expression.
+        """
+        return pulumi.get(self, "retry_on_stderr_match")
+
+
+@pulumi.output_type
+class Sandbox(dict):
+    """
+    Restrictions on a command that runs on the machine running the provider. Each process of the command is subject to the limits that are set.
+    """
+    @staticmethod
+    def __key_warning(key: str):
+        suggest = None
+        if key == "cpuSeconds":
+            suggest = "cpu_seconds"
+        elif key == "maxProcesses":
+            suggest = "max_processes"
+        elif key == "memoryMB":
+            suggest = "memory_mb"
+        elif key == "openFiles":
+            suggest = "open_files"
+
+        if suggest:
+            pulumi.log.warn(f"Key '{key}' not found in Sandbox. Access the value via the '{suggest}' property getter instead.")
+
+    def __getitem__(self, key: str) -> Any:
+        Sandbox.__key_warning(key)
+        return super().__getitem__(key)
+
+    def get(self, key: str, default = None) -> Any:
+        Sandbox.__key_warning(key)
+        return super().get(key, default)
+
+    def __init__(__self__, *,
+                 cpu_seconds: Optional[int] = None,
+                 max_processes: Optional[int] = None,
+                 memory_mb: Optional[int] = None,
+                 network: Optional[bool] = None,
+                 open_files: Optional[int] = None):
+        """
+        Restrictions on a command that runs on the machine running the provider. Each process of the command is subject to the limits that are set.
+        :param int cpu_seconds: The CPU time each process may use, in seconds, after which it is killed
+        :param int max_processes: The number of processes the user of the command may run, including those outside of the sandbox
+        :param int memory_mb: The size of the address space of each process, in mebibytes
+        :param bool network: Keep the network of the provider instead of running the command in an empty network namespace
+        :param int open_files: The number of files each process may have open
+        """
+        if cpu_seconds is not None:
+            pulumi.set(__self__, "cpu_seconds", cpu_seconds)
+        if max_processes is not None:
+            pulumi.set(__self__, "max_processes", max_processes)
+        if memory_mb is not None:
+            pulumi.set(__self__, "memory_mb", memory_mb)
+        if network is not None:
+            pulumi.set(__self__, "network", network)
+        if open_files is not None:
+            pulumi.set(__self__, "open_files", open_files)
+
+    @property
+    @pulumi.getter(name="cpuSeconds")
+    def cpu_seconds(self) -> Optional[int]:
+        """
+        The CPU time each process may use, in seconds, after which it is killed
+        """
+        return pulumi.get(self, "cpu_seconds")
+
+    @property
+    @pulumi.getter(name="maxProcesses")
+    def max_processes(self) -> Optional[int]:
+        """
+        The number of processes the user of the command may run, including those outside of the sandbox
+        """
+        return pulumi.get(self, "max_processes")
+
+    @property
+    @pulumi.getter(name="memoryMB")
+    def memory_mb(self) -> Optional[int]:
+        """
+        The size of the address space of each process, in mebibytes
+        """
+        return pulumi.get(self, "memory_mb")
+
+    @property
+    @pulumi.getter
+    def network(self) -> Optional[bool]:
+        """
+        Keep the network of the provider instead of running the command in an empty network namespace
+        """
+        return pulumi.get(self, "network")
+
+    @property
+    @pulumi.getter(name="openFiles")
+    def open_files(self) -> Optional[int]:
+        """
+        The number of files each process may have open
+        """
+        return pulumi.get(self, "open_files")
+
+
+@pulumi.output_type
+class WaitFor(dict):
+    """
+    A probe that runs after create and update until the resource is ready. The resource is ready when the probe exits with `exitCode`, prints stdout matching `stdoutMatch`, or prints JSON whose value at `jsonPath` equals `equals`. Without a criterion the probe must exit with 0.
+    """
+    @staticmethod
+    def __key_warning(key: str):
+        suggest = None
+        if key == "exitCode":
+            suggest = "exit_code"
+        elif key == "jsonPath":
+            suggest = "json_path"
+        elif key == "stdoutMatch":
+            suggest = "stdout_match"
+
+        if suggest:
+            pulumi.log.warn(f"Key '{key}' not found in WaitFor. Access the value via the '{suggest}' property getter instead.")
+
+    def __getitem__(self, key: str) -> Any:
+        WaitFor.__key_warning(key)
+        return super().__getitem__(key)
+
+    def get(self, key: str, default = None) -> Any:
+        WaitFor.__key_warning(key)
+        return super().get(key, default)
+
+    def __init__(__self__, *,
+                 probe: 'outputs.Cmd',
+                 equals: Optional[str] = None,
+                 exit_code: Optional[int] = None,
+                 interval: Optional[str] = None,
+                 json_path: Optional[str] = None,
+                 stdout_match: Optional[str] = None,
+                 timeout: Optional[str] = None):
+        """
+        A probe that runs after create and update until the resource is ready. The resource is ready when the probe exits with `exitCode`, prints stdout matching `stdoutMatch`, or prints JSON whose value at `jsonPath` equals `equals`. Without a criterion the probe must exit with 0.
+        :param 'Cmd' probe: The command that checks whether the resource is ready
+        :param str equals: The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`.
+        :param int exit_code: The exit code of the probe when the resource is ready
+        :param str interval: The time between probes, as a duration. Defaults to `5s`.
+        :param str json_path: The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`.
+        :param str stdout_match: A regular expression that the stdout of the probe matches when the resource is ready
+        :param str timeout: How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`.
+        """
+        pulumi.set(__self__, "probe", probe)
+        if equals is not None:
+            pulumi.set(__self__, "equals", equals)
+        if exit_code is not None:
+            pulumi.set(__self__, "exit_code", exit_code)
+        if interval is not None:
+            pulumi.set(__self__, "interval", interval)
+        if json_path is not None:
+            pulumi.set(__self__, "json_path", json_path)
+        if stdout_match is not None:
+            pulumi.set(__self__, "stdout_match", stdout_match)
+        if timeout is not None:
+            pulumi.set(__self__, "timeout", timeout)
+
+    @property
+    @pulumi.getter
+    def probe(self) -> 'outputs.Cmd':
+        """
+        The command that checks whether the resource is ready
+        """
+        return pulumi.get(self, "probe")
+
+    @property
+    @pulumi.getter
+    def equals(self) -> Optional[str]:
+        """
+        The value at `jsonPath` when the resource is ready. Values other than strings are compared in their JSON form, such as `true`.
+        """
+        return pulumi.get(self, "equals")
+
+    @property
+    @pulumi.getter(name="exitCode")
+    def exit_code(self) -> Optional[int]:
+        """
+        The exit code of the probe when the resource is ready
+        """
+        return pulumi.get(self, "exit_code")
+
+    @property
+    @pulumi.getter
+    def interval(self) -> Optional[str]:
+        """
+        The time between probes, as a duration. Defaults to `5s`.
+        """
+        return pulumi.get(self, "interval")
+
+    @property
+    @pulumi.getter(name="jsonPath")
+    def json_path(self) -> Optional[str]:
+        """
+        The path of a value in the JSON stdout of the probe, such as `status.phase`. Requires `equals`.
+        """
+        return pulumi.get(self, "json_path")
+
+    @property
+    @pulumi.getter(name="stdoutMatch")
+    def stdout_match(self) -> Optional[str]:
+        """
+        A regular expression that the stdout of the probe matches when the resource is ready
+        """
+        return pulumi.get(self, "stdout_match")
+
+    @property
+    @pulumi.getter
+    def timeout(self) -> Optional[str]:
+        """
+        How long to wait for the resource to be ready before the operation fails, as a duration. Defaults to `5m`.
+        """
+        return pulumi.get(self, "timeout")
+
 
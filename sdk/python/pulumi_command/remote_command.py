@@ -0,0 +1,573 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumigen. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+from . import _utilities
+from . import outputs
+from ._inputs import *
+
+__all__ = ['RemoteCommandArgs', 'RemoteCommand']
+
+@pulumi.input_type
+class RemoteCommandArgs:
+    def __init__(__self__, *,
+                 connection: pulumi.Input['ConnectionArgs'],
+                 create: pulumi.Input['CmdArgs'],
+                 delete: Optional[pulumi.Input['CmdArgs']] = None,
+                 delete_before_replace: Optional[pulumi.Input[bool]] = None,
+                 diff: Optional[pulumi.Input['CmdArgs']] = None,
+                 expected: Optional[pulumi.Input[str]] = None,
+                 id_from: Optional[pulumi.Input['IdFromArgs']] = None,
+                 read: Optional[pulumi.Input['CmdArgs']] = None,
+                 replace_on: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None,
+                 secret_outputs: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None,
+                 update: Optional[pulumi.Input['CmdArgs']] = None,
+                 wait_for: Optional[pulumi.Input['WaitForArgs']] = None):
+        """
+        The set of arguments for constructing a RemoteCommand resource.
+        :param pulumi.Input['ConnectionArgs'] connection: The host the commands run on
+        :param pulumi.Input['CmdArgs'] create: Define a command to create a resource.
+        :param pulumi.Input[bool] delete_before_replace: Whether the resource is deleted before its replacement is created. Defaults to true. Set to false to create the replacement first.
+        :param pulumi.Input['CmdArgs'] diff: Specify a command to run to diff the resource.
+               
+               Exit 0 to run update.
+               Exit with a non-zero value or omit to disable update.
+               Hint: an easy method to always run update is to set diff to `['true']`
+        :param pulumi.Input[str] expected: The output the `read` command prints when the resource has not drifted. Defaults to the stdout of the last create or update.
+        :param pulumi.Input['IdFromArgs'] id_from: How the ID of the resource is derived. Defaults to the static ID `id`. Changing it replaces the resource.
+        :param pulumi.Input['CmdArgs'] read: Define a command to create read the resource.
+        :param pulumi.Input[Sequence[pulumi.Input[str]]] replace_on: The inputs whose changes replace the resource instead of updating it: any of `compare`, `create`, `read`, `update`, `delete` and `diff`.
+        :param pulumi.Input[Sequence[pulumi.Input[str]]] secret_outputs: The outputs to mark as secret: any of `stdout`, `stderr` and `parsed`. Secret inputs are always stored as secrets and redacted from logged output.
+        :param pulumi.Input['CmdArgs'] update: If unspecified, create definition will be used. Define to provide an alternate update command.
+        :param pulumi.Input['WaitForArgs'] wait_for: Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time.
+        """
+        pulumi.set(__self__, "connection", connection)
+        pulumi.set(__self__, "create", create)
+        if delete is not None:
+            pulumi.set(__self__, "delete", delete)
+        if delete_before_replace is not None:
+            pulumi.set(__self__, "delete_before_replace", delete_before_replace)
+        if diff is not None:
+            pulumi.set(__self__, "diff", diff)
+        if expected is not None:
+            pulumi.set(__self__, "expected", expected)
+        if id_from is not None:
+            pulumi.set(__self__, "id_from", id_from)
+        if read is not None:
+            pulumi.set(__self__, "read", read)
+        if replace_on is not None:
+            pulumi.set(__self__, "replace_on", replace_on)
+        if secret_outputs is not None:
+            pulumi.set(__self__, "secret_outputs", secret_outputs)
+        if update is not None:
+            pulumi.set(__self__, "update", update)
+        if wait_for is not None:
+            pulumi.set(__self__, "wait_for", wait_for)
+
+    @property
+    @pulumi.getter
+    def connection(self) -> pulumi.Input['ConnectionArgs']:
+        """
+        The host the commands run on
+        """
+        return pulumi.get(self, "connection")
+
+    @connection.setter
+    def connection(self, value: pulumi.Input['ConnectionArgs']):
+        pulumi.set(self, "connection", value)
+
+    @property
+    @pulumi.getter
+    def create(self) -> pulumi.Input['CmdArgs']:
+        """
+        Define a command to create a resource.
+        """
+        return pulumi.get(self, "create")
+
+    @create.setter
+    def create(self, value: pulumi.Input['CmdArgs']):
+        pulumi.set(self, "create", value)
+
+    @property
+    @pulumi.getter
+    def delete(self) -> Optional[pulumi.Input['CmdArgs']]:
+        return pulumi.get(self, "delete")
+
+    @delete.setter
+    def delete(self, value: Optional[pulumi.Input['CmdArgs']]):
+        pulumi.set(self, "delete", value)
+
+    @property
+    @pulumi.getter(name="deleteBeforeReplace")
+    def delete_before_replace(self) -> Optional[pulumi.Input[bool]]:
+        """
+        Whether the resource is deleted before its replacement is created. Defaults to true. Set to false to create the replacement first.
+        """
+        return pulumi.get(self, "delete_before_replace")
+
+    @delete_before_replace.setter
+    def delete_before_replace(self, value: Optional[pulumi.Input[bool]]):
+        pulumi.set(self, "delete_before_replace", value)
+
+    @property
+    @pulumi.getter
+    def diff(self) -> Optional[pulumi.Input['CmdArgs']]:
+        """
+        Specify a command to run to diff the resource.
+
+        Exit 0 to run update.
+        Exit with a non-zero value or omit to disable update.
+        Hint: an easy method to always run update is to set diff to `['true']`
+        """
+        return pulumi.get(self, "diff")
+
+    @diff.setter
+    def diff(self, value: Optional[pulumi.Input['CmdArgs']]):
+        pulumi.set(self, "diff", value)
+
+    @property
+    @pulumi.getter
+    def expected(self) -> Optional[pulumi.Input[str]]:
+        """
+        The output the `read` command prints when the resource has not drifted. Defaults to the stdout of the last create or update.
+        """
+        return pulumi.get(self, "expected")
+
+    @expected.setter
+    def expected(self, value: Optional[pulumi.Input[str]]):
+        pulumi.set(self, "expected", value)
+
+    @property
+    @pulumi.getter(name="idFrom")
+    def id_from(self) -> Optional[pulumi.Input['IdFromArgs']]:
+        """
+        How the ID of the resource is derived. Defaults to the static ID `id`. Changing it replaces the resource.
+        """
+        return pulumi.get(self, "id_from")
+
+    @id_from.setter
+    def id_from(self, value: Optional[pulumi.Input['IdFromArgs']]):
+        pulumi.set(self, "id_from", value)
+
+    @property
+    @pulumi.getter
+    def read(self) -> Optional[pulumi.Input['CmdArgs']]:
+        """
+        Define a command to create read the resource.
+        """
+        return pulumi.get(self, "read")
+
+    @read.setter
+    def read(self, value: Optional[pulumi.Input['CmdArgs']]):
+        pulumi.set(self, "read", value)
+
+    @property
+    @pulumi.getter(name="replaceOn")
+    def replace_on(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]:
+        """
+        The inputs whose changes replace the resource instead of updating it: any of `compare`, `create`, `read`, `update`, `delete` and `diff`.
+        """
+        return pulumi.get(self, "replace_on")
+
+    @replace_on.setter
+    def replace_on(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]):
+        pulumi.set(self, "replace_on", value)
+
+    @property
+    @pulumi.getter(name="secretOutputs")
+    def secret_outputs(self) -> Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]:
+        """
+        The outputs to mark as secret: any of `stdout`, `stderr` and `parsed`. Secret inputs are always stored as secrets and redacted from logged output.
+        """
+        return pulumi.get(self, "secret_outputs")
+
+    @secret_outputs.setter
+    def secret_outputs(self, value: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]]):
+        pulumi.set(self, "secret_outputs", value)
+
+    @property
+    @pulumi.getter
+    def update(self) -> Optional[pulumi.Input['CmdArgs']]:
+        """
+        If unspecified, create definition will be used. Define to provide an alternate update command.
+        """
+        return pulumi.get(self, "update")
+
+    @update.setter
+    def update(self, value: Optional[pulumi.Input['CmdArgs']]):
+        pulumi.set(self, "update", value)
+
+    @property
+    @pulumi.getter(name="waitFor")
+    def wait_for(self) -> Optional[pulumi.Input['WaitForArgs']]:
+        """
+        Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time.
+        """
+        return pulumi.get(self, "wait_for")
+
+    @wait_for.setter
+    def wait_for(self, value: Optional[pulumi.Input['WaitForArgs']]):
+        pulumi.set(self, "wait_for", value)
+
+
+class RemoteCommand(pulumi.CustomResource):
+    @overload
+    def __init__(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 connection: Optional[pulumi.Input[pulumi.InputType['ConnectionArgs']]] = None,
+                 create: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 delete: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 delete_before_replace: Optional[pulumi.Input[bool]] = None,
+                 diff: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 expected: Optional[pulumi.Input[str]] = None,
+                 id_from: Optional[pulumi.Input[pulumi.InputType['IdFromArgs']]] = None,
+                 read: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 replace_on: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None,
+                 secret_outputs: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None,
+                 update: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 wait_for: Optional[pulumi.Input[pulumi.InputType['WaitForArgs']]] = None,
+                 __props__=None):
+        """
+        Execute commands on a remote host over SSH and save them as a resource.
+
+        A `RemoteCommand` has the same lifecycle as a `Command`, but each command runs on the host of `connection` with the shell of the remote user. The environment of the provider is not passed to remote commands, only `environment` and the `PULUMI_COMMAND_*` variables. The update command also runs when the host, port or user of the connection change.
+
+        :param str resource_name: The name of the resource.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        :param pulumi.Input[pulumi.InputType['ConnectionArgs']] connection: The host the commands run on
+        :param pulumi.Input[pulumi.InputType['CmdArgs']] create: Define a command to create a resource.
+        :param pulumi.Input[bool] delete_before_replace: Whether the resource is deleted before its replacement is created. Defaults to true. Set to false to create the replacement first.
+        :param pulumi.Input[pulumi.InputType['CmdArgs']] diff: Specify a command to run to diff the resource.
+               
+               Exit 0 to run update.
+               Exit with a non-zero value or omit to disable update.
+               Hint: an easy method to always run update is to set diff to `['true']`
+        :param pulumi.Input[str] expected: The output the `read` command prints when the resource has not drifted. Defaults to the stdout of the last create or update.
+        :param pulumi.Input[pulumi.InputType['IdFromArgs']] id_from: How the ID of the resource is derived. Defaults to the static ID `id`. Changing it replaces the resource.
+        :param pulumi.Input[pulumi.InputType['CmdArgs']] read: Define a command to create read the resource.
+        :param pulumi.Input[Sequence[pulumi.Input[str]]] replace_on: The inputs whose changes replace the resource instead of updating it: any of `compare`, `create`, `read`, `update`, `delete` and `diff`.
+        :param pulumi.Input[Sequence[pulumi.Input[str]]] secret_outputs: The outputs to mark as secret: any of `stdout`, `stderr` and `parsed`. Secret inputs are always stored as secrets and redacted from logged output.
+        :param pulumi.Input[pulumi.InputType['CmdArgs']] update: If unspecified, create definition will be used. Define to provide an alternate update command.
+        :param pulumi.Input[pulumi.InputType['WaitForArgs']] wait_for: Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time.
+        """
+        ...
+    @overload
+    def __init__(__self__,
+                 resource_name: str,
+                 args: RemoteCommandArgs,
+                 opts: Optional[pulumi.ResourceOptions] = None):
+        """
+        Execute commands on a remote host over SSH and save them as a resource.
+
+        A `RemoteCommand` has the same lifecycle as a `Command`, but each command runs on the host of `connection` with the shell of the remote user. The environment of the provider is not passed to remote commands, only `environment` and the `PULUMI_COMMAND_*` variables. The update command also runs when the host, port or user of the connection change.
+
+        :param str resource_name: The name of the resource.
+        :param RemoteCommandArgs args: The arguments to use to populate this resource's properties.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        ...
+    def __init__(__self__, resource_name: str, *args, **kwargs):
+        resource_args, opts = _utilities.get_resource_args_opts(RemoteCommandArgs, pulumi.ResourceOptions, *args, **kwargs)
+        if resource_args is not None:
+            __self__._internal_init(resource_name, opts, **resource_args.__dict__)
+        else:
+            __self__._internal_init(resource_name, *args, **kwargs)
+
+    def _internal_init(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 connection: Optional[pulumi.Input[pulumi.InputType['ConnectionArgs']]] = None,
+                 create: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 delete: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 delete_before_replace: Optional[pulumi.Input[bool]] = None,
+                 diff: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 expected: Optional[pulumi.Input[str]] = None,
+                 id_from: Optional[pulumi.Input[pulumi.InputType['IdFromArgs']]] = None,
+                 read: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 replace_on: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None,
+                 secret_outputs: Optional[pulumi.Input[Sequence[pulumi.Input[str]]]] = None,
+                 update: Optional[pulumi.Input[pulumi.InputType['CmdArgs']]] = None,
+                 wait_for: Optional[pulumi.Input[pulumi.InputType['WaitForArgs']]] = None,
+                 __props__=None):
+        if opts is None:
+            opts = pulumi.ResourceOptions()
+        if not isinstance(opts, pulumi.ResourceOptions):
+            raise TypeError('Expected resource options to be a ResourceOptions instance')
+        if opts.version is None:
+            opts.version = _utilities.get_version()
+        if opts.id is None:
+            if __props__ is not None:
+                raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
+            __props__ = RemoteCommandArgs.__new__(RemoteCommandArgs)
+
+            if connection is None and not opts.urn:
+                raise TypeError("Missing required property 'connection'")
+            __props__.__dict__["connection"] = connection
+            if create is None and not opts.urn:
+                raise TypeError("Missing required property 'create'")
+            __props__.__dict__["create"] = create
+            __props__.__dict__["delete"] = delete
+            __props__.__dict__["delete_before_replace"] = delete_before_replace
+            __props__.__dict__["diff"] = diff
+            __props__.__dict__["expected"] = expected
+            __props__.__dict__["id_from"] = id_from
+            __props__.__dict__["read"] = read
+            __props__.__dict__["replace_on"] = replace_on
+            __props__.__dict__["secret_outputs"] = secret_outputs
+            __props__.__dict__["update"] = update
+            __props__.__dict__["wait_for"] = wait_for
+            __props__.__dict__["attempts"] = None
+            __props__.__dict__["compare"] = None
+            __props__.__dict__["drifted"] = None
+            __props__.__dict__["duration_ms"] = None
+            __props__.__dict__["exit_code"] = None
+            __props__.__dict__["finished_at"] = None
+            __props__.__dict__["parsed"] = None
+            __props__.__dict__["read_stdout"] = None
+            __props__.__dict__["script_hash"] = None
+            __props__.__dict__["started_at"] = None
+            __props__.__dict__["stderr"] = None
+            __props__.__dict__["stdout"] = None
+        super(RemoteCommand, __self__).__init__(
+            'command:v1:RemoteCommand',
+            resource_name,
+            __props__,
+            opts)
+
+    @staticmethod
+    def get(resource_name: str,
+            id: pulumi.Input[str],
+            opts: Optional[pulumi.ResourceOptions] = None) -> 'RemoteCommand':
+        """
+        Get an existing RemoteCommand resource's state with the given name, id, and optional extra
+        properties used to qualify the lookup.
+
+        :param str resource_name: The unique name of the resulting resource.
+        :param pulumi.Input[str] id: The unique provider ID of the resource to lookup.
+        :param pulumi.ResourceOptions opts: Options for the resource.
+        """
+        opts = pulumi.ResourceOptions.merge(opts, pulumi.ResourceOptions(id=id))
+
+        __props__ = RemoteCommandArgs.__new__(RemoteCommandArgs)
+
+        __props__.__dict__["attempts"] = None
+        __props__.__dict__["compare"] = None
+        __props__.__dict__["connection"] = None
+        __props__.__dict__["create"] = None
+        __props__.__dict__["delete"] = None
+        __props__.__dict__["delete_before_replace"] = None
+        __props__.__dict__["diff"] = None
+        __props__.__dict__["drifted"] = None
+        __props__.__dict__["duration_ms"] = None
+        __props__.__dict__["exit_code"] = None
+        __props__.__dict__["expected"] = None
+        __props__.__dict__["finished_at"] = None
+        __props__.__dict__["id_from"] = None
+        __props__.__dict__["parsed"] = None
+        __props__.__dict__["read"] = None
+        __props__.__dict__["read_stdout"] = None
+        __props__.__dict__["replace_on"] = None
+        __props__.__dict__["script_hash"] = None
+        __props__.__dict__["secret_outputs"] = None
+        __props__.__dict__["started_at"] = None
+        __props__.__dict__["stderr"] = None
+        __props__.__dict__["stdout"] = None
+        __props__.__dict__["update"] = None
+        __props__.__dict__["wait_for"] = None
+        return RemoteCommand(resource_name, opts=opts, __props__=__props__)
+
+    @property
+    @pulumi.getter
+    def attempts(self) -> pulumi.Output[Optional[int]]:
+        """
+        The number of times the last command ran, including retries
+        """
+        return pulumi.get(self, "attempts")
+
+    @property
+    @pulumi.getter
+    def compare(self) -> pulumi.Output[Optional[str]]:
+        return pulumi.get(self, "compare")
+
+    @property
+    @pulumi.getter
+    def connection(self) -> pulumi.Output[Optional['outputs.Connection']]:
+        """
+        The host the commands run on
+        """
+        return pulumi.get(self, "connection")
+
+    @property
+    @pulumi.getter
+    def create(self) -> pulumi.Output[Optional['outputs.Cmd']]:
+        """
+        Define a command to create a resource.
+        """
+        return pulumi.get(self, "create")
+
+    @property
+    @pulumi.getter
+    def delete(self) -> pulumi.Output[Optional['outputs.Cmd']]:
+        return pulumi.get(self, "delete")
+
+    @property
+    @pulumi.getter(name="deleteBeforeReplace")
+    def delete_before_replace(self) -> pulumi.Output[Optional[bool]]:
+        """
+        Whether the resource is deleted before its replacement is created. Defaults to true. Set to false to create the replacement first.
+        """
+        return pulumi.get(self, "delete_before_replace")
+
+    @property
+    @pulumi.getter
+    def diff(self) -> pulumi.Output[Optional['outputs.Cmd']]:
+        """
+        Specify a command to run to diff the resource.
+
+        Exit 0 to run update.
+        Exit with a non-zero value or omit to disable update.
+        Hint: an easy method to always run update is to set diff to `['true']`
+        """
+        return pulumi.get(self, "diff")
+
+    @property
+    @pulumi.getter
+    def drifted(self) -> pulumi.Output[Optional[bool]]:
+        """
+        Whether the output of the `read` command differed from the expected output during the last refresh
+        """
+        return pulumi.get(self, "drifted")
+
+    @property
+    @pulumi.getter(name="durationMs")
+    def duration_ms(self) -> pulumi.Output[Optional[int]]:
+        """
+        How long the command ran, in milliseconds
+        """
+        return pulumi.get(self, "duration_ms")
+
+    @property
+    @pulumi.getter(name="exitCode")
+    def exit_code(self) -> pulumi.Output[Optional[int]]:
+        """
+        exit code of the command
+        """
+        return pulumi.get(self, "exit_code")
+
+    @property
+    @pulumi.getter
+    def expected(self) -> pulumi.Output[Optional[str]]:
+        """
+        The output the `read` command prints when the resource has not drifted. Defaults to the stdout of the last create or update.
+        """
+        return pulumi.get(self, "expected")
+
+    @property
+    @pulumi.getter(name="finishedAt")
+    def finished_at(self) -> pulumi.Output[Optional[str]]:
+        """
+        The time the command exited, in RFC 3339 format
+        """
+        return pulumi.get(self, "finished_at")
+
+    @property
+    @pulumi.getter(name="idFrom")
+    def id_from(self) -> pulumi.Output[Optional['outputs.IdFrom']]:
+        """
+        How the ID of the resource is derived. Defaults to the static ID `id`. Changing it replaces the resource.
+        """
+        return pulumi.get(self, "id_from")
+
+    @property
+    @pulumi.getter
+    def parsed(self) -> pulumi.Output[Optional[Any]]:
+        """
+        stdout of the command decoded according to its `outputFormat`
+        """
+        return pulumi.get(self, "parsed")
+
+    @property
+    @pulumi.getter
+    def read(self) -> pulumi.Output[Optional['outputs.Cmd']]:
+        """
+        Define a command to create read the resource.
+        """
+        return pulumi.get(self, "read")
+
+    @property
+    @pulumi.getter(name="readStdout")
+    def read_stdout(self) -> pulumi.Output[Optional[str]]:
+        """
+        stdout of the `read` command during the last refresh
+        """
+        return pulumi.get(self, "read_stdout")
+
+    @property
+    @pulumi.getter(name="replaceOn")
+    def replace_on(self) -> pulumi.Output[Optional[Sequence[str]]]:
+        """
+        The inputs whose changes replace the resource instead of updating it: any of `compare`, `create`, `read`, `update`, `delete` and `diff`.
+        """
+        return pulumi.get(self, "replace_on")
+
+    @property
+    @pulumi.getter(name="scriptHash")
+    def script_hash(self) -> pulumi.Output[Optional[str]]:
+        """
+        The SHA-256 hash of the script of the last create or update, when it is a `script`
+        """
+        return pulumi.get(self, "script_hash")
+
+    @property
+    @pulumi.getter(name="secretOutputs")
+    def secret_outputs(self) -> pulumi.Output[Optional[Sequence[str]]]:
+        """
+        The outputs to mark as secret: any of `stdout`, `stderr` and `parsed`. Secret inputs are always stored as secrets and redacted from logged output.
+        """
+        return pulumi.get(self, "secret_outputs")
+
+    @property
+    @pulumi.getter(name="startedAt")
+    def started_at(self) -> pulumi.Output[Optional[str]]:
+        """
+        The time the command was started, in RFC 3339 format
+        """
+        return pulumi.get(self, "started_at")
+
+    @property
+    @pulumi.getter
+    def stderr(self) -> pulumi.Output[Optional[str]]:
+        """
+        stderr of the command
+        """
+        return pulumi.get(self, "stderr")
+
+    @property
+    @pulumi.getter
+    def stdout(self) -> pulumi.Output[Optional[str]]:
+        """
+        stdout of the command
+        """
+        return pulumi.get(self, "stdout")
+
+    @property
+    @pulumi.getter
+    def update(self) -> pulumi.Output[Optional['outputs.Cmd']]:
+        """
+        If unspecified, create definition will be used. Define to provide an alternate update command.
+        """
+        return pulumi.get(self, "update")
+
+    @property
+    @pulumi.getter(name="waitFor")
+    def wait_for(self) -> pulumi.Output[Optional['outputs.WaitFor']]:
+        """
+        Wait after create and update until a probe reports that the resource is ready. The operation fails if it is not ready in time.
+        """
+        return pulumi.get(self, "wait_for")
+
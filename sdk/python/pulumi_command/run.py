@@ -0,0 +1,180 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumigen. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import warnings
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+from . import _utilities
+from ._inputs import *
+
+__all__ = [
+    'RunResult',
+    'AwaitableRunResult',
+    'run',
+]
+
+@pulumi.output_type
+class RunResult:
+    """
+    The result of running a command
+    """
+    def __init__(__self__, attempts=None, exit_code=None, image_digest=None, parsed=None, stderr=None, stdout=None):
+        if attempts and not isinstance(attempts, int):
+            raise TypeError("Expected argument 'attempts' to be a int")
+        pulumi.set(__self__, "attempts", attempts)
+        if exit_code and not isinstance(exit_code, int):
+            raise TypeError("Expected argument 'exit_code' to be a int")
+        pulumi.set(__self__, "exit_code", exit_code)
+        if image_digest and not isinstance(image_digest, str):
+            raise TypeError("Expected argument 'image_digest' to be a str")
+        pulumi.set(__self__, "image_digest", image_digest)
+        if parsed and not isinstance(parsed, dict):
+            raise TypeError("Expected argument 'parsed' to be a dict")
+        pulumi.set(__self__, "parsed", parsed)
+        if stderr and not isinstance(stderr, str):
+            raise TypeError("Expected argument 'stderr' to be a str")
+        pulumi.set(__self__, "stderr", stderr)
+        if stdout and not isinstance(stdout, str):
+            raise TypeError("Expected argument 'stdout' to be a str")
+        pulumi.set(__self__, "stdout", stdout)
+
+    @property
+    @pulumi.getter
+    def attempts(self) -> Optional[int]:
+        """
+        The number of times the command ran, including retries
+        """
+        return pulumi.get(self, "attempts")
+
+    @property
+    @pulumi.getter(name="exitCode")
+    def exit_code(self) -> int:
+        """
+        exit code of the command
+        """
+        return pulumi.get(self, "exit_code")
+
+    @property
+    @pulumi.getter(name="imageDigest")
+    def image_digest(self) -> Optional[str]:
+        """
+        The digest of the image the command ran in, when it has an `image`
+        """
+        return pulumi.get(self, "image_digest")
+
+    @property
+    @pulumi.getter
+    def parsed(self) -> Optional[Any]:
+        """
+        stdout of the command decoded according to its `outputFormat`
+        """
+        return pulumi.get(self, "parsed")
+
+    @property
+    @pulumi.getter
+    def stderr(self) -> str:
+        """
+        stderr of the command
+        """
+        return pulumi.get(self, "stderr")
+
+    @property
+    @pulumi.getter
+    def stdout(self) -> str:
+        """
+        stdout of the command
+        """
+        return pulumi.get(self, "stdout")
+
+
+class AwaitableRunResult(RunResult):
+    # pylint: disable=using-constant-test
+    def __await__(self):
+        if False:
+            yield self
+        return RunResult(
+            attempts=self.attempts,
+            exit_code=self.exit_code,
+            image_digest=self.image_digest,
+            parsed=self.parsed,
+            stderr=self.stderr,
+            stdout=self.stdout)
+
+
+def run(allowed_exit_codes: Optional[Sequence[int]] = None,
+        command: Optional[Sequence[str]] = None,
+        dir: Optional[str] = None,
+        env_allowlist: Optional[Sequence[str]] = None,
+        environment: Optional[Mapping[str, str]] = None,
+        image: Optional[pulumi.InputType['Image']] = None,
+        inherit_env: Optional[str] = None,
+        interpreter: Optional[str] = None,
+        output_format: Optional[str] = None,
+        retry: Optional[pulumi.InputType['Retry']] = None,
+        sandbox: Optional[pulumi.InputType['Sandbox']] = None,
+        script: Optional[str] = None,
+        script_file: Optional[bool] = None,
+        stdin: Optional[str] = None,
+        stop_grace_period: Optional[str] = None,
+        stop_signal: Optional[str] = None,
+        timeout: Optional[str] = None,
+        unset_env: Optional[Sequence[str]] = None,
+        opts: Optional[pulumi.InvokeOptions] = None) -> AwaitableRunResult:
+    """
+    Run a command and return its output. Use this to look up data without creating a resource. The command runs whenever the program runs, including during previews, so it should not have side effects.
+
+
+    :param Sequence[int] allowed_exit_codes: Non-zero exit codes that are treated as success.
+    :param Sequence[str] command: Specifiy the command to run as an array of arguments. Set either `command` or `script`.
+    :param str dir: The working directory to run the command in. Relative paths are resolved against the Pulumi project root.
+    :param Sequence[str] env_allowlist: The names of the variables to inherit when `inheritEnv` is `allowlist`.
+    :param pulumi.InputType['Image'] image: Run the command in a new container of this image through the Docker Engine API at `DOCKER_HOST`, which must be a unix socket and defaults to `/var/run/docker.sock`. `dir` is a path in the container. The environment of the provider is not passed to the container, only `environment`, so `inheritEnv` has no effect. Not supported with `scriptFile` or by RemoteCommand.
+    :param str inherit_env: Controls which variables of the provider's environment are passed to the command. One of `all` (the default), `allowlist` to only pass the variables named in `envAllowlist`, or `none`.
+           
+           The inherited variables, minus those named in `unsetEnv`, are merged with `environment`, which takes precedence.
+    :param str interpreter: The interpreter of `script`: `sh` (the default), `bash`, `python3` or `pwsh`.
+    :param str output_format: Decode stdout into the `parsed` output. One of `text`, `json`, `yaml`, `dotenv` (an object of `KEY=VALUE` lines) or `lines` (an array of lines). The command fails if stdout cannot be parsed.
+    :param pulumi.InputType['Retry'] retry: Retry the command when it fails. Timeouts and cancellation are not retried.
+    :param pulumi.InputType['Sandbox'] sandbox: Run the command in a sandbox on Linux. The command sees the file system read-only, except for a scratch directory in `TMPDIR`, `/dev` and `/proc`, has no network unless `network` is set, cannot gain privileges, and is subject to the resource limits that are set. Not supported with `image` or by RemoteCommand.
+    :param str script: A script to run with `interpreter` instead of `command`. POSIX shell scripts run with `set -euo pipefail`.
+    :param bool script_file: Run `script` from a private temporary file instead of passing it as an argument. A script starting with `#!` runs with the interpreter its shebang names. Errors refer to the file as `<operation> script`, with its line numbers.
+    :param str stdin: Pass the stdin to a command
+    :param str stop_grace_period: How long the command is given to exit after `stopSignal` before its process group is killed, as a duration. Defaults to `10s`.
+    :param str stop_signal: The signal sent to the command's process group when it times out or the deployment is cancelled. One of `SIGTERM` (the default), `SIGINT`, `SIGHUP`, `SIGQUIT` or `SIGKILL`. Ignored on Windows, where the command is always killed.
+    :param str timeout: The maximum time the command may run, as a duration such as `30s` or `5m`. When the resource's `customTimeouts` are shorter, they apply instead. On timeout the command's process group is sent `stopSignal` and then SIGKILL if it has not exited after `stopGracePeriod`.
+    :param Sequence[str] unset_env: The names of inherited variables to remove from the command's environment.
+    """
+    __args__ = dict()
+    __args__['allowedExitCodes'] = allowed_exit_codes
+    __args__['command'] = command
+    __args__['dir'] = dir
+    __args__['envAllowlist'] = env_allowlist
+    __args__['environment'] = environment
+    __args__['image'] = image
+    __args__['inheritEnv'] = inherit_env
+    __args__['interpreter'] = interpreter
+    __args__['outputFormat'] = output_format
+    __args__['retry'] = retry
+    __args__['sandbox'] = sandbox
+    __args__['script'] = script
+    __args__['scriptFile'] = script_file
+    __args__['stdin'] = stdin
+    __args__['stopGracePeriod'] = stop_grace_period
+    __args__['stopSignal'] = stop_signal
+    __args__['timeout'] = timeout
+    __args__['unsetEnv'] = unset_env
+    if opts is None:
+        opts = pulumi.InvokeOptions()
+    if opts.version is None:
+        opts.version = _utilities.get_version()
+    __ret__ = pulumi.runtime.invoke('command:v1:run', __args__, opts=opts, typ=RunResult).value
+
+    return AwaitableRunResult(
+        attempts=__ret__.attempts,
+        exit_code=__ret__.exit_code,
+        image_digest=__ret__.image_digest,
+        parsed=__ret__.parsed,
+        stderr=__ret__.stderr,
+        stdout=__ret__.stdout)